@@ -18,6 +18,7 @@ package collection
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
 	"github.com/dcjulian29/go-toolbox/textformat"
@@ -32,15 +33,32 @@ import (
 //	ansible-dev collection add <collection> [flags]
 //
 // The positional argument <collection> is the fully qualified collection
-// name (e.g. "community.general"). If no --source flag is provided, the
-// collection name is used as the source, implying it will be fetched from
-// Ansible Galaxy. The collection type is always set to "galaxy".
+// name (e.g. "community.general") or, with a --type other than "galaxy",
+// the repository URL, archive URL or path to install from. Without --type
+// ansible-galaxy installs the collection from Galaxy.
+//
+// Adding is idempotent: when the collection is already listed its entry is
+// updated in place with the given flags, and nothing is written when they
+// already match. requirements.yml is edited as a YAML node tree (see
+// [ansible.RequirementsFile]), so comments, the order of the entries and
+// keys ansible-dev does not know are kept. The resulting entry is
+// validated first (see [ansible.Collection.Validate]); for example a
+// Galaxy collection name that is not "namespace.name" is rejected. With
+// the global --dry-run flag the change is shown as a unified diff.
 //
 // Flags:
-//   - --source, -s: override the source URL or Galaxy reference for the
-//     collection. Defaults to the collection name when omitted.
-//   - --version, -v: pin the collection to a specific version string.
-//     Defaults to the empty string (latest).
+//   - --server:        the Galaxy server URL, or a server name from
+//     galaxy_server_list in ansible.cfg, to download the collection from
+//     (the "source" key). Only valid for Galaxy collections.
+//   - --source, -s:    deprecated alias of --server.
+//   - --type, -t:      the source type: galaxy, git, url, file, dir or
+//     subdirs.
+//   - --signature:     a detached signature URL or file used to verify the
+//     collection; repeat it for several. Replaces the current signatures.
+//   - --version, -v:   pin the collection to a specific version string,
+//     or the branch, tag or commit of a git source. Defaults to the empty
+//     string (latest), which keeps the current version of a collection
+//     that is already listed.
 //
 // The command prints a reminder that the collection must be restored
 // (installed) before it can be used. If no argument is supplied, the help
@@ -59,29 +77,47 @@ func addCmd() *cobra.Command {
 
 			name := args[0]
 
-			source, _ := cmd.Flags().GetString("source")
+			server, _ := cmd.Flags().GetString("server")
 			version, _ := cmd.Flags().GetString("version")
+			kind, _ := cmd.Flags().GetString("type")
+			signatures, _ := cmd.Flags().GetStringArray("signature")
 
-			if len(name) > 0 && len(source) == 0 {
-				source = name // Ansible Galaxy Collection
+			if source, _ := cmd.Flags().GetString("source"); len(server) == 0 {
+				server = source
 			}
 
-			requirements, _ := ansible.ReadRequirements()
+			requirements, err := ansible.LoadRequirementsFile()
+			if err != nil {
+				return err
+			}
 
-			collection := ansible.Collection{
-				Name:    name,
-				Source:  source,
-				Type:    "galaxy",
-				Version: version,
+			exists := requirements.HasCollection(name)
+
+			err = requirements.SetCollection(ansible.Collection{
+				Name:       name,
+				Source:     server,
+				Type:       kind,
+				Version:    version,
+				Signatures: signatures,
+			})
+			if err != nil {
+				return err
 			}
 
-			requirements.Collections = append(requirements.Collections, collection)
+			if !requirements.Changed() {
+				fmt.Println(textformat.Info(fmt.Sprintf("collection '%s' is already in requirements.yml", name)))
+				return nil
+			}
 
-			if err := ansible.SaveRequirements(requirements); err != nil {
+			if err := requirements.Save(); err != nil {
 				return err
 			}
 
 			msg := fmt.Sprintf("collection '%s' added to requirements.yml but must be restored before use", name)
+			if exists {
+				msg = fmt.Sprintf("collection '%s' updated in requirements.yml but must be restored before use", name)
+			}
+
 			fmt.Println(textformat.Info(msg))
 
 			return nil
@@ -91,8 +127,13 @@ func addCmd() *cobra.Command {
 		},
 	}
 
-	cmd.Flags().StringP("source", "s", "", "source of the collection")
+	cmd.Flags().String("server", "", "Galaxy server URL or name to install the collection from")
+	cmd.Flags().StringP("source", "s", "", "Galaxy server of the collection")
+	cmd.Flags().StringP("type", "t", "", "source type: "+strings.Join(ansible.CollectionTypes, ", "))
+	cmd.Flags().StringArray("signature", nil, "detached signature URL or file (repeatable)")
 	cmd.Flags().StringP("version", "v", "", "version of the collection")
 
+	_ = cmd.Flags().MarkDeprecated("source", "use --server instead")
+
 	return cmd
 }
@@ -37,7 +37,7 @@ import (
 // The following subcommands are registered:
 //   - add:    add a new collection to requirements.yml.
 //   - list:   list all collections declared in requirements.yml.
-//   - purge:  remove all installed collection artifacts.
+//   - purge:  remove installed collection artifacts.
 //   - remove: remove a collection from requirements.yml.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
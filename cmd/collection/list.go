@@ -20,7 +20,7 @@ import (
 	"os"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/tw"
 	"github.com/spf13/cobra"
@@ -71,7 +71,7 @@ func listCmd() *cobra.Command {
 				for _, c := range requirements.Collections {
 					row := []string{c.Name, c.Source, c.Type, c.Version}
 					if err := table.Append(row); err != nil {
-						return nil
+						return err
 					}
 				}
 
@@ -84,7 +84,7 @@ func listCmd() *cobra.Command {
 				param = []string{"collection", "list", "-v"}
 			}
 
-			err := execute.ExternalProgram("ansible-galaxy", param...)
+			err := runner.Run("ansible-galaxy", param...)
 			if err != nil {
 				return err
 			}
@@ -19,74 +19,198 @@ package collection
 import (
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/prompt"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
-	"gopkg.in/ini.v1"
 )
 
 // purgeCmd creates the Cobra command for "ansible-dev collection purge",
-// which deletes all installed Ansible collection files from the development
-// environment.
+// which deletes installed Ansible collection files from the development
+// environment. requirements.yml is not modified; use "collection remove"
+// for that.
 //
-// The command reads the "collections_path" key from the [defaults] section
-// of ansible.cfg, appends the standard "ansible_collections" subdirectory,
-// and recursively removes that directory tree. A confirmation message is
-// printed to stdout on success.
+// Usage:
 //
-// Note: despite the Use string showing "<collection>", this command does
-// not accept a positional argument — it unconditionally purges the entire
-// ansible_collections directory.
+//	ansible-dev collection purge <namespace.name>...
+//	ansible-dev collection purge --all [--yes]
+//	ansible-dev collection purge --unused
 //
-// An error is returned if:
-//   - ansible.cfg cannot be loaded or is missing the [defaults] section.
-//   - The "collections_path" key is not defined.
-//   - The ansible_collections directory does not exist.
-//   - The directory cannot be removed.
+// Exactly one of the following selects what is removed from the
+// collections path (see [ansible.CollectionsFolder]):
+//   - <namespace.name>...: the named collections and their
+//     "<namespace>.<name>-<version>.info" install records. A namespace
+//     directory left empty is removed as well.
+//   - --all:    the entire ansible_collections directory, after a
+//     confirmation prompt unless --yes is given.
+//   - --unused: the installed collections that requirements.yml neither
+//     declares nor needs as a dependency (see
+//     [ansible.UnusedCollections]).
+//
+// An error is returned if no or more than one selection is given, a name
+// is not a valid collection name or the collection is not installed
+// (nothing is removed in those cases), the
+// ansible_collections directory does not exist for --all, the prompt is
+// declined, or a directory cannot be removed.
 //
 // A PreRunE hook calls [ansible.EnsureAnsibleDirectory] to verify the
 // current directory is a valid Ansible project.
 func purgeCmd() *cobra.Command {
+	var all, unused, yes bool
+
 	cmd := &cobra.Command{
-		Use:   "purge <collection>",
-		Short: "Purge all Ansible collection files from the development environment",
-		RunE: func(_ *cobra.Command, _ []string) error {
-			cfg, err := ini.Load("ansible.cfg")
-			if err != nil {
-				return err
+		Use:   "purge [<namespace.name>...]",
+		Short: "Purge installed Ansible collection files from the development environment",
+		RunE: func(_ *cobra.Command, args []string) error {
+			selected := 0
+
+			for _, set := range []bool{len(args) > 0, all, unused} {
+				if set {
+					selected++
+				}
 			}
 
-			section, err := cfg.GetSection("defaults")
-			if err != nil {
-				return err
+			if selected != 1 {
+				return errors.New("give collection names, --all or --unused")
 			}
 
-			path, err := section.GetKey("collections_path")
+			folder, err := ansible.CollectionsFolder()
 			if err != nil {
 				return err
 			}
 
-			folder := filepath.Join(path.String(), "ansible_collections")
-
-			if !filesystem.DirectoryExist(folder) {
-				return errors.New("collections files do not exists")
+			if all {
+				return purgeAll(folder, yes)
 			}
 
-			if err := filesystem.RemoveDirectory(folder); err != nil {
-				return err
-			}
+			names := args
+
+			if unused {
+				collections, err := ansible.UnusedCollections()
+				if err != nil {
+					return err
+				}
+
+				if len(collections) == 0 {
+					fmt.Println(textformat.Info("no unused collections are installed"))
+					return nil
+				}
 
-			fmt.Println(textformat.Info("collections files were purged"))
+				names = []string{}
 
-			return nil
+				for _, c := range collections {
+					names = append(names, c.Name)
+				}
+			}
+
+			return purge(folder, names)
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			return ansible.EnsureAnsibleDirectory()
 		},
 	}
 
+	cmd.Flags().BoolVar(&all, "all", false, "purge every installed collection")
+	cmd.Flags().BoolVar(&unused, "unused", false, "purge the installed collections not needed by requirements.yml")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "do not ask for confirmation with --all")
+
 	return cmd
 }
+
+// purgeAll removes the ansible_collections directory folder once the user
+// confirms it.
+func purgeAll(folder string, yes bool) error {
+	if !filesystem.DirectoryExist(folder) {
+		return errors.New("collections files do not exists")
+	}
+
+	if !yes && !runner.IsDryRun() &&
+		!prompt.Confirm(textformat.Yellow(fmt.Sprintf("Purge every collection in '%s'?", folder))) {
+		return errors.New("purge cancelled")
+	}
+
+	if err := runner.Remove(folder); err != nil {
+		return err
+	}
+
+	fmt.Println(textformat.Info("collections files were purged"))
+
+	return nil
+}
+
+// purge removes the collections names from the ansible_collections
+// directory folder, together with their install info directories and the
+// namespace directories they leave empty. Every name is checked with
+// [ansible.SplitCollectionName], so that it cannot reach outside its
+// namespace, before anything is removed.
+func purge(folder string, names []string) error {
+	names = slices.Compact(slices.Sorted(slices.Values(names)))
+	dirs := make([]string, 0, len(names))
+	count := map[string]int{}
+
+	for _, name := range names {
+		namespace, collection, err := ansible.SplitCollectionName(name)
+		if err != nil {
+			return err
+		}
+
+		dir := filepath.Join(folder, namespace, collection)
+		if !filesystem.DirectoryExist(dir) {
+			return fmt.Errorf("collection '%s' is not installed", name)
+		}
+
+		dirs = append(dirs, dir)
+		count[filepath.Dir(dir)]++
+	}
+
+	emptied := []string{}
+
+	for namespace, n := range count {
+		entries, err := os.ReadDir(namespace)
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == n {
+			emptied = append(emptied, namespace)
+		}
+	}
+
+	for i, dir := range dirs {
+		if err := runner.Remove(dir); err != nil {
+			return err
+		}
+
+		// ansible-galaxy records each install in a
+		// "<namespace>.<name>-<version>.info" directory next to the
+		// namespaces.
+		info, err := filepath.Glob(filepath.Join(folder, names[i]+"-*.info"))
+		if err != nil {
+			return err
+		}
+
+		for _, dir := range info {
+			if err := runner.Remove(dir); err != nil {
+				return err
+			}
+		}
+
+		fmt.Println(textformat.Info(fmt.Sprintf("collection '%s' was purged", names[i])))
+	}
+
+	slices.Sort(emptied)
+
+	for _, namespace := range emptied {
+		if err := runner.Remove(namespace); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
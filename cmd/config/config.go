@@ -0,0 +1,51 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config implements the "ansible-dev config" command group, which
+// inspects and edits the layered ansible-dev configuration. Available
+// subcommands are show, get, and set.
+package config
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates and returns the Cobra command for the "config" command
+// group.
+//
+// When invoked without a subcommand it prints the help text. The
+// configuration itself is loaded by the root command before any subcommand
+// executes, so every subcommand sees the effective values.
+//
+// The following subcommands are registered:
+//   - show: list every effective value and the layer that supplied it.
+//   - get:  print the effective value of a single key.
+//   - set:  store a value in the project or user configuration file.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Show or change the ansible-dev configuration",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(getCmd())
+	cmd.AddCommand(setCmd())
+	cmd.AddCommand(showCmd())
+
+	return cmd
+}
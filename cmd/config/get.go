@@ -0,0 +1,63 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// getCmd creates the Cobra command for "ansible-dev config get", which
+// prints the effective value of a single configuration key.
+//
+// Usage:
+//
+//	ansible-dev config get <key> [flags]
+//
+// Flags:
+//   - --source: also print the layer that supplied the value
+//     (default false).
+//
+// An error is returned if the key is unknown.
+func getCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the effective value of a configuration key",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v, ok := config.Lookup(args[0])
+			if !ok {
+				return fmt.Errorf("unknown configuration key '%s'", args[0])
+			}
+
+			if source, _ := cmd.Flags().GetBool("source"); source {
+				fmt.Printf("%s (%s)\n", config.FormatValue(v.Value), v.Source)
+				return nil
+			}
+
+			fmt.Println(config.FormatValue(v.Value))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool("source", false, "also print where the value came from")
+
+	return cmd
+}
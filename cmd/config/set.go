@@ -0,0 +1,73 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/spf13/cobra"
+)
+
+// setCmd creates the Cobra command for "ansible-dev config set", which
+// stores a configuration value in a configuration file.
+//
+// Usage:
+//
+//	ansible-dev config set <key> <value> [flags]
+//
+// The value is parsed as YAML, so numbers stay numbers and lists may be
+// given in flow form (e.g. "[a, b]"). By default the value is written to
+// the project file (.ansible-dev.yml in the current directory).
+//
+// Flags:
+//   - --global, -g: write to the user configuration file
+//     (~/.config/ansible-dev/config.yml) instead (default false).
+//
+// An error is returned if the key is unknown or the file cannot be written.
+func setCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Store a configuration value in the project or user configuration file",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file := config.ProjectFile
+
+			if global, _ := cmd.Flags().GetBool("global"); global {
+				user, err := config.UserFile()
+				if err != nil {
+					return err
+				}
+
+				file = user
+			}
+
+			if err := config.SetValue(file, args[0], args[1]); err != nil {
+				return err
+			}
+
+			fmt.Println(textformat.Info(fmt.Sprintf("'%s' set in %s", args[0], file)))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolP("global", "g", false, "write to the user configuration file")
+
+	return cmd
+}
@@ -0,0 +1,52 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// showCmd creates the Cobra command for "ansible-dev config show", which
+// renders a table of every configuration key with its effective value and
+// the layer that supplied it (default, user file, project file, environment
+// variable, or --set flag).
+func showCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show",
+		Short: "Show the effective configuration and where each value came from",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+			table.Header("Key", "Value", "Source")
+
+			for _, v := range config.Values() {
+				row := []string{v.Key, config.FormatValue(v.Value), v.Source}
+				if err := table.Append(row); err != nil {
+					return err
+				}
+			}
+
+			return table.Render()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package console implements the "ansible-dev console" command, which
+// opens a shell on a development host through the backend's own channel
+// ("vagrant ssh" for VMs, "docker exec" or "podman exec" for containers)
+// rather than the connection details in hosts.ini.
+package console
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var become bool
+
+// NewCommand creates and returns the Cobra command for
+// "ansible-dev console".
+//
+// Usage:
+//
+//	ansible-dev console <vm> [flags] [-- <command>]
+//
+// The command delegates to [backend.Backend.Console] of the configured
+// backend. It works before hosts.ini has been updated with a host's
+// connection details, which makes it useful when "ansible-dev ssh" cannot
+// connect. Everything after the "--" separator is run on the host instead
+// of an interactive shell. VM names are completed from the inventory.
+//
+// Flags:
+//   - --become, -b: open a root shell, or run the command as root
+//     (default false).
+//
+// A PreRunE hook performs two checks before execution:
+//  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
+//     is a valid Ansible project.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "console <vm> [flags] [-- <command>]",
+		Short: "Open a shell on a host of the Ansible development environment",
+		Args:  cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return ansible.HostNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			if _, err := ansible.SelectHosts(args[:1]); err != nil {
+				return err
+			}
+
+			b, err := backend.Current()
+			if err != nil {
+				return err
+			}
+
+			return b.Console(args[0], strings.Join(args[1:], " "), become)
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			return backend.Ensure()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&become, "become", "b", false, "open a root shell or run the command as root")
+
+	return cmd
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package copyfile implements the "ansible-dev copy" command, which copies
+// a local file or directory to the hosts of the development environment
+// with the Ansible "copy" module.
+package copyfile
+
+import (
+	"errors"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	become bool
+	limit  string
+)
+
+// NewCommand creates and returns the Cobra command for "ansible-dev copy".
+//
+// Usage:
+//
+//	ansible-dev copy <local> <remote> [flags]
+//
+// Under the hood, the command invokes:
+//
+//	ansible -i hosts.ini -m copy -a '{"dest":"<remote>","src":"<local>"}' <pattern>
+//
+// via [ansible.RunModule], with the arguments given as JSON (see
+// [ansible.ModuleArgs]) so that paths with spaces or "=" are kept whole.
+// The results are read through the JSON stdout callback and printed as a
+// per-host summary stating whether the file changed (see
+// [ansible.SummarizeAdHoc]). As with the copy module, a <local> directory
+// ending in "/" copies its contents rather than the directory itself.
+//
+// Flags:
+//   - --limit, -l:  Ansible host pattern selecting the hosts (default
+//     "all").
+//   - --become, -b: write the file as root, e.g. below /etc
+//     (default false).
+//
+// A PreRunE hook performs two checks before execution:
+//  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
+//     is a valid Ansible project.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "copy <local> <remote>",
+		Short: "Copy a file to the hosts of the Ansible development environment",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			results, err := ansible.RunModule("copy", ansible.ModuleArgs(map[string]string{"src": args[0], "dest": args[1]}), limit, become)
+
+			ansible.SummarizeAdHoc(results, "copied")
+
+			return err
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			return backend.Ensure()
+		},
+	}
+
+	cmd.Flags().StringVarP(&limit, "limit", "l", "all", "only copy to hosts matching the pattern")
+	cmd.Flags().BoolVarP(&become, "become", "b", false, "write the file as root")
+
+	return cmd
+}
@@ -23,21 +23,29 @@ import (
 	"errors"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
 	"github.com/spf13/cobra"
 )
 
+var vms []string
+
 // NewCommand creates and returns the Cobra command for "ansible-dev destroy".
 //
-// The command delegates to [vagrant.Destroy], which force-destroys all
-// managed VMs and removes the ansible.log, .vagrant, and .tmp artifacts
-// from the current directory.
+// The command delegates to [backend.Backend.Destroy] of the configured
+// backend (for Vagrant, [vagrant.Destroy]), which force-destroys all
+// managed hosts and removes the ansible.log, .vagrant, and .tmp artifacts
+// from the current directory, and then clears the record of applied roles
+// via [ansible.ClearProvisioning].
+//
+// Flags:
+//   - --vm: only destroy the named VM, keeping the other VMs and the
+//     shared artifacts. May be repeated (default: every VM).
 //
 // A PreRunE hook performs two validations before execution:
 //  1. Calls [ansible.EnsureAnsibleDirectory] to confirm the current
 //     directory contains an ansible.cfg file.
-//  2. Calls [vagrant.EnsureVagrantfile] to confirm a Vagrantfile is
-//     present.
+//  2. Calls [backend.Ensure] to confirm the configured backend can be
+//     used (for Vagrant, that a Vagrantfile is present).
 //
 // An error is returned if either pre-flight check fails or if the
 // destroy operation itself encounters a failure.
@@ -46,16 +54,31 @@ func NewCommand() *cobra.Command {
 		Use:   "destroy",
 		Short: "Destroy the Ansible development vagrant environment",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return vagrant.Destroy()
+			if _, err := ansible.SelectHosts(vms); err != nil {
+				return err
+			}
+
+			b, err := backend.Current()
+			if err != nil {
+				return err
+			}
+
+			if err := b.Destroy(vms...); err != nil {
+				return err
+			}
+
+			return ansible.ClearProvisioning(vms...)
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := ansible.EnsureAnsibleDirectory(); err != nil {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
+	cmd.Flags().StringSliceVar(&vms, "vm", []string{}, "only destroy the specified VM(s)")
+
 	return cmd
 }
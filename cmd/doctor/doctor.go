@@ -0,0 +1,59 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package doctor implements the "ansible-dev doctor" command group, which
+// diagnoses and repairs inconsistencies in the Ansible development
+// environment. Available subcommands include inventory.
+package doctor
+
+import (
+	"errors"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates and returns the Cobra command for the "doctor"
+// command group.
+//
+// When invoked without a subcommand it prints the help text.
+//
+// The following subcommands are registered:
+//   - inventory: compare the machines with hosts.ini and host_vars/.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the Ansible development environment",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(inventoryCmd())
+
+	return cmd
+}
+
+// ensure verifies that the current directory is an Ansible development
+// directory whose backend can be used.
+func ensure(_ *cobra.Command, _ []string) error {
+	if err := ansible.EnsureAnsibleDirectory(); err != nil {
+		return errors.New("not an Ansible development directory")
+	}
+
+	return backend.Ensure()
+}
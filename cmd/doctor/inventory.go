@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doctor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/doctor"
+	"github.com/spf13/cobra"
+)
+
+// inventoryCmd creates the Cobra command for
+// "ansible-dev doctor inventory".
+//
+// Usage:
+//
+//	ansible-dev doctor inventory [--fix] [--output json]
+//
+// Compares the machine names of the backend (for Vagrant, from
+// "vagrant status --machine-readable") with the hosts of the [vagrant]
+// group of hosts.ini and the host_vars/ files, and reports machines missing
+// from the inventory, inventory hosts that are not machines, machines
+// without host_vars and host_vars files without a host (see
+// [doctor.CheckInventory]). "ansible-dev start" and "ansible-dev reset"
+// run the same check before starting any machine.
+//
+// Flags:
+//   - --fix:        reconcile the inventory with the machines (see
+//     [doctor.FixInventory]) and create the missing host_vars stubs.
+//   - --output, -o: "text" (default) or "json".
+//
+// Without --fix the command fails when an inconsistency is found, so that
+// it can be used in scripts.
+func inventoryCmd() *cobra.Command {
+	var (
+		fix    bool
+		output string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "inventory",
+		Short: "Compare the machines with the inventory and host_vars",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			report, err := doctor.CheckInventory()
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				data, err := json.MarshalIndent(report, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(data))
+			} else {
+				report.Print(os.Stdout)
+			}
+
+			if report.OK() {
+				return nil
+			}
+
+			if fix {
+				return doctor.FixInventory(report)
+			}
+
+			return errors.New("the inventory needs attention; run with --fix to reconcile it")
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if output != "text" && output != "json" {
+				return fmt.Errorf("unsupported output format '%s' (text, json)", output)
+			}
+
+			return ensure(cmd, args)
+		},
+	}
+
+	cmd.Flags().BoolVar(&fix, "fix", false, "reconcile the inventory and host_vars with the machines")
+	cmd.Flags().StringVarP(&output, "output", "o", "text", "output format: text or json")
+
+	return cmd
+}
@@ -0,0 +1,100 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fetch implements the "ansible-dev fetch" command, which copies a
+// file from the hosts of the development environment to a local directory
+// with the Ansible "fetch" module.
+package fetch
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/spf13/cobra"
+)
+
+var (
+	become bool
+	limit  string
+)
+
+// NewCommand creates and returns the Cobra command for "ansible-dev fetch".
+//
+// Usage:
+//
+//	ansible-dev fetch <remote> <localdir> [flags]
+//
+// Under the hood, the command invokes:
+//
+//	ansible -i hosts.ini -m fetch -a '{"dest":"<localdir>/","src":"<remote>"}' <pattern>
+//
+// via [ansible.RunModule], with the arguments given as JSON (see
+// [ansible.ModuleArgs]) so that paths with spaces or "=" are kept whole.
+// The fetch module lays the files out per host, so "/etc/hosts" fetched
+// from "debian" is saved as "<localdir>/debian/etc/hosts". The results
+// are read through the JSON stdout callback and printed as a per-host
+// summary stating whether the local copy changed (see
+// [ansible.SummarizeAdHoc]).
+//
+// Flags:
+//   - --limit, -l:  Ansible host pattern selecting the hosts (default
+//     "all").
+//   - --become, -b: read the file as root, e.g. for logs only root can
+//     read (default false).
+//
+// A PreRunE hook performs two checks before execution:
+//  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
+//     is a valid Ansible project.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "fetch <remote> <localdir>",
+		Short: "Fetch a file from the hosts of the Ansible development environment",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			dest, err := filepath.Abs(args[1])
+			if err != nil {
+				return err
+			}
+
+			if !strings.HasSuffix(dest, string(filepath.Separator)) {
+				dest += string(filepath.Separator)
+			}
+
+			results, err := ansible.RunModule("fetch", ansible.ModuleArgs(map[string]string{"src": args[0], "dest": dest}), limit, become)
+
+			ansible.SummarizeAdHoc(results, "fetched to "+filepath.Join(args[1], "<host>", args[0]))
+
+			return err
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			return backend.Ensure()
+		},
+	}
+
+	cmd.Flags().StringVarP(&limit, "limit", "l", "all", "only fetch from hosts matching the pattern")
+	cmd.Flags().BoolVarP(&become, "become", "b", false, "read the file as root")
+
+	return cmd
+}
@@ -26,7 +26,9 @@ import (
 	"fmt"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
 	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
 )
@@ -43,19 +45,22 @@ var force bool
 //	.ansible-lint          – ansible-lint profile and rule configuration
 //	collections/           – empty directory for Galaxy collections
 //	group_vars/vagrant.yml – group variables for the [vagrant] inventory group
-//	host_vars/debian.yml   – host variables for the "debian" VM
-//	host_vars/alma.yml     – host variables for the "alma" VM
+//	host_vars/<name>.yml   – host variables for each configured machine
 //	hosts.ini              – INI inventory with [vagrant] and [all:vars] sections
 //	playbooks/runbook.yml  – skeleton runbook playbook
 //	roles/                 – empty directory for Ansible roles
 //	.yamlignore            – files excluded from YAML linting (secrets.yml)
 //	.yamlint               – yamllint configuration
-//	Vagrantfile            – multi-VM Vagrant config (Debian + AlmaLinux, VirtualBox)
+//	Vagrantfile            – multi-VM Vagrant config for the configured machines
+//
+// The machines, their boxes, and the VM resources come from the "machines"
+// and "vm" configuration values, which default to a Debian and an AlmaLinux
+// VM with 2 CPUs and 4 GB of memory each.
 //
 // If ansible.cfg already exists and --force is not set, the command returns
 // an error without modifying any files. When --force is set, every file is
-// re-created (or left as-is by the underlying EnsureFileExist helper if
-// unchanged).
+// re-created. Files and directories are written through the active
+// [runner.Runner], so with --dry-run they are printed instead.
 //
 // Flags:
 //   - --force, -f: overwrite an existing development environment
@@ -70,7 +75,7 @@ func NewCommand() *cobra.Command {
 		RunE: func(_ *cobra.Command, _ []string) error {
 			fmt.Println(textformat.Yellow("Initializing development environment..."))
 
-			if filesystem.FileExist("ansible.cfg") && !force {
+			if runner.FileExist("ansible.cfg") && !force {
 				return errors.New("ansible development environment already exist and force was not provided")
 			}
 
@@ -83,7 +88,7 @@ func NewCommand() *cobra.Command {
 			}
 
 			fmt.Println("  ...  collections/")
-			if err := filesystem.EnsureDirectoryExist("collections"); err != nil {
+			if err := runner.MkdirAll("collections"); err != nil {
 				return err
 			}
 
@@ -108,7 +113,7 @@ func NewCommand() *cobra.Command {
 			}
 
 			fmt.Println("  ...  roles/")
-			if err := filesystem.EnsureDirectoryExist("roles"); err != nil {
+			if err := runner.MkdirAll("roles"); err != nil {
 				return err
 			}
 
@@ -157,7 +162,7 @@ callback_result_format      = yaml
 verbosity                   = 1
 `)
 
-	if err := filesystem.EnsureFileExist("ansible.cfg", content); err != nil {
+	if err := runner.WriteFile("ansible.cfg", content); err != nil {
 		return err
 	}
 
@@ -192,7 +197,7 @@ skip_list:
   - experimental
 `)
 
-	if err := filesystem.EnsureFileExist(".ansible-lint", content); err != nil {
+	if err := runner.WriteFile(".ansible-lint", content); err != nil {
 		return err
 	}
 
@@ -202,12 +207,14 @@ skip_list:
 // gitIgnore creates the ".gitignore" file for the development environment.
 // It excludes runtime-modified and generated files that should not be
 // committed: hosts.ini (rewritten on every vagrant up by ansible-dev),
-// ansible.log, .vagrant/, .tmp/, and other build-time Ansible directories.
+// ansible.log, .vagrant/, .tmp/, the .ansible-dev/ state folder, and other
+// build-time Ansible directories.
 func gitIgnore() error {
 	fmt.Println(" ... .gitignore")
 
 	content := []byte(`.vagrant/
 .ansible/
+.ansible-dev/
 .tmp/
 .vagrant/
 ansible.log
@@ -216,7 +223,7 @@ hosts.ini
 roles/
 `)
 
-	if err := filesystem.EnsureFileExist(".gitignore", content); err != nil {
+	if err := runner.WriteFile(".gitignore", content); err != nil {
 		return err
 	}
 
@@ -230,7 +237,7 @@ roles/
 // An error is returned if the directory or file cannot be created.
 func groupVariables() error {
 	fmt.Println("  ...  group_vars/")
-	if err := filesystem.EnsureDirectoryExist("group_vars"); err != nil {
+	if err := runner.MkdirAll("group_vars"); err != nil {
 		return err
 	}
 
@@ -238,38 +245,32 @@ func groupVariables() error {
 
 	content := []byte("---\nvarname: value")
 
-	if err := filesystem.EnsureFileExist("group_vars/vagrant.yml", content); err != nil {
+	if err := runner.WriteFile("group_vars/vagrant.yml", content); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// hostVariables creates the "host_vars/" directory and starter variable
-// files for each VM defined in the default Vagrantfile:
-//   - host_vars/debian.yml – variables for the "debian" VM.
-//   - host_vars/alma.yml   – variables for the "alma" VM.
+// hostVariables creates the "host_vars/" directory and a starter variable
+// file, host_vars/<name>.yml, for each configured machine.
 //
 // Each file contains a single placeholder variable. An error is returned
 // if the directory or any file cannot be created.
 func hostVariables() error {
 	fmt.Println("  ...  host_vars/")
-	if err := filesystem.EnsureDirectoryExist("host_vars"); err != nil {
+	if err := runner.MkdirAll("host_vars"); err != nil {
 		return err
 	}
 
 	content := []byte("---\nvarname: value")
 
-	fmt.Println("  ...    debian.yml")
+	for _, m := range config.Current().Machines {
+		fmt.Printf("  ...    %s.yml\n", m.Name)
 
-	if err := filesystem.EnsureFileExist("host_vars/debian.yml", content); err != nil {
-		return err
-	}
-
-	fmt.Println("  ...    alma.yml")
-
-	if err := filesystem.EnsureFileExist("host_vars/alma.yml", content); err != nil {
-		return err
+		if err := runner.WriteFile("host_vars/"+m.Name+".yml", content); err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -277,16 +278,27 @@ func hostVariables() error {
 
 // inventoryFile creates the "hosts.ini" Ansible inventory file. The
 // generated inventory defines:
-//   - A [vagrant] group with two hosts: "debian" (192.168.57.5) and
-//     "alma" (192.168.57.6).
+//   - A [vagrant] group with one host per configured machine, using a
+//     placeholder address that is replaced when the VM boots.
 //   - An [all:vars] section with SSH connection parameters configured for
 //     Vagrant (insecure private key, disabled host-key checking, port 22).
 //
+// In dynamic inventory mode the inventory script is written instead and
+// ansible.cfg is pointed at it (see [ansible.EnsureInventorySource]).
+//
 // An error is returned if the file cannot be created or written.
 func inventoryFile() error {
-	fmt.Println("  ...  hosts.ini")
+	if config.Current().Inventory.Dynamic {
+		fmt.Printf("  ...  %s\n", ansible.InventoryScript)
+	} else {
+		fmt.Println("  ...  hosts.ini")
+	}
+
+	if err := ansible.EnsureHostsIni(); err != nil {
+		return err
+	}
 
-	return ansible.EnsureHostsIni()
+	return ansible.EnsureInventorySource()
 }
 
 // runbook creates the "playbooks/" directory and a skeleton runbook
@@ -298,7 +310,7 @@ func inventoryFile() error {
 // An error is returned if the directory or file cannot be created.
 func runbook() error {
 	fmt.Println("  ...  playbooks/")
-	if err := filesystem.EnsureDirectoryExist("playbooks"); err != nil {
+	if err := runner.MkdirAll("playbooks"); err != nil {
 		return err
 	}
 
@@ -326,7 +338,7 @@ func runbook() error {
     # variables needed for runbook
 `)
 
-	if err := filesystem.EnsureFileExist("playbooks/runbook.yml", content); err != nil {
+	if err := runner.WriteFile("playbooks/runbook.yml", content); err != nil {
 		return err
 	}
 
@@ -343,7 +355,7 @@ func yamlIgnore() error {
 	content := []byte(`secrets.yml
 `)
 
-	if err := filesystem.EnsureFileExist(".yamlignore", content); err != nil {
+	if err := runner.WriteFile(".yamlignore", content); err != nil {
 		return err
 	}
 
@@ -384,93 +396,27 @@ ignore-from-file:
   - .yamlignore
 `)
 
-	if err := filesystem.EnsureFileExist(".yamlint", content); err != nil {
+	if err := runner.WriteFile(".yamlint", content); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// vagrantFile creates the "Vagrantfile" for the development environment.
-// The generated configuration defines a VirtualBox-backed, multi-VM setup
-// with the following characteristics:
-//
-//   - Shared settings: insecure SSH key insertion disabled, synced folders
-//     disabled, 600-second boot timeout, and a connectivity ping to the
-//     host at 192.168.57.1 on every provision.
-//   - VirtualBox provider: 4 CPUs, 4 GB RAM, headless mode, VMSVGA
-//     graphics, IOAPIC enabled, and guest additions checking disabled.
-//   - "debian" VM: debian/bookworm64 box at 192.168.57.5.
-//   - "alma" VM: almalinux/10 box at 192.168.57.6.
+// vagrantFile creates the "Vagrantfile" for the development environment
+// from the configured machines via [vagrant.RenderVagrantfile]. See that
+// function for the generated settings; "ansible-dev vm render" produces the
+// same file.
 //
 // An error is returned if the file cannot be created or written.
 func vagrantFile() error {
 	fmt.Println("  ...  Vagrantfile")
 
-	content := []byte(`VM_CPUS   = 2
-VM_MEMORY = 4096
-
-host = Vagrant::Util::Platform.platform
-
-if host =~ /mswin|mingw|cygwin/
-  hyperv_available = File.exist?("C:/Windows/System32/vmms.exe")
-  ENV["VAGRANT_DEFAULT_PROVIDER"] ||= (hyperv_available ? "hyperv" : "virtualbox")
-elsif host =~ /linux/
-  libvirt_ok = system("virsh --version >/dev/null 2>&1")
-  ENV["VAGRANT_DEFAULT_PROVIDER"] ||= (libvirt_ok ? "libvirt" : "virtualbox")
-else
-  ENV["VAGRANT_DEFAULT_PROVIDER"] ||= "virtualbox"
-end
-
-Vagrant.configure("2") do |config|
-  config.ssh.insert_key = false
-  if Vagrant.has_plugin?("vagrant-vbguest")
-    config.vbguest.auto_update = false
-  end
-  config.vm.boot_timeout = 3600
-  config.vm.box_check_update = true
-  config.vm.synced_folder ".", "/vagrant", disabled: true
-
-  if ENV["VAGRANT_DEFAULT_PROVIDER"] == "hyperv"
-    config.vm.network "public_network", bridge: "Default Switch"
-  end
-
-  config.vm.provider "virtualbox" do |vb|
-    vb.gui    = false
-    vb.cpus   = VM_CPUS
-    vb.memory = VM_MEMORY
-    vb.check_guest_additions = false
-    vb.customize [ "modifyvm", :id, "--uartmode1", "disconnected" ]
-    vb.customize [ "modifyvm", :id, "--graphicscontroller", "vmsvga" ]
-    vb.customize [ "modifyvm", :id, "--ioapic", "on" ]
-  end
-
-  config.vm.provider "hyperv" do |hv|
-    hv.cpus   = VM_CPUS
-    hv.memory = VM_MEMORY
-    hv.enable_enhanced_session_mode = false
-    hv.auto_start_action = "Nothing"
-  end
-
-  config.vm.provider "libvirt" do |lv|
-    lv.cpus   = VM_CPUS
-    lv.memory = VM_MEMORY
-    lv.driver = "kvm"
-  end
-
-  config.vm.define "debian" do |c|
-    c.vm.box      = "dcjulian29/debian-13"
-    c.vm.hostname = "debian.dev"
-  end
-
-  config.vm.define "alma" do |c|
-    c.vm.box      = "dcjulian29/almalinux-10"
-    c.vm.hostname = "alma.dev"
-  end
-end
-`)
+	cfg := config.Current()
+
+	content := vagrant.RenderVagrantfile(cfg.VM, cfg.Machines)
 
-	if err := filesystem.EnsureFileExist("Vagrantfile", content); err != nil {
+	if err := runner.WriteFile("Vagrantfile", content); err != nil {
 		return err
 	}
 
@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+)
+
+// dynamic prints the inventory in the JSON schema Ansible expects from an
+// inventory script: the whole inventory (see [ansible.DynamicInventory])
+// when host is empty, or the variables of host (see [ansible.DynamicHost]).
+// The structure comes from the configured machines and the addresses from
+// the running hosts of the backend (see [backend.Connections]), so nothing
+// is read from hosts.ini.
+func dynamic(host string) error {
+	b, err := backend.Current()
+	if err != nil {
+		return err
+	}
+
+	connections, err := backend.Connections(b)
+	if err != nil {
+		return err
+	}
+
+	var result any
+
+	if len(host) > 0 {
+		result = ansible.DynamicHost(connections[host])
+	} else {
+		result = ansible.DynamicInventory(config.Current().Machines, connections)
+	}
+
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(string(data))
+
+	return nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/spf13/cobra"
+)
+
+// exportFiles maps each export format to the file written under the
+// .ansible-dev/ state folder by --write.
+var exportFiles = map[string]string{
+	"ssh-config": "ssh_config",
+	"env":        "hosts.env",
+	"json":       "hosts.json",
+}
+
+// exportCmd creates the Cobra command for
+// "ansible-dev inventory export".
+//
+// Usage:
+//
+//	ansible-dev inventory export [--format ssh-config|env|json] [--write]
+//
+// Prints the connection details of every inventory host as resolved by
+// Ansible (see [ansible.HostConnections]), so that other tools such as IDE
+// remote plugins, rsync or Testinfra can reach the development hosts. The
+// formats are:
+//   - ssh-config: an OpenSSH client configuration with a Host block per
+//     host (HostName, Port, User, IdentityFile and the options of
+//     ansible_ssh_common_args). Hosts that cannot be reached with ssh are
+//     listed as comments.
+//   - env:        shell variable assignments, <HOST>_SSH_HOST,
+//     <HOST>_SSH_PORT, <HOST>_SSH_USER, <HOST>_SSH_IDENTITY_FILE and
+//     <HOST>_SSH_OPTIONS per host, plus SSH_HOSTS listing the hosts.
+//   - json:       an array with one object per host.
+//
+// Flags:
+//   - --format, -f: the output format (default "ssh-config").
+//   - --write, -w:  write the output to .ansible-dev/ssh_config,
+//     .ansible-dev/hosts.env or .ansible-dev/hosts.json instead of
+//     printing it. The SSH configuration can then be used with
+//     "ssh -F" or an "Include" line in ~/.ssh/config.
+//
+// An error is returned if the format is unsupported, ansible-inventory
+// fails, or the file cannot be written.
+func exportCmd() *cobra.Command {
+	var (
+		format string
+		write  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the connection details of the inventory hosts for other tools",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			hosts, err := ansible.HostConnections()
+			if err != nil {
+				return err
+			}
+
+			var content string
+
+			switch format {
+			case "ssh-config":
+				content = sshConfig(hosts)
+			case "env":
+				content = envFile(hosts)
+			default:
+				data, err := json.MarshalIndent(hosts, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				content = string(data) + "\n"
+			}
+
+			if !write {
+				fmt.Print(content)
+				return nil
+			}
+
+			file := filepath.Join(ansible.StateFolder, exportFiles[format])
+
+			if err := runner.WriteFile(file, []byte(content)); err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", file)
+
+			if format == "ssh-config" {
+				abs, err := filepath.Abs(file)
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(textformat.Info(fmt.Sprintf("Use it with 'ssh -F %s <host>' or add 'Include %s' to ~/.ssh/config", file, abs)))
+			}
+
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if _, ok := exportFiles[format]; !ok {
+				return fmt.Errorf("unsupported format '%s' (ssh-config, env, json)", format)
+			}
+
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "f", "ssh-config", "output format: ssh-config, env, or json")
+	cmd.Flags().BoolVarP(&write, "write", "w", false, "write the output to a file under .ansible-dev/")
+
+	return cmd
+}
+
+// sshConfig renders hosts as an OpenSSH client configuration.
+func sshConfig(hosts []ansible.HostConnection) string {
+	var b strings.Builder
+
+	b.WriteString("# Development hosts, generated by \"ansible-dev inventory export\".\n")
+
+	for _, h := range hosts {
+		if !h.Reachable() {
+			fmt.Fprintf(&b, "\n# %s is not reachable with ssh", h.Name)
+
+			if len(h.Connection) > 0 && h.Connection != "ssh" {
+				fmt.Fprintf(&b, " (connection %s)", h.Connection)
+			} else {
+				b.WriteString(" (not started)")
+			}
+
+			b.WriteString("\n")
+
+			continue
+		}
+
+		fmt.Fprintf(&b, "\nHost %s\n", h.Name)
+		fmt.Fprintf(&b, "  HostName %s\n", h.Address)
+
+		if len(h.Port) > 0 {
+			fmt.Fprintf(&b, "  Port %s\n", h.Port)
+		}
+
+		if len(h.User) > 0 {
+			fmt.Fprintf(&b, "  User %s\n", h.User)
+		}
+
+		if len(h.IdentityFile) > 0 {
+			fmt.Fprintf(&b, "  IdentityFile %s\n", sshQuote(h.IdentityFile))
+		}
+
+		for _, o := range h.SSHOptions {
+			key, value, _ := strings.Cut(o, "=")
+			fmt.Fprintf(&b, "  %s %s\n", key, sshQuote(value))
+		}
+	}
+
+	return b.String()
+}
+
+// sshQuote double-quotes an ssh_config argument containing whitespace.
+func sshQuote(v string) string {
+	if strings.ContainsAny(v, " \t") {
+		return `"` + v + `"`
+	}
+
+	return v
+}
+
+// envName matches the characters that cannot appear in a shell variable
+// name.
+var envName = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// envFile renders hosts as shell variable assignments that can be sourced.
+func envFile(hosts []ansible.HostConnection) string {
+	var (
+		b     strings.Builder
+		names []string
+	)
+
+	b.WriteString("# Development hosts, generated by \"ansible-dev inventory export\".\n")
+
+	for _, h := range hosts {
+		names = append(names, h.Name)
+		prefix := strings.ToUpper(envName.ReplaceAllString(h.Name, "_")) + "_SSH_"
+
+		options := make([]string, 0, len(h.SSHOptions))
+		for _, o := range h.SSHOptions {
+			options = append(options, "-o "+o)
+		}
+
+		b.WriteString("\n")
+
+		for _, v := range [][2]string{
+			{"HOST", h.Address},
+			{"PORT", h.Port},
+			{"USER", h.User},
+			{"IDENTITY_FILE", h.IdentityFile},
+			{"OPTIONS", strings.Join(options, " ")},
+		} {
+			fmt.Fprintf(&b, "%s%s=%s\n", prefix, v[0], runner.Quote(v[1]))
+		}
+	}
+
+	fmt.Fprintf(&b, "\nSSH_HOSTS=%s\n", runner.Quote(strings.Join(names, " ")))
+
+	return b.String()
+}
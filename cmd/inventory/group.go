@@ -0,0 +1,177 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/spf13/cobra"
+)
+
+// groupCmd creates the Cobra command for the "ansible-dev inventory group"
+// command group, which edits the groups of hosts.ini in place, keeping its
+// comments and order (see [inventory.Inventory]).
+//
+// The following subcommands are registered:
+//   - add:      add an empty group.
+//   - add-host: add existing hosts to a group.
+//   - remove:   remove a group.
+func groupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Add or remove groups of the Ansible development inventory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(groupAddCmd())
+	cmd.AddCommand(groupAddHostCmd())
+	cmd.AddCommand(groupRemoveCmd())
+
+	return cmd
+}
+
+// groupAddCmd creates the Cobra command for
+// "ansible-dev inventory group add".
+//
+// Usage:
+//
+//	ansible-dev inventory group add <group>
+//
+// The group is added without hosts, together with a group_vars/<group>.yml
+// stub as created by "ansible-dev initialize". An error is returned if the
+// group already exists.
+func groupAddCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <group>",
+		Short: "Add a group to the Ansible development inventory",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			err := edit(func(inv *inventory.Inventory) error {
+				return inv.AddGroup(args[0])
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			return stub(ansible.EnsureGroupVars, "group_vars", args[0])
+		},
+		PreRunE: ensure,
+	}
+
+	return cmd
+}
+
+// groupAddHostCmd creates the Cobra command for
+// "ansible-dev inventory group add-host".
+//
+// Usage:
+//
+//	ansible-dev inventory group add-host <group> <host>...
+//
+// Each host must already be in the inventory (see
+// "ansible-dev inventory host add") and is listed in the group as a bare
+// member; hosts the group already lists are skipped. A missing group is
+// created together with its group_vars stub.
+func groupAddHostCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-host <group> <host>...",
+		Short: "Add hosts to a group of the Ansible development inventory",
+		Args:  cobra.MinimumNArgs(2),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return completeGroups(nil, args, "")
+			}
+
+			return completeHosts(nil, nil, "")
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			group := args[0]
+
+			err := edit(func(inv *inventory.Inventory) error {
+				for _, host := range args[1:] {
+					if inv.Host(host) == nil {
+						return fmt.Errorf("'%s' is not a host in %s (use 'ansible-dev inventory host add')", host, inventory.Path())
+					}
+
+					if g := inv.Group(group); g != nil && slices.Contains(g.Hosts, host) {
+						continue
+					}
+
+					if err := inv.AddHost(group, host, nil); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			return stub(ansible.EnsureGroupVars, "group_vars", group)
+		},
+		PreRunE: ensure,
+	}
+
+	return cmd
+}
+
+// groupRemoveCmd creates the Cobra command for
+// "ansible-dev inventory group remove".
+//
+// Usage:
+//
+//	ansible-dev inventory group remove <group>
+//
+// The group is removed together with its variables and its children list,
+// and is dropped from the children of other groups. Hosts listed in other
+// groups stay in the inventory and the group_vars file is kept. The edit
+// is refused if it would orphan a VM defined in the Vagrantfile (see
+// [edit]), which is always the case for the [vagrant] group.
+func groupRemoveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "remove <group>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove a group from the Ansible development inventory",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeGroups,
+		RunE: func(_ *cobra.Command, args []string) error {
+			err := edit(func(inv *inventory.Inventory) error {
+				return inv.RemoveGroup(args[0])
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			return nil
+		},
+		PreRunE: ensure,
+	}
+
+	return cmd
+}
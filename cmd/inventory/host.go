@@ -0,0 +1,242 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/spf13/cobra"
+)
+
+// hostCmd creates the Cobra command for the "ansible-dev inventory host"
+// command group, which edits the hosts of hosts.ini in place, keeping its
+// comments and order (see [inventory.Inventory]).
+//
+// The following subcommands are registered:
+//   - add:     add a host to one or more groups.
+//   - remove:  remove a host from the inventory or from one group.
+//   - set-var: set or unset variables on a host.
+func hostCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "host",
+		Short: "Add, remove, or change hosts of the Ansible development inventory",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(hostAddCmd())
+	cmd.AddCommand(hostRemoveCmd())
+	cmd.AddCommand(hostSetVarCmd())
+
+	return cmd
+}
+
+// hostAddCmd creates the Cobra command for
+// "ansible-dev inventory host add".
+//
+// Usage:
+//
+//	ansible-dev inventory host add <host> [key=value]... [--group <group>]...
+//
+// The host is listed with the given variables in the first group and as a
+// bare member of the other groups; groups that do not exist are created.
+// Without --group the host is added to the "ungrouped" group, since the
+// [vagrant] group is reserved for the machines managed by ansible-dev (use
+// "ansible-dev vm add" to add a VM). A host_vars/<host>.yml stub is created
+// as by "ansible-dev initialize", together with group_vars/<group>.yml for
+// each new group.
+//
+// An error is returned if the host is already in the inventory; use
+// "ansible-dev inventory group add-host" to add it to another group.
+func hostAddCmd() *cobra.Command {
+	var groups []string
+
+	cmd := &cobra.Command{
+		Use:   "add <host> [key=value]...",
+		Short: "Add a host to the Ansible development inventory",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			host := args[0]
+
+			vars, err := parseVars(args[1:])
+			if err != nil {
+				return err
+			}
+
+			if len(groups) == 0 {
+				groups = []string{"ungrouped"}
+			}
+
+			var created []string
+
+			err = edit(func(inv *inventory.Inventory) error {
+				if inv.Host(host) != nil {
+					return fmt.Errorf("'%s' is already a host in %s", host, inventory.Path())
+				}
+
+				for i, group := range groups {
+					if inv.Group(group) == nil && group != "ungrouped" {
+						created = append(created, group)
+					}
+
+					if i > 0 {
+						vars = nil
+					}
+
+					if err := inv.AddHost(group, host, vars); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			if err := stub(ansible.EnsureHostVars, "host_vars", host); err != nil {
+				return err
+			}
+
+			for _, group := range created {
+				if err := stub(ansible.EnsureGroupVars, "group_vars", group); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+		PreRunE: ensure,
+	}
+
+	cmd.Flags().StringSliceVarP(&groups, "group", "g", []string{}, "group(s) of the host")
+
+	return cmd
+}
+
+// hostRemoveCmd creates the Cobra command for
+// "ansible-dev inventory host remove".
+//
+// Usage:
+//
+//	ansible-dev inventory host remove <host> [--group <group>]
+//
+// The host is removed from every group, or only from the group given with
+// --group. Its host_vars file is kept. The edit is refused if it would
+// orphan a VM defined in the Vagrantfile (see [edit]).
+func hostRemoveCmd() *cobra.Command {
+	var group string
+
+	cmd := &cobra.Command{
+		Use:               "remove <host>",
+		Aliases:           []string{"rm"},
+		Short:             "Remove a host from the Ansible development inventory",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeHosts,
+		RunE: func(_ *cobra.Command, args []string) error {
+			err := edit(func(inv *inventory.Inventory) error {
+				if len(group) > 0 {
+					return inv.RemoveHostFromGroup(group, args[0])
+				}
+
+				return inv.RemoveHost(args[0])
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			return nil
+		},
+		PreRunE: ensure,
+	}
+
+	cmd.Flags().StringVarP(&group, "group", "g", "", "only remove the host from this group")
+
+	return cmd
+}
+
+// hostSetVarCmd creates the Cobra command for
+// "ansible-dev inventory host set-var".
+//
+// Usage:
+//
+//	ansible-dev inventory host set-var <host> [key=value]... [--unset <key>]...
+//
+// The variables are set on the line (or YAML mapping) that defines the
+// host's variables, replacing existing values in place. Note that the
+// connection variables of the managed hosts (ansible_host, ansible_port,
+// ...) are rewritten each time the host is started.
+func hostSetVarCmd() *cobra.Command {
+	var unset []string
+
+	cmd := &cobra.Command{
+		Use:               "set-var <host> [key=value]...",
+		Short:             "Set or unset variables of a host in the Ansible development inventory",
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: completeHosts,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vars, err := parseVars(args[1:])
+			if err != nil {
+				return err
+			}
+
+			if len(vars) == 0 && len(unset) == 0 {
+				return cmd.Help()
+			}
+
+			err = edit(func(inv *inventory.Inventory) error {
+				return inv.SetHostVars(args[0], vars, unset)
+			})
+			if err != nil {
+				return err
+			}
+
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			return nil
+		},
+		PreRunE: ensure,
+	}
+
+	cmd.Flags().StringSliceVar(&unset, "unset", []string{}, "variable(s) to remove from the host")
+
+	return cmd
+}
+
+// parseVars parses key=value arguments.
+func parseVars(args []string) ([][2]string, error) {
+	var vars [][2]string
+
+	for _, arg := range args {
+		key, value, ok := strings.Cut(arg, "=")
+		if !ok || len(key) == 0 {
+			return nil, fmt.Errorf("invalid variable '%s' (expected key=value)", arg)
+		}
+
+		vars = append(vars, [2]string{key, value})
+	}
+
+	return vars, nil
+}
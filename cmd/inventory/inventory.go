@@ -16,13 +16,24 @@ limitations under the License.
 
 // Package inventory implements the "ansible-dev inventory" (aliased as
 // "inv") command, which displays the Ansible inventory for the current
-// development environment by delegating to the ansible-inventory CLI tool.
+// development environment by delegating to the ansible-inventory CLI tool
+// or acts as a dynamic inventory script, its "export" subcommand, which
+// renders the connection details of the hosts for other tools, and its
+// "host" and "group" subcommands, which edit hosts.ini in place.
 package inventory
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/ansible-dev/internal/vagrant"
-	"github.com/dcjulian29/go-toolbox/execute"
 	"github.com/spf13/cobra"
 )
 
@@ -43,6 +54,14 @@ import (
 //	host variables included. The output format defaults to JSON but can
 //	be changed with --toml or --yaml (mutually exclusive).
 //
+// Dynamic inventory mode (--list or --host set):
+//
+//	Prints the inventory, or the variables of one host, in the JSON
+//	schema Ansible expects from an inventory script, built from the
+//	configured machines and the live connection details of the backend
+//	(see [dynamic]). This is what the inventory script written when the
+//	"inventory.dynamic" configuration value is true runs.
+//
 // Flags:
 //   - --variables:   include host variables and switch to list mode
 //     (default false).
@@ -50,19 +69,35 @@ import (
 //     --variables (mutually exclusive with --yaml).
 //   - --yaml, -y:    output in YAML format instead of JSON; requires
 //     --variables (mutually exclusive with --toml).
+//   - --list:        print the whole inventory as an inventory script
+//     (mutually exclusive with --host and --variables).
+//   - --host:        print the variables of a single host as an inventory
+//     script (mutually exclusive with --list and --variables).
 //
 // A PreRunE hook validates the environment with two checks:
 //  1. [ansible.EnsureAnsibleDirectory] confirms ansible.cfg is present.
-//  2. [vagrant.EnsureVagrantfile] confirms a Vagrantfile is present.
+//  2. [backend.Ensure] confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
 //
 // An error is returned if either pre-flight check fails or if
 // ansible-inventory exits with a non-zero status.
+//
+// The following subcommands are registered:
+//   - export: print the connection details of the hosts for other tools.
+//   - host:   add, remove, or set variables on a host.
+//   - group:  add or remove a group, or add hosts to it.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "inventory",
 		Aliases: []string{"inv"},
 		Short:   "Show inventory information for the Ansible development vagrant environment",
 		RunE: func(cmd *cobra.Command, _ []string) error {
+			host, _ := cmd.Flags().GetString("host")
+
+			if list, _ := cmd.Flags().GetBool("list"); list || len(host) > 0 {
+				return dynamic(host)
+			}
+
 			param := []string{}
 			variables, _ := cmd.Flags().GetBool("variables")
 
@@ -80,7 +115,7 @@ func NewCommand() *cobra.Command {
 				param = append(param, "--graph")
 			}
 
-			err := execute.ExternalProgram("ansible-inventory", param...)
+			err := runner.Run("ansible-inventory", param...)
 			if err != nil {
 				return err
 			}
@@ -92,7 +127,7 @@ func NewCommand() *cobra.Command {
 				return err
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
@@ -100,7 +135,142 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().Bool("toml", false, "Use TOML format instead of default JSON")
 	cmd.Flags().BoolP("yaml", "y", false, "Use YAML format instead of default JSON")
 
+	cmd.Flags().Bool("list", false, "print the inventory as a dynamic inventory script")
+	cmd.Flags().String("host", "", "print the variables of a host as a dynamic inventory script")
+
 	cmd.MarkFlagsMutuallyExclusive("toml", "yaml")
+	cmd.MarkFlagsMutuallyExclusive("list", "host", "variables")
+
+	cmd.AddCommand(exportCmd())
+	cmd.AddCommand(groupCmd())
+	cmd.AddCommand(hostCmd())
 
 	return cmd
 }
+
+// ensure verifies that the current directory is an Ansible development
+// directory with a hosts.ini inventory that Ansible reads. The editing
+// subcommands do not need the backend, so unlike the parent command they do
+// not check it. In dynamic inventory mode the structure comes from the
+// configured machines, so editing hosts.ini is refused.
+func ensure(_ *cobra.Command, _ []string) error {
+	if err := ansible.EnsureAnsibleDirectory(); err != nil {
+		return errors.New("not an Ansible development directory")
+	}
+
+	if config.Current().Inventory.Dynamic {
+		return errors.New("the inventory is dynamic; change the groups of the machines in the configuration instead")
+	}
+
+	if path := inventory.Path(); !runner.FileExist(path) {
+		return fmt.Errorf("can't find the %s file", path)
+	}
+
+	return nil
+}
+
+// edit loads hosts.ini, applies change to it and saves it. Before saving,
+// the edit is refused if it would orphan a VM: remove a machine defined in
+// the Vagrantfile (see [definedMachines]) from the hosts of the
+// [vagrant] group, which every lifecycle command works on. Machines are
+// removed with "ansible-dev vm remove" instead, which also updates the
+// Vagrantfile.
+func edit(change func(inv *inventory.Inventory) error) error {
+	inv, err := inventory.Load(inventory.Path())
+	if err != nil {
+		return err
+	}
+
+	before := inv.GroupHosts(inventory.Managed)
+
+	if err := change(inv); err != nil {
+		return err
+	}
+
+	machines, err := definedMachines()
+	if err != nil {
+		return err
+	}
+
+	after := inv.GroupHosts(inventory.Managed)
+
+	var orphans []string
+
+	for _, m := range machines {
+		if slices.Contains(before, m) && !slices.Contains(after, m) {
+			orphans = append(orphans, m)
+		}
+	}
+
+	if len(orphans) > 0 {
+		return fmt.Errorf("refusing to orphan %s: defined in the Vagrantfile but no longer in the [%s] group (use 'ansible-dev vm remove' to remove a VM)",
+			strings.Join(orphans, ", "), inventory.Managed)
+	}
+
+	return inv.Save()
+}
+
+// definedMachines returns the machines of the development environment: the
+// VMs Vagrant reports for the Vagrantfile (see [vagrant.MachineNames], which
+// the doctor inventory check uses as well), or the configured machines when
+// a container backend is used.
+func definedMachines() ([]string, error) {
+	if b := config.Current().Backend; b == "docker" || b == "podman" {
+		var names []string
+		for _, m := range config.Current().Machines {
+			names = append(names, m.Name)
+		}
+
+		return names, nil
+	}
+
+	return vagrant.MachineNames()
+}
+
+// stub creates the variables file of a new host or group, reporting it
+// when created.
+func stub(ensure func(string) (bool, error), folder, name string) error {
+	created, err := ensure(name)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("  ...  %s/%s.yml\n", folder, name)
+	}
+
+	return nil
+}
+
+// completeHosts completes the first argument with the inventory hosts.
+func completeHosts(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	inv, err := inventory.Load(inventory.Path())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return inv.HostNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeGroups completes the first argument with the inventory groups.
+func completeGroups(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	inv, err := inventory.Load(inventory.Path())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var names []string
+	for _, g := range inv.Groups() {
+		names = append(names, g.Name)
+	}
+
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
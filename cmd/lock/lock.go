@@ -0,0 +1,59 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lock implements the "ansible-dev lock" command group, which
+// inspects the requirements.lock file written by "ansible-dev restore".
+// Available subcommands include verify.
+package lock
+
+import (
+	"errors"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates and returns the Cobra command for the "lock" command
+// group.
+//
+// When invoked without a subcommand it prints the help text.
+//
+// The following subcommands are registered:
+//   - verify: report drift between requirements.lock, requirements.yml and
+//     the installed roles and collections.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lock",
+		Short: "Inspect the requirements.lock file",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(verifyCmd())
+
+	return cmd
+}
+
+// ensure verifies that the current directory is an Ansible development
+// directory with a requirements.yml file.
+func ensure(_ *cobra.Command, _ []string) error {
+	if err := ansible.EnsureAnsibleDirectory(); err != nil {
+		return errors.New("not an Ansible development directory")
+	}
+
+	return ansible.EnsureRequirementsFile()
+}
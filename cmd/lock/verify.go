@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/lock"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd creates the Cobra command for "ansible-dev lock verify".
+//
+// Usage:
+//
+//	ansible-dev lock verify [--output json]
+//
+// Compares requirements.lock with requirements.yml and with the roles and
+// collections installed in the project, and lists every difference found
+// by [lock.Verify]: requirements that are not locked, locked entries that
+// are no longer required, version pins that disagree with the lock, and
+// installed copies that are missing, unlocked, or whose version or content
+// hash differ from the lock.
+//
+// Flags:
+//   - --output, -o: "table" (default) or "json".
+//
+// The command fails when any drift is found, so that it can be used in
+// scripts.
+func verifyCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Report drift between requirements.lock, requirements.yml and the installed content",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			l, err := lock.Read()
+			if err != nil {
+				return fmt.Errorf("reading %s: %w", lock.Filename, err)
+			}
+
+			problems, err := lock.Verify(l)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				data, err := json.MarshalIndent(problems, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(data))
+			} else if len(problems) == 0 {
+				fmt.Println(textformat.Info(lock.Filename + " matches requirements.yml and the installed content"))
+			} else {
+				table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+				table.Header("Kind", "Name", "Problem")
+
+				for _, p := range problems {
+					if err := table.Append([]string{p.Kind, p.Name, p.Problem}); err != nil {
+						return err
+					}
+				}
+
+				if err := table.Render(); err != nil {
+					return err
+				}
+			}
+
+			if len(problems) > 0 {
+				return errors.New(lock.Filename + " is out of date; run \"ansible-dev restore\" to update it")
+			}
+
+			return nil
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format '%s' (table, json)", output)
+			}
+
+			return ensure(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "output format: table or json")
+
+	return cmd
+}
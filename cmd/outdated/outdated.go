@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package outdated implements the "ansible-dev outdated" command, which
+// reports the roles and collections of requirements.yml that are behind
+// their pinned or latest version.
+package outdated
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/outdated"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates and returns the Cobra command for
+// "ansible-dev outdated".
+//
+// Usage:
+//
+//	ansible-dev outdated [--output json]
+//
+// For every role and collection of requirements.yml the command shows the
+// installed version (from the role's meta/.galaxy_install_info or the
+// collection's MANIFEST.json), the version constraint of requirements.yml
+// and the latest version available from its source (see
+// [outdated.Check]):
+//   - Galaxy roles and collections are looked up on the server named by
+//     the requirement, or on the "galaxy.server" configuration value.
+//   - repository sources use the newest version tag reported by
+//     "git ls-remote --tags".
+//
+// The Status column is "not installed", "not at pinned version",
+// "outdated", "unknown" (when the latest version could not be found; the
+// reason is printed below the table) or "up to date".
+//
+// Flags:
+//   - --output, -o: "table" (default) or "json".
+//
+// A PreRunE hook validates the output format and verifies that the current
+// directory is an Ansible development directory with a requirements.yml
+// file.
+func NewCommand() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "outdated",
+		Short: "Show the roles and collections that are behind their pinned or latest version",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			entries, err := outdated.Check(config.Current().Galaxy.Server)
+			if err != nil {
+				return err
+			}
+
+			if output == "json" {
+				data, err := json.MarshalIndent(entries, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(data))
+
+				return nil
+			}
+
+			table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+			table.Header("Kind", "Name", "Installed", "Required", "Latest", "Status")
+
+			for _, e := range entries {
+				row := []string{e.Kind, e.Name, e.Installed, e.Required, e.Latest, e.Status}
+				if err := table.Append(row); err != nil {
+					return err
+				}
+			}
+
+			if err := table.Render(); err != nil {
+				return err
+			}
+
+			for _, e := range entries {
+				if len(e.Error) > 0 {
+					fmt.Println(textformat.Yellow(fmt.Sprintf("%s '%s': %s", e.Kind, e.Name, e.Error)))
+				}
+			}
+
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format '%s' (table, json)", output)
+			}
+
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			return ansible.EnsureRequirementsFile()
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "output format: table or json")
+
+	return cmd
+}
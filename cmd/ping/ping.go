@@ -23,24 +23,30 @@ import (
 	"errors"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/spf13/cobra"
 )
 
+var limit string
+
 // NewCommand creates and returns the Cobra command for "ansible-dev ping".
 //
 // The command runs "ansible -i hosts.ini -m ping all", which executes the
 // Ansible ping module against every host in the local inventory. This is a
 // connectivity and authentication check — it confirms that Ansible can SSH
 // into each Vagrant VM and receive a "pong" response, rather than
-// performing an ICMP ping.
+// performing an ICMP ping. The --limit (-l) flag restricts the check to
+// the hosts matching an Ansible host pattern (default "all"). In dynamic
+// inventory mode the inventory script is used instead of hosts.ini (see
+// [ansible.InventorySource]).
 //
 // A PreRunE hook validates the environment with two checks:
 //  1. [ansible.EnsureAnsibleDirectory] confirms ansible.cfg is present.
 //     A custom error message ("not an Ansible development directory") is
 //     returned on failure.
-//  2. [vagrant.EnsureVagrantfile] confirms a Vagrantfile is present.
+//  2. [backend.Ensure] confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
 //
 // An error is returned if either pre-flight check fails or if the ansible
 // command exits with a non-zero status (indicating one or more hosts are
@@ -50,7 +56,7 @@ func NewCommand() *cobra.Command {
 		Use:   "ping",
 		Short: "Ping the Ansible development vagrant environment",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			err := execute.ExternalProgram("ansible", "-i", "hosts.ini", "-m", "ping", "all")
+			err := runner.Run("ansible", "-i", ansible.InventorySource(), "-m", "ping", limit)
 			if err != nil {
 				return err
 			}
@@ -62,9 +68,11 @@ func NewCommand() *cobra.Command {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
+	cmd.Flags().StringVarP(&limit, "limit", "l", "all", "only ping hosts matching the pattern")
+
 	return cmd
 }
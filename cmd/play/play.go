@@ -21,13 +21,24 @@ package play
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/prompt"
 	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
 )
 
-var playFromFlags ansible.Play
+// prePlaySnapshot is the name of the snapshot taken by --snapshot.
+const prePlaySnapshot = "ansible-dev-pre-play"
+
+var (
+	playFromFlags     ansible.Play
+	snapshot          bool
+	rollbackOnFailure bool
+)
 
 // NewCommand creates and returns the Cobra command for "ansible-dev play".
 //
@@ -42,6 +53,8 @@ var playFromFlags ansible.Play
 // Flags are mapped to [ansible.Play] fields as follows:
 //   - --tags:                 comma-separated list of Ansible tags to
 //     selectively run tasks (maps to [ansible.Play.Tags]).
+//   - --limit, -l:            Ansible host pattern restricting the hosts
+//     the role is applied to (maps to [ansible.Play.Limit]).
 //   - --ask-vault-password:   prompt for the Ansible Vault password
 //     (maps to [ansible.Play.AskVaultPass]).
 //   - --ask-become-password:  prompt for the privilege escalation password
@@ -52,12 +65,22 @@ var playFromFlags ansible.Play
 //     (maps to [ansible.Play.Step]).
 //   - --verbose, -v:          enable verbose ansible-playbook output
 //     (maps to [ansible.Play.Verbose]).
+//   - --snapshot:             save a "ansible-dev-pre-play" snapshot of
+//     every running inventory host targeted by --limit before the play
+//     runs. If the play fails the
+//     user is asked whether to restore it.
+//   - --rollback-on-failure:  restore the pre-play snapshot without asking
+//     when the play fails. Implies --snapshot.
+//
+// Snapshots are only supported by the Vagrant backend; --snapshot and
+// --rollback-on-failure are rejected for container backends.
 //
 // A PreRunE hook validates the environment with two checks:
 //  1. [ansible.EnsureAnsibleDirectory] confirms ansible.cfg is present.
 //     A custom error message ("not an Ansible development directory") is
 //     returned on failure.
-//  2. [vagrant.EnsureVagrantfile] confirms a Vagrantfile is present.
+//  2. [backend.Ensure] confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
 //
 // An error is returned if either pre-flight check fails, the temporary
 // playbook cannot be generated, or ansible-playbook exits with a non-zero
@@ -70,6 +93,7 @@ func NewCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, args []string) error {
 			playFromFlags.Name = args[0]
 			playFromFlags.Tags, _ = cmd.Flags().GetStringSlice("tags")
+			playFromFlags.Limit, _ = cmd.Flags().GetString("limit")
 			playFromFlags.AskBecomePass, _ = cmd.Flags().GetBool("becomepass")
 			playFromFlags.AskVaultPass, _ = cmd.Flags().GetBool("vaultpass")
 			playFromFlags.FlushCache, _ = cmd.Flags().GetBool("flushcache")
@@ -80,8 +104,32 @@ func NewCommand() *cobra.Command {
 			if err != nil {
 				return err
 			}
+
+			var hosts []string
+
+			if snapshot || rollbackOnFailure {
+				if hosts, err = runningHosts(playFromFlags.Limit); err != nil {
+					return err
+				}
+
+				for _, host := range hosts {
+					if err := vagrant.SaveSnapshot(host, prePlaySnapshot); err != nil {
+						return err
+					}
+				}
+			}
+
 			err = ansible.ExecutePlay(playFromFlags)
 			if err != nil {
+				if len(hosts) > 0 && (rollbackOnFailure || prompt.Confirm(textformat.Yellow(
+					fmt.Sprintf("\nPlay failed. Restore the '%s' snapshot?", prePlaySnapshot)))) {
+					for _, host := range hosts {
+						if err := vagrant.RestoreSnapshot(host, prePlaySnapshot); err != nil {
+							return err
+						}
+					}
+				}
+
 				return err
 			}
 
@@ -92,7 +140,11 @@ func NewCommand() *cobra.Command {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			if err := backend.Ensure(); err != nil {
+				return err
+			}
+
+			return ensureSnapshots()
 		},
 	}
 
@@ -102,6 +154,65 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().Bool("flush-cache", false, "clear the fact cache for every host in inventory")
 	cmd.Flags().BoolP("step", "s", false, "one-step-at-a-time: confirm each task before running")
 	cmd.Flags().StringSlice("tags", []string{}, "only plays and task tagged with these values")
+	cmd.Flags().StringP("limit", "l", "", "further limit selected hosts to an additional pattern")
+	cmd.Flags().BoolVar(&snapshot, "snapshot", false, "snapshot every VM before the play and offer a restore if it fails")
+	cmd.Flags().BoolVar(&rollbackOnFailure, "rollback-on-failure", false, "restore the pre-play snapshot without asking if the play fails")
 
 	return cmd
 }
+
+// runningHosts returns the names of the hosts matched by the limit host
+// pattern (every inventory host when empty) whose VM is running according
+// to [vagrant.Status]. Hosts that have not been created cannot be
+// snapshotted and are skipped.
+func runningHosts(limit string) ([]string, error) {
+	inventory, err := ansible.SelectHosts(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(limit) > 0 {
+		if inventory, err = ansible.ListHosts(limit); err != nil {
+			return nil, err
+		}
+	}
+
+	statuses, err := vagrant.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	running := map[string]bool{}
+	for _, s := range statuses {
+		running[s.Name] = s.State == "running"
+	}
+
+	var hosts []string
+
+	for _, host := range inventory {
+		if running[host] {
+			hosts = append(hosts, host)
+		}
+	}
+
+	return hosts, nil
+}
+
+// ensureSnapshots returns an error when --snapshot or --rollback-on-failure
+// is given but the configured backend cannot take snapshots.
+func ensureSnapshots() error {
+	if !snapshot && !rollbackOnFailure {
+		return nil
+	}
+
+	b, err := backend.Current()
+	if err != nil {
+		return err
+	}
+
+	if b.Name() != "vagrant" {
+		return fmt.Errorf("the '%s' backend does not support snapshots", b.Name())
+	}
+
+	return nil
+}
@@ -16,37 +16,58 @@ limitations under the License.
 
 // Package reset implements the "ansible-dev reset" command, which
 // destroys and recreates the entire Vagrant development environment from
-// scratch, optionally provisioning one or more Ansible roles afterward.
+// scratch, or restores it from a baseline snapshot, optionally provisioning
+// one or more Ansible roles afterward.
 package reset
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/doctor"
 	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
 )
 
 var (
-	roles   []string
-	tags    []string
-	verbose bool
+	fast     bool
+	parallel int
+	roles    []string
+	tags     []string
+	verbose  bool
+	vms      []string
 )
 
 // NewCommand creates and returns the Cobra command for "ansible-dev reset".
 //
 // The command performs a full environment reset in three phases:
 //
-//  1. Destroy – calls [vagrant.Destroy] to force-destroy all VMs and
-//     remove local artifacts (ansible.log, .vagrant, .tmp).
-//  2. Recreate – reads the inventory via [ansible.GetInventory] and calls
-//     [vagrant.Up] for each host, booting the VM and waiting for network
-//     reachability.
+//  1. Destroy – calls [backend.Backend.Destroy] of the configured backend
+//     (for Vagrant, [vagrant.Destroy]) to force-destroy all hosts and
+//     remove local artifacts (ansible.log, .vagrant, .tmp), then clears the
+//     record of applied roles.
+//  2. Recreate – selects the hosts via [ansible.SelectHosts] and calls
+//     [backend.Backend.Up] with their names, booting each VM and waiting for
+//     SSH readiness (concurrently when --parallel is given).
 //  3. Provision (optional) – if one or more --role flags were provided,
 //     calls [ansible.ApplyRoles] to generate and execute a temporary
 //     playbook for each role.
 //
+// With --fast the first two phases are replaced by [vagrant.RestoreBaseline],
+// which restores the "baseline" snapshot recorded by
+// "ansible-dev start --baseline" on every VM. When any VM has no baseline
+// or its provider lacks snapshot support, or a container backend is used,
+// the command falls back to the full destroy and recreate.
+//
 // Flags:
+//   - --fast:       restore the baseline snapshot instead of destroying and
+//     recreating the VMs (default false).
+//   - --parallel:   recreate VMs concurrently, as for "ansible-dev start"
+//     (default 1, sequential).
 //   - --role:       one or more Ansible roles to apply after the VMs are
 //     brought online. May be specified multiple times or as a
 //     comma-separated list (default: none).
@@ -55,36 +76,72 @@ var (
 //     comma-separated list (default: none).
 //   - --verbose, -v: enable verbose ansible-playbook output
 //     (default false).
+//   - --vm:         only reset (and provision) the named VM, leaving the
+//     others untouched. May be repeated (default: every host in
+//     hosts.ini).
 //
-// A PreRunE hook validates the environment with two checks:
+// A PreRunE hook validates the environment with three checks:
 //  1. [ansible.EnsureAnsibleDirectory] confirms ansible.cfg is present.
 //     A custom error message ("not an Ansible development directory") is
-//     returned on failure.
-//  2. [vagrant.EnsureVagrantfile] confirms a Vagrantfile is present.
+//     returned on failure. ansible.cfg is then pointed at hosts.ini or,
+//     in dynamic inventory mode, at the inventory script (see
+//     [ansible.EnsureInventorySource]).
+//  2. [backend.Ensure] confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+//  3. [doctor.PreCheck] confirms that the machines of the backend and the
+//     hosts of the [vagrant] group agree.
 //
 // Execution is fail-fast: an error at any phase stops the command and
-// returns immediately.
+// returns immediately. In --parallel mode every VM is attempted before the
+// recreate phase reports its failures.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "reset",
 		Short: "Reset the Ansible development vagrant environment",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			if err := vagrant.Destroy(); err != nil {
+			names, err := ansible.SelectHosts(vms)
+			if err != nil {
 				return err
 			}
 
-			inventory, err := ansible.GetInventory()
+			b, err := backend.Current()
 			if err != nil {
 				return err
 			}
 
-			for _, host := range inventory {
-				if err := vagrant.Up(host.Name); err != nil {
+			restored := false
+
+			if fast && b.Name() != "vagrant" {
+				fmt.Println(textformat.Yellow(fmt.Sprintf("The '%s' backend does not support snapshots.", b.Name())))
+			}
+
+			if fast && b.Name() == "vagrant" {
+				if restored, err = vagrant.RestoreBaseline(names); err != nil {
+					return err
+				}
+
+				if !restored {
+					fmt.Println(textformat.Yellow("Falling back to destroying and recreating the VMs..."))
+				}
+			}
+
+			if !restored {
+				if err := b.Destroy(vms...); err != nil {
+					return err
+				}
+			}
+
+			if err := ansible.ClearProvisioning(vms...); err != nil {
+				return err
+			}
+
+			if !restored {
+				if err := b.Up(names, parallel); err != nil {
 					return err
 				}
 			}
 
-			err = ansible.ApplyRoles(roles, tags, verbose)
+			err = ansible.ApplyRoles(roles, tags, strings.Join(vms, ","), verbose)
 			if err != nil {
 				return err
 			}
@@ -100,13 +157,25 @@ func NewCommand() *cobra.Command {
 				return err
 			}
 
-			return vagrant.EnsureVagrantfile()
+			if err := ansible.EnsureInventorySource(); err != nil {
+				return err
+			}
+
+			if err := backend.Ensure(); err != nil {
+				return err
+			}
+
+			return doctor.PreCheck()
 		},
 	}
 
+	cmd.Flags().BoolVar(&fast, "fast", false, "restore the baseline snapshot instead of recreating the VMs")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "recreate up to N VMs concurrently (all when no value is given)")
+	cmd.Flags().Lookup("parallel").NoOptDefVal = "0"
 	cmd.Flags().StringSliceVar(&roles, "role", []string{}, "provision the VMs with the specified role(s)")
 	cmd.Flags().StringSliceVar(&tags, "tag", []string{}, "apply the role with the specified tag(s)")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "tell Ansible to print more debug messages")
+	cmd.Flags().StringSliceVar(&vms, "vm", []string{}, "only reset the specified VM(s)")
 
 	return cmd
 }
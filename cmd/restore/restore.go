@@ -20,11 +20,17 @@ limitations under the License.
 package restore
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/lock"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // NewCommand creates and returns the Cobra command for
@@ -36,9 +42,24 @@ import (
 // "add" subcommands, which only modify the manifest without installing
 // artifacts.
 //
+// After the install the requirements.lock file is rewritten (see
+// [lock.Generate]) with the version, source, git commit and content hash
+// of every installed role and collection. The commit of a git source is the
+// one installed, so its version has to be a full commit hash unless it is
+// installed as a git checkout; the lock is not written otherwise.
+//
+// With --locked, requirements.yml is not resolved again. Instead the
+// entries of requirements.lock whose installed copy is missing or differs
+// are installed at their locked version or commit, without dependencies,
+// from a generated ".tmp/requirements.lock.yml" (see
+// [lock.Lock.Requirements]). The command then fails if any installed role
+// or collection still does not match its locked hash.
+//
 // Flags:
 //   - --force:      force overwriting of already-installed roles or
 //     collections (passes --force to ansible-galaxy; default false).
+//   - --locked:     install exactly the content of requirements.lock
+//     (default false).
 //   - --verbose, -v: enable verbose ansible-galaxy output (passes -v;
 //     default false).
 //
@@ -48,8 +69,10 @@ import (
 //     returned on failure.
 //  2. [ansible.EnsureRequirementsFile] confirms requirements.yml exists.
 //
-// An error is returned if either pre-flight check fails or if
-// ansible-galaxy exits with a non-zero status.
+// An error is returned if either pre-flight check fails, if ansible-galaxy
+// exits with a non-zero status, if the lock file cannot be written or,
+// with --locked, if it cannot be read or the installed content does not
+// match it.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "restore",
@@ -57,6 +80,11 @@ func NewCommand() *cobra.Command {
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			verbose, _ := cmd.Flags().GetBool("verbose")
 			force, _ := cmd.Flags().GetBool("force")
+			locked, _ := cmd.Flags().GetBool("locked")
+
+			if locked {
+				return restoreLocked(verbose)
+			}
 
 			param := []string{"install"}
 
@@ -70,11 +98,16 @@ func NewCommand() *cobra.Command {
 
 			param = append(param, "-r", "requirements.yml")
 
-			if err := execute.ExternalProgram("ansible-galaxy", param...); err != nil {
+			if err := runner.Run("ansible-galaxy", param...); err != nil {
 				return err
 			}
 
-			return nil
+			l, err := lock.Generate()
+			if err != nil {
+				return err
+			}
+
+			return l.Write()
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := ansible.EnsureAnsibleDirectory(); err != nil {
@@ -86,7 +119,78 @@ func NewCommand() *cobra.Command {
 	}
 
 	cmd.Flags().Bool("force", false, "force overwriting existing roles or collections")
+	cmd.Flags().Bool("locked", false, "install exactly the roles and collections of requirements.lock")
 	cmd.Flags().BoolP("verbose", "v", false, "tell Ansible to print more debug messages")
 
 	return cmd
 }
+
+// lockedRequirements is the requirements file generated for a locked
+// restore.
+const lockedRequirements = ".tmp/requirements.lock.yml"
+
+// restoreLocked installs the entries of requirements.lock that are missing
+// or differ on disk, replacing the installed copies, and then checks every
+// locked entry against its hash.
+func restoreLocked(verbose bool) error {
+	l, err := lock.Read()
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", lock.Filename, err)
+	}
+
+	stale, err := l.Stale()
+	if err != nil {
+		return err
+	}
+
+	if len(stale.Roles)+len(stale.Collections) == 0 {
+		fmt.Println(textformat.Info("roles and collections already match " + lock.Filename))
+		return nil
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(stale.Requirements()); err != nil {
+		return err
+	}
+
+	if err := runner.WriteFile(lockedRequirements, buf.Bytes()); err != nil {
+		return err
+	}
+
+	param := []string{"install", "--force", "--no-deps"}
+
+	if verbose {
+		param = append(param, "-v")
+	}
+
+	param = append(param, "-r", lockedRequirements)
+
+	if err := runner.Run("ansible-galaxy", param...); err != nil {
+		return err
+	}
+
+	if runner.IsDryRun() {
+		return nil
+	}
+
+	problems, err := l.Check()
+	if err != nil {
+		return err
+	}
+
+	if len(problems) > 0 {
+		lines := make([]string, 0, len(problems))
+
+		for _, p := range problems {
+			lines = append(lines, fmt.Sprintf("%s '%s': %s", p.Kind, p.Name, p.Problem))
+		}
+
+		return fmt.Errorf("installed content does not match %s:\n  %s", lock.Filename, strings.Join(lines, "\n  "))
+	}
+
+	return nil
+}
@@ -35,11 +35,23 @@ import (
 // "geerlingguy.docker"). If no --source flag is provided, the role name
 // is used as the source, implying it will be fetched from Ansible Galaxy.
 //
+// Adding is idempotent: when the role is already listed its entry is
+// updated in place with the given --source, --scm and --version, and
+// nothing is written when they already match. requirements.yml is edited
+// as a YAML node tree (see [ansible.RequirementsFile]), so comments, the
+// order of the entries and keys ansible-dev does not know are kept. The
+// resulting entry is validated first (see [ansible.Role.Validate]). With
+// the global --dry-run flag the change is shown as a unified diff.
+//
 // Flags:
 //   - --source, -s: override the source URL or Galaxy reference for the
-//     role. Defaults to the role name when omitted.
+//     role. Defaults to the role name when omitted for a new role, and
+//     to the current source when the role is already listed.
+//   - --scm: the source control system of a repository source, "git"
+//     or "hg".
 //   - --version, -v: pin the role to a specific version string.
-//     Defaults to the empty string (latest).
+//     Defaults to the empty string (latest), which keeps the current
+//     version of a role that is already listed.
 //
 // The command prints a reminder that the role must be restored (installed
 // via "ansible-dev restore") before it can be used. If no argument is
@@ -64,27 +76,44 @@ func addCmd() *cobra.Command {
 			name := args[0]
 
 			source, _ := cmd.Flags().GetString("source")
+			scm, _ := cmd.Flags().GetString("scm")
 			version, _ := cmd.Flags().GetString("version")
 
-			if len(name) > 0 && len(source) == 0 {
-				source = name // Ansible Galaxy Role
+			requirements, err := ansible.LoadRequirementsFile()
+			if err != nil {
+				return err
 			}
 
-			requirements, _ := ansible.ReadRequirements()
+			exists := requirements.HasRole(name)
+
+			if !exists && len(source) == 0 {
+				source = name // Ansible Galaxy Role
+			}
 
-			role := ansible.Role{
+			err = requirements.SetRole(ansible.Role{
 				Name:    name,
 				Source:  source,
+				Scm:     scm,
 				Version: version,
+			})
+			if err != nil {
+				return err
 			}
 
-			requirements.Roles = append(requirements.Roles, role)
+			if !requirements.Changed() {
+				fmt.Println(textformat.Info(fmt.Sprintf("role '%s' is already in requirements.yml", name)))
+				return nil
+			}
 
-			if err := ansible.SaveRequirements(requirements); err != nil {
+			if err := requirements.Save(); err != nil {
 				return err
 			}
 
 			msg := fmt.Sprintf("role '%s' added to requirements.yml but must be restored before use", name)
+			if exists {
+				msg = fmt.Sprintf("role '%s' updated in requirements.yml but must be restored before use", name)
+			}
+
 			fmt.Println(textformat.Info(msg))
 
 			return nil
@@ -95,6 +124,7 @@ func addCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringP("source", "s", "", "source of the role")
+	cmd.Flags().String("scm", "", "source control system of a repository source: git or hg")
 	cmd.Flags().StringP("version", "v", "", "version of the role")
 
 	return cmd
@@ -23,6 +23,7 @@ import (
 	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 	"github.com/spf13/cobra"
 )
@@ -35,19 +36,21 @@ import (
 //   - The local roles directory is resolved via [ansible.RootRoleFolder]
 //     (the "roles_path" setting in ansible.cfg) relative to the current
 //     working directory.
-//   - The upstream repository directory is read from the ANSIBLE_ROLES
-//     environment variable. An error is returned if this variable is not
-//     set.
+//   - The upstream repository directory is the "paths.roles" configuration
+//     value, which defaults to the ANSIBLE_ROLES environment variable. An
+//     error is returned if it is not set.
 //
 // For each subdirectory in the local roles path, the command looks for a
-// matching directory in ANSIBLE_ROLES (falling back to a name with the
-// "dcjulian29." prefix stripped). If a match is found, it performs a
-// file-by-file hash comparison, excluding .git, .github,
-// .galaxy_install_info, and .ansible paths.
+// matching directory in the upstream directory (falling back to a name with
+// the "<galaxy.namespace>." prefix stripped). If a match is found, it
+// performs a file-by-file hash comparison, excluding the paths listed in the
+// "compare.role_ignore" configuration value (by default .git, .github,
+// .galaxy_install_info, and .ansible).
 //
 // When differences are detected the command opens a graphical diff tool:
 //   - Windows: WinMerge (C:\Program Files\WinMerge\winmergeu.exe) with
-//     recursive comparison and the "AnsibleRoles" file filter.
+//     recursive comparison and the "compare.role_filter" file filter
+//     (by default "AnsibleRoles").
 //   - Linux/macOS: Meld (/usr/bin/meld).
 //
 // Flags:
@@ -70,9 +73,11 @@ func compareCmd() *cobra.Command {
 			sep := string(os.PathSeparator)
 			pwd, _ := os.Getwd()
 
-			repoFolder := strings.ReplaceAll(os.Getenv("ANSIBLE_ROLES"), "\\", sep)
+			cfg := config.Current()
+
+			repoFolder := strings.ReplaceAll(cfg.Paths.Roles, "\\", sep)
 			if len(repoFolder) == 0 {
-				return errors.New("the Ansible roles directory is not defined in environment")
+				return errors.New("the Ansible roles directory is not configured (set 'paths.roles' or ANSIBLE_ROLES)")
 			}
 
 			folder, err := ansible.RootRoleFolder()
@@ -93,7 +98,7 @@ func compareCmd() *cobra.Command {
 			}
 
 			home := ansible.HomeFolder()
-			ignored := []string{"\\.git", "\\.github", ".galaxy_install_info", ".ansible"}
+			ignored := cfg.Compare.RoleIgnore
 
 			for _, e := range entries {
 				workingEntry := workingFolder + sep + e.Name()
@@ -102,7 +107,7 @@ func compareCmd() *cobra.Command {
 				repoEntry := repoFolder + sep + e.Name()
 
 				if !filesystem.DirectoryExist(repoEntry) {
-					repoEntry = strings.Replace(repoEntry, "dcjulian29.", "", 1)
+					repoEntry = strings.Replace(repoEntry, cfg.Galaxy.Namespace+".", "", 1)
 
 					if !filesystem.DirectoryExist(repoEntry) {
 						continue
@@ -110,7 +115,7 @@ func compareCmd() *cobra.Command {
 				}
 
 				if _, err := ansible.ComparePair(
-					workingEntry, repoEntry, ignored, checksum, nodiff, "AnsibleRoles", home,
+					workingEntry, repoEntry, ignored, checksum, nodiff, cfg.Compare.RoleFilter, home,
 				); err != nil {
 					return err
 				}
@@ -18,7 +18,7 @@ package role
 
 import (
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/spf13/cobra"
 )
 
@@ -34,7 +34,7 @@ import (
 // "dcjulian29.docker"). The command resolves the role's directory via
 // [ansible.RoleFolder] and, if the directory exists (checked with
 // [ansible.RoleFolderExists]), removes it entirely using
-// [filesystem.RemoveDirectory]. If the role directory does not exist,
+// [runner.Remove]. If the role directory does not exist,
 // the command exits silently without error.
 //
 // If no argument is supplied, the help text is displayed instead.
@@ -59,7 +59,7 @@ func deleteCmd() *cobra.Command {
 			folder, _ := ansible.RoleFolder(role)
 
 			if ansible.RoleFolderExists(role) {
-				if err := filesystem.RemoveDirectory(folder); err != nil {
+				if err := runner.Remove(folder); err != nil {
 					return err
 				}
 			}
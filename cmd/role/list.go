@@ -20,7 +20,7 @@ import (
 	"os"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/tw"
 	"github.com/spf13/cobra"
@@ -68,11 +68,19 @@ func listCmd() *cobra.Command {
 					return err
 				}
 
-				requirements, _ := ansible.ReadRequirements()
+				requirements, err := ansible.ReadRequirements()
+				if err != nil {
+					return err
+				}
+
 				table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
 
 				for _, r := range requirements.Roles {
 					row := []string{r.Name, r.Source, r.Version}
+					if len(r.Include) > 0 {
+						row = []string{"(include)", r.Include, ""}
+					}
+
 					if err := table.Append(row); err != nil {
 						return err
 					}
@@ -88,7 +96,7 @@ func listCmd() *cobra.Command {
 					param = []string{"role", "list", "-v"}
 				}
 
-				return execute.ExternalProgram("ansible-galaxy", param...)
+				return runner.Run("ansible-galaxy", param...)
 			}
 
 			return nil
@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
@@ -39,7 +40,7 @@ import (
 //   - Without --force: the command returns an error prompting the user
 //     to pass --force to replace the existing role.
 //   - With --force: the existing directory is removed via
-//     [filesystem.RemoveDirectory] before the new role is scaffolded.
+//     [runner.Remove] before the new role is scaffolded.
 //
 // If no argument is supplied, the help text is displayed instead.
 //
@@ -47,9 +48,10 @@ import (
 // embedded role template (LICENSE, README, lint configuration, GitHub
 // workflows, meta/main.yml, ...) is overlaid with !!ROLE_NAME!! / !!ROLE_DESC!!
 // substituted. When --publish is set, the role is additionally copied to the
-// directory named by the ANSIBLE_ROLES environment variable, committed to a new
-// git repository, pushed to a freshly-created public GitHub repository, and
-// recorded in requirements.yml.
+// role source directory ("paths.roles", defaulting to ANSIBLE_ROLES), committed
+// to a new git repository, pushed to a freshly-created public GitHub repository
+// named from the "github.owner" and "github.role_prefix" configuration values,
+// and recorded in requirements.yml.
 //
 // Flags:
 //   - --force, -f:       force overwrite of an existing role directory.
@@ -81,7 +83,7 @@ func newCmd() *cobra.Command {
 					return fmt.Errorf("role '%s' exists. Use '--force' to replace", role)
 				}
 
-				if err := filesystem.RemoveDirectory(folder); err != nil {
+				if err := runner.Remove(folder); err != nil {
 					return err
 				}
 			}
@@ -96,7 +98,7 @@ func newCmd() *cobra.Command {
 			// in the published role, so remove it before overlaying templates.
 			tests := filepath.Join(folder, "tests")
 			if filesystem.DirectoryExist(tests) {
-				if err := filesystem.RemoveDirectory(tests); err != nil {
+				if err := runner.Remove(tests); err != nil {
 					return err
 				}
 			}
@@ -116,15 +118,21 @@ func newCmd() *cobra.Command {
 				return err
 			}
 
-			base := ansible.BaseRoleName(role)
+			requirements, err := ansible.LoadRequirementsFile()
+			if err != nil {
+				return err
+			}
 
-			requirements, _ := ansible.ReadRequirements()
-			requirements.Roles = append(requirements.Roles, ansible.Role{
+			err = requirements.SetRole(ansible.Role{
 				Name:   role,
-				Source: fmt.Sprintf("https://github.com/dcjulian29/ansible-role-%s.git", base),
+				Source: ansible.RoleRepositorySource(role),
+				Scm:    "git",
 			})
+			if err != nil {
+				return err
+			}
 
-			if err := ansible.SaveRequirements(requirements); err != nil {
+			if err := requirements.Save(); err != nil {
 				return err
 			}
 
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package role
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/spf13/cobra"
+)
+
+// purgeCmd creates the Cobra command for "ansible-dev role purge", which
+// deletes installed roles that the project no longer uses.
+//
+// Usage:
+//
+//	ansible-dev role purge --unused
+//
+// With --unused, every role installed by ansible-galaxy in the roles path
+// that requirements.yml neither declares nor needs as a dependency (see
+// [ansible.UnusedRoles]) is removed. Roles developed in place are never
+// touched. To remove a single role, use "ansible-dev role delete".
+//
+// An error is returned if --unused is not given, requirements.yml or
+// ansible.cfg cannot be read, or a role directory cannot be removed.
+//
+// A PreRunE hook calls [ansible.EnsureAnsibleDirectory] to verify the
+// current directory is a valid Ansible project.
+func purgeCmd() *cobra.Command {
+	var unused bool
+
+	cmd := &cobra.Command{
+		Use:   "purge --unused",
+		Short: "Purge installed roles that are not needed by requirements.yml",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if !unused {
+				return errors.New("give --unused; use \"role delete <role>\" to remove a single role")
+			}
+
+			roles, err := ansible.UnusedRoles()
+			if err != nil {
+				return err
+			}
+
+			if len(roles) == 0 {
+				fmt.Println(textformat.Info("no unused roles are installed"))
+				return nil
+			}
+
+			for _, r := range roles {
+				if err := runner.Remove(r.Path); err != nil {
+					return err
+				}
+
+				fmt.Println(textformat.Info(fmt.Sprintf("role '%s' was purged", r.Name)))
+			}
+
+			return nil
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return ansible.EnsureAnsibleDirectory()
+		},
+	}
+
+	cmd.Flags().BoolVar(&unused, "unused", false, "purge the installed roles not needed by requirements.yml")
+
+	return cmd
+}
@@ -32,13 +32,15 @@ import (
 //	ansible-dev role remove <role> [flags]
 //
 // The positional argument <role> is the role name to remove (e.g.
-// "dcjulian29.docker"). The command reads the current requirements via
-// [ansible.ReadRequirements], searches for a role whose Name field
-// matches the argument exactly, and splices it out of the list. If no
-// matching role is found, an error is returned.
+// "dcjulian29.docker"). The command loads requirements.yml via
+// [ansible.LoadRequirementsFile] and deletes only the entry of the role
+// whose name (or, without one, the name derived from its source) matches
+// the argument exactly, keeping the rest of the file, comments included.
+// If no matching role is found, an error is returned.
 //
-// After a successful removal the updated manifest is persisted via
-// [ansible.SaveRequirements] and an informational message is printed.
+// After a successful removal the file is saved and an informational
+// message is printed. With the global --dry-run flag the change is shown
+// as a unified diff.
 //
 // If no argument is supplied, the help text is displayed instead.
 //
@@ -63,33 +65,24 @@ func removeCmd() *cobra.Command {
 				return cmd.Help()
 			}
 
-			var changed []ansible.Role
-
 			role := args[0]
-			requirements, _ := ansible.ReadRequirements()
-			found := false
-
-			for i, r := range requirements.Roles {
-				if r.Name == role {
-					changed = append(requirements.Roles[:i], requirements.Roles[i+1:]...)
-					found = true
-				}
+
+			requirements, err := ansible.LoadRequirementsFile()
+			if err != nil {
+				return err
 			}
 
-			if !found {
+			if !requirements.RemoveRole(role) {
 				return fmt.Errorf("role '%s' not present", role)
 			}
 
-			if len(changed) > 0 {
-				requirements.Roles = changed
-				if err := ansible.SaveRequirements(requirements); err != nil {
-					return err
-				}
-
-				msg := fmt.Sprintf("role '%s' removed from requirements.yml", role)
-				fmt.Println(textformat.Info(msg))
+			if err := requirements.Save(); err != nil {
+				return err
 			}
 
+			msg := fmt.Sprintf("role '%s' removed from requirements.yml", role)
+			fmt.Println(textformat.Info(msg))
+
 			if r, _ := cmd.Flags().GetBool("purge"); r {
 				return ansible.RemoveRole(role)
 			}
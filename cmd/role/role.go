@@ -17,7 +17,7 @@ limitations under the License.
 // Package role implements the "ansible-dev role" command group, which
 // provides subcommands for managing Ansible roles in the development
 // environment. Operations include adding, comparing, creating, deleting,
-// listing, and removing roles.
+// listing, purging, and removing roles.
 package role
 
 import (
@@ -39,6 +39,7 @@ import (
 //   - delete:  delete a role's directory from the roles path.
 //   - list:    list roles declared in requirements.yml or installed on disk.
 //   - new:     scaffold a new role via ansible-galaxy init.
+//   - purge:   delete installed roles not needed by requirements.yml.
 //   - remove:  remove a role entry from requirements.yml.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
@@ -58,6 +59,7 @@ func NewCommand() *cobra.Command {
 	cmd.AddCommand(deleteCmd())
 	cmd.AddCommand(listCmd())
 	cmd.AddCommand(newCmd())
+	cmd.AddCommand(purgeCmd())
 	cmd.AddCommand(removeCmd())
 
 	return cmd
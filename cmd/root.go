@@ -23,22 +23,42 @@ limitations under the License.
 // development environment:
 //
 //   - collection: manage Ansible collections in requirements.yml.
+//   - config:     show or change the ansible-dev configuration.
+//   - console:    open a shell on a host through the backend.
+//   - copy:       copy a local file to the hosts.
 //   - destroy:    tear down the Vagrant environment.
+//   - doctor:     diagnose and repair the environment.
+//   - fetch:      fetch a file from the hosts into per-host directories.
 //   - initialize: scaffold a new Ansible project.
-//   - inventory:  display the host inventory.
+//   - inventory:  display or edit the host inventory.
 //   - ping:       verify host reachability.
 //   - play:       provision roles against Vagrant hosts.
 //   - reset:      reset the development environment.
 //   - restore:    install dependencies from requirements.yml.
 //   - role:       manage Ansible roles (add, compare, delete, list, new, remove).
+//   - run:        run any module on the hosts and report per-host results.
 //   - runbook:    execute the project's runbook playbook.
 //   - shell:      run ad-hoc shell commands on all hosts.
+//   - ssh:        open an SSH session on a host.
+//   - snapshot:   save, restore, list, and delete VM snapshots.
 //   - start/up:   boot and optionally provision VMs.
 //   - status:     show Vagrant VM state.
 //   - stop/down:  gracefully halt VMs.
 //   - tag:        list tags defined in a role.
 //   - task:       list tasks that would execute for a role.
 //   - upgrade:    update and prune Vagrant boxes.
+//   - vars:       explain where the variables of a host come from.
+//   - vm:         manage the machine definitions and regenerate the
+//     Vagrantfile, hosts.ini, and host_vars from them.
+//
+// The persistent --dry-run flag is available on every command. When set,
+// external programs are not executed and generated files are not written;
+// the exact command lines and file contents are printed instead. The
+// persistent --set key=value flag overrides any configuration value for a
+// single invocation.
+//
+// The development hosts are Vagrant VMs unless the "backend" configuration
+// value selects Docker or Podman containers instead.
 package cmd
 
 import (
@@ -46,31 +66,56 @@ import (
 	"os"
 
 	"github.com/dcjulian29/ansible-dev/cmd/collection"
+	"github.com/dcjulian29/ansible-dev/cmd/config"
+	"github.com/dcjulian29/ansible-dev/cmd/console"
+	"github.com/dcjulian29/ansible-dev/cmd/copyfile"
 	"github.com/dcjulian29/ansible-dev/cmd/destroy"
+	"github.com/dcjulian29/ansible-dev/cmd/doctor"
+	"github.com/dcjulian29/ansible-dev/cmd/fetch"
 	"github.com/dcjulian29/ansible-dev/cmd/initialize"
 	"github.com/dcjulian29/ansible-dev/cmd/inventory"
+	"github.com/dcjulian29/ansible-dev/cmd/lock"
+	"github.com/dcjulian29/ansible-dev/cmd/outdated"
 	"github.com/dcjulian29/ansible-dev/cmd/ping"
 	"github.com/dcjulian29/ansible-dev/cmd/play"
 	"github.com/dcjulian29/ansible-dev/cmd/reset"
 	"github.com/dcjulian29/ansible-dev/cmd/restore"
 	"github.com/dcjulian29/ansible-dev/cmd/role"
+	"github.com/dcjulian29/ansible-dev/cmd/run"
 	"github.com/dcjulian29/ansible-dev/cmd/runbook"
 	"github.com/dcjulian29/ansible-dev/cmd/shell"
+	"github.com/dcjulian29/ansible-dev/cmd/snapshot"
+	"github.com/dcjulian29/ansible-dev/cmd/ssh"
 	"github.com/dcjulian29/ansible-dev/cmd/start"
 	"github.com/dcjulian29/ansible-dev/cmd/status"
 	"github.com/dcjulian29/ansible-dev/cmd/stop"
 	"github.com/dcjulian29/ansible-dev/cmd/tag"
 	"github.com/dcjulian29/ansible-dev/cmd/task"
 	"github.com/dcjulian29/ansible-dev/cmd/upgrade"
+	"github.com/dcjulian29/ansible-dev/cmd/vars"
+	"github.com/dcjulian29/ansible-dev/cmd/vm"
+	cfg "github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
 	"go.szostok.io/version/extension"
 )
 
+var (
+	dryRun    bool
+	overrides []string
+)
+
 // rootCmd is the top-level Cobra command for the ansible-dev CLI. When
 // invoked without a subcommand it prints the help text. Both
 // SilenceErrors and SilenceUsage are enabled so that error formatting
 // is handled exclusively by [Execute].
+//
+// A PersistentPreRunE hook installs a [runner.DryRun] as the process-wide
+// runner when --dry-run is given, so that every subcommand previews its
+// commands and file writes instead of performing them. It then loads the
+// layered configuration once via [cfg.Load] so that every package sees the
+// same effective values.
 var rootCmd = &cobra.Command{
 	Use:   "ansible-dev",
 	Short: "ansible-dev enables development of Ansible playbooks, roles, and runbooks.",
@@ -82,6 +127,13 @@ across multiple hosting providers, reducing the time and effort required to mana
 infrastructure environments.`,
 	SilenceErrors: true,
 	SilenceUsage:  true,
+	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+		if dryRun {
+			runner.Set(runner.NewDryRun(os.Stdout))
+		}
+
+		return cfg.Load(overrides)
+	},
 	PreRunE: func(cmd *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			return cmd.Help()
@@ -117,21 +169,36 @@ func Execute() {
 // under cmd/ and exposes a NewCommand factory function that returns a
 // configured [cobra.Command].
 func init() {
+	rootCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the commands and file writes that would be performed without executing them")
+	rootCmd.PersistentFlags().StringArrayVar(&overrides, "set", []string{}, "override a configuration value (key=value)")
+
 	rootCmd.AddCommand(collection.NewCommand())
+	rootCmd.AddCommand(config.NewCommand())
+	rootCmd.AddCommand(console.NewCommand())
+	rootCmd.AddCommand(copyfile.NewCommand())
 	rootCmd.AddCommand(destroy.NewCommand())
+	rootCmd.AddCommand(doctor.NewCommand())
+	rootCmd.AddCommand(fetch.NewCommand())
 	rootCmd.AddCommand(initialize.NewCommand())
 	rootCmd.AddCommand(inventory.NewCommand())
+	rootCmd.AddCommand(lock.NewCommand())
+	rootCmd.AddCommand(outdated.NewCommand())
 	rootCmd.AddCommand(ping.NewCommand())
 	rootCmd.AddCommand(play.NewCommand())
 	rootCmd.AddCommand(reset.NewCommand())
 	rootCmd.AddCommand(restore.NewCommand())
 	rootCmd.AddCommand(role.NewCommand())
+	rootCmd.AddCommand(run.NewCommand())
 	rootCmd.AddCommand(runbook.NewCommand())
 	rootCmd.AddCommand(shell.NewCommand())
+	rootCmd.AddCommand(snapshot.NewCommand())
+	rootCmd.AddCommand(ssh.NewCommand())
 	rootCmd.AddCommand(start.NewCommand())
 	rootCmd.AddCommand(status.NewCommand())
 	rootCmd.AddCommand(stop.NewCommand())
 	rootCmd.AddCommand(tag.NewCommand())
 	rootCmd.AddCommand(task.NewCommand())
 	rootCmd.AddCommand(upgrade.NewCommand())
+	rootCmd.AddCommand(vars.NewCommand())
+	rootCmd.AddCommand(vm.NewCommand())
 }
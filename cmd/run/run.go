@@ -0,0 +1,233 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package run implements the "ansible-dev run" command, which runs any
+// Ansible module ad hoc on the hosts of the development environment and
+// reports the result of each host in a table or as JSON.
+package run
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+var (
+	moduleArgs string
+	become     bool
+	diffHosts  bool
+	limit      string
+	module     string
+	output     string
+)
+
+// result is one host of the report; Differs is only set with --diff-hosts.
+type result struct {
+	ansible.ModuleResult
+	Differs bool `json:"differs,omitempty"`
+}
+
+// NewCommand creates and returns the Cobra command for "ansible-dev run".
+//
+// Usage:
+//
+//	ansible-dev run -m <module> [-a <args>] [flags]
+//
+// For example:
+//
+//	ansible-dev run -m command -a "cat /etc/os-release"
+//	ansible-dev run -m ansible.builtin.service -a "name=sshd state=started" -b
+//
+// Under the hood, the command invokes:
+//
+//	ansible -i hosts.ini -m <module> -a <args> <pattern>
+//
+// with the ansible.posix JSON stdout callback enabled (see
+// [ansible.RunModule]) and prints one row per host with its status,
+// whether it changed, the return code and the module output. Modules that
+// do not produce standard output, such as ping, are shown with their
+// remaining result values as compact JSON.
+//
+// Flags:
+//   - --module, -m: the module to run (required).
+//   - --args, -a:   the module arguments.
+//   - --limit, -l:  Ansible host pattern selecting the hosts (default
+//     "all").
+//   - --become, -b: run the module as root (default false).
+//   - --output, -o: "table" (default) or "json".
+//   - --diff-hosts: highlight the hosts whose output differs from the
+//     output of the majority of the hosts, which is useful to spot the
+//     odd one out, e.g. a package version or a configuration file.
+//
+// The results are reported even when the module failed on some hosts; the
+// command then returns an error so that the exit status is non-zero.
+//
+// A PreRunE hook performs two checks before execution:
+//  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
+//     is a valid Ansible project.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run -m <module> [-a <args>]",
+		Short: "Run an Ansible module on the hosts of the Ansible development environment",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			results, err := ansible.RunModule(module, moduleArgs, limit, become)
+
+			if runner.IsDryRun() {
+				return err
+			}
+
+			report := make([]result, 0, len(results))
+			for _, r := range results {
+				report = append(report, result{ModuleResult: r})
+			}
+
+			if diffHosts {
+				markDifferences(report)
+			}
+
+			if output == "json" {
+				data, jerr := json.MarshalIndent(report, "", "  ")
+				if jerr != nil {
+					return jerr
+				}
+
+				fmt.Println(string(data))
+
+				return err
+			}
+
+			if rerr := render(report); rerr != nil {
+				return rerr
+			}
+
+			return err
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			switch output {
+			case "table", "json":
+			default:
+				return fmt.Errorf("unsupported output format '%s' (table, json)", output)
+			}
+
+			return backend.Ensure()
+		},
+	}
+
+	cmd.Flags().StringVarP(&module, "module", "m", "", "the Ansible module to run")
+	cmd.Flags().StringVarP(&moduleArgs, "args", "a", "", "the module arguments")
+	cmd.Flags().StringVarP(&limit, "limit", "l", "all", "only run the module on hosts matching the pattern")
+	cmd.Flags().BoolVarP(&become, "become", "b", false, "run the module as root")
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "output format: table or json")
+	cmd.Flags().BoolVar(&diffHosts, "diff-hosts", false, "highlight hosts whose output differs from the majority")
+
+	_ = cmd.MarkFlagRequired("module")
+
+	return cmd
+}
+
+// outputOf returns the value compared by --diff-hosts: the status together
+// with everything the module reported.
+func outputOf(r result) string {
+	return strings.Join([]string{r.Status, r.Stdout, r.Stderr, r.Msg}, "\x00")
+}
+
+// markDifferences sets Differs on every result whose output is not the
+// most common one. When there is no majority, e.g. two hosts with
+// different output, every host differs.
+func markDifferences(report []result) {
+	counts := make(map[string]int, len(report))
+	for _, r := range report {
+		counts[outputOf(r)]++
+	}
+
+	majority, best, tied := "", 0, false
+
+	for _, r := range report {
+		switch n := counts[outputOf(r)]; {
+		case n > best:
+			majority, best, tied = outputOf(r), n, false
+		case n == best && outputOf(r) != majority:
+			tied = true
+		}
+	}
+
+	for i := range report {
+		report[i].Differs = tied || outputOf(report[i]) != majority
+	}
+}
+
+// render writes the report as a table with one row per host. Failed and
+// unreachable hosts show their message when they produced no output, and
+// with --diff-hosts the hosts whose output differs are shown in red.
+func render(report []result) error {
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+
+	header := []any{"Host", "Status", "Changed", "RC", "Output"}
+	if diffHosts {
+		header = append(header, "Differs")
+	}
+
+	table.Header(header...)
+
+	for _, r := range report {
+		var rc string
+		if r.RC != nil {
+			rc = strconv.Itoa(*r.RC)
+		}
+
+		text := r.Stdout
+		if len(text) == 0 {
+			text = r.Msg
+		}
+
+		host := r.Host
+		row := []string{host, r.Status, strconv.FormatBool(r.Changed), rc, strings.TrimRight(text, "\n")}
+
+		if diffHosts {
+			differs := ""
+			if r.Differs {
+				differs = "yes"
+				row[0] = textformat.Red(host)
+			}
+
+			row = append(row, differs)
+		}
+
+		if err := table.Append(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
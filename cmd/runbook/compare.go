@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
@@ -32,20 +33,22 @@ import (
 // compareCmd creates the Cobra command for "ansible-dev runbook compare", the
 // runbook analogue of "role compare". Where role compare walks the local roles
 // directory, runbook compare is driven from the source side: it walks each
-// runbook repository under the ANSIBLE_RUNBOOKS environment variable and
-// compares it against the collection installed in the current project.
+// runbook repository under the "paths.runbooks" configuration value (which
+// defaults to the ANSIBLE_RUNBOOKS environment variable) and compares it
+// against the collection installed in the current project.
 //
 // For each runbook directory it reads galaxy.yml to obtain the namespace and
 // name (so no namespace is hard-coded), locates the installed collection at
 // <collections_path>/ansible_collections/<namespace>/<name>, and delegates the
 // file-by-file hash comparison to [ansible.ComparePair].
 //
-// The ignore set is a fixed list that mirrors the WinMerge "AnsibleRunbooks"
-// file filter, so the checksum comparison and the visual diff exclude the same
-// files: the source repo's SCM and per-repo files (which galaxy strips on
-// build, e.g. galaxy.yml, README.md, .devcontainer) and the installed copy's
-// runtime artifacts (MANIFEST.json, FILES.json). Runbooks present in
-// ANSIBLE_RUNBOOKS but not installed are reported and skipped.
+// The ignore set is the "compare.runbook_ignore" configuration value, which by
+// default mirrors the WinMerge "AnsibleRunbooks" file filter
+// ("compare.runbook_filter"), so the checksum comparison and the visual diff
+// exclude the same files: the source repo's SCM and per-repo files (which
+// galaxy strips on build, e.g. galaxy.yml, README.md, .devcontainer) and the
+// installed copy's runtime artifacts (MANIFEST.json, FILES.json). Runbooks
+// present in the source directory but not installed are reported and skipped.
 //
 // Flags:
 //   - --checksum: print per-file hash comparisons.
@@ -63,9 +66,11 @@ func compareCmd() *cobra.Command {
 			sep := string(os.PathSeparator)
 			pwd, _ := os.Getwd()
 
-			runbooksFolder := strings.ReplaceAll(os.Getenv("ANSIBLE_RUNBOOKS"), "\\", sep)
+			cfg := config.Current()
+
+			runbooksFolder := strings.ReplaceAll(cfg.Paths.Runbooks, "\\", sep)
 			if len(runbooksFolder) == 0 {
-				return errors.New("the Ansible runbooks directory is not defined in environment")
+				return errors.New("the Ansible runbooks directory is not configured (set 'paths.runbooks' or ANSIBLE_RUNBOOKS)")
 			}
 
 			collections, err := ansible.CollectionsFolder()
@@ -87,28 +92,12 @@ func compareCmd() *cobra.Command {
 
 			home := ansible.HomeFolder()
 
-			// This list mirrors the WinMerge "AnsibleRunbooks" file filter so
-			// that the checksum comparison and the visual diff agree on what to
-			// exclude: the source repo's SCM and per-repo files (which galaxy
+			// The default list mirrors the WinMerge "AnsibleRunbooks" file filter
+			// so that the checksum comparison and the visual diff agree on what
+			// to exclude: the source repo's SCM and per-repo files (which galaxy
 			// strips on build) and the installed copy's runtime artifacts
 			// (galaxy.yml is replaced by MANIFEST.json / FILES.json on install).
-			ignored := []string{
-				".ansible-lint",
-				".editorconfig",
-				".gitattributes",
-				".gitignore",
-				".yamllint",
-				"build.cmd",
-				"FILES.json",
-				"galaxy.yml",
-				"MANIFEST.json",
-				"README.md",
-				".tar.gz",
-				".devcontainer",
-				".git",
-				".github",
-				".vscode",
-			}
+			ignored := cfg.Compare.RunbookIgnore
 
 			for _, e := range entries {
 				if !e.IsDir() {
@@ -133,7 +122,7 @@ func compareCmd() *cobra.Command {
 				}
 
 				if _, err := ansible.ComparePair(
-					installedEntry, sourceEntry, ignored, checksum, nodiff, "AnsibleRunbooks", home,
+					installedEntry, sourceEntry, ignored, checksum, nodiff, cfg.Compare.RunbookFilter, home,
 				); err != nil {
 					return err
 				}
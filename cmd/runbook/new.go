@@ -32,11 +32,13 @@ import (
 //
 //	ansible-dev runbook new <runbook> [flags]
 //
-// The runbook is rendered into the directory named by the ANSIBLE_RUNBOOKS
-// environment variable joined with <runbook>, with !!RUNBOOK_NAME!! and
-// !!RUNBOOK_DESC!! substituted. When --publish is set, the directory is
-// committed to a new git repository and pushed to a freshly-created public
-// GitHub repository named "ansible-runbook-<runbook>".
+// The runbook is rendered into the runbook source directory ("paths.runbooks",
+// defaulting to the ANSIBLE_RUNBOOKS environment variable) joined with
+// <runbook>, with !!RUNBOOK_NAME!! and !!RUNBOOK_DESC!! substituted. When
+// --publish is set, the directory is committed to a new git repository and
+// pushed to a freshly-created public GitHub repository named from the
+// "github.owner" and "github.runbook_prefix" configuration values (by default
+// "dcjulian29/ansible-runbook-<runbook>").
 //
 // Flags:
 //   - --description, -d: description text substituted for !!RUNBOOK_DESC!! in
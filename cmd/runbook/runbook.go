@@ -23,7 +23,7 @@ import (
 	"errors"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
 	"github.com/spf13/cobra"
 )
 
@@ -38,6 +38,9 @@ import (
 // Flags:
 //   - --verbose, -v:          tell Ansible to print more debug messages
 //     (default false). Maps to [ansible.Play.Verbose].
+//   - --limit, -l:            Ansible host pattern restricting the hosts
+//     the runbook runs against (default: all). Maps to
+//     [ansible.Play.Limit].
 //   - --ask-become-password:  prompt for the privilege escalation (sudo)
 //     password at runtime (default false). Maps to
 //     [ansible.Play.AskBecomePass].
@@ -52,8 +55,9 @@ import (
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
 //     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present,
-//     since the runbook targets Vagrant-managed hosts.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present), since the runbook
+//     targets the development hosts.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "runbook",
@@ -65,6 +69,7 @@ func NewCommand() *cobra.Command {
 
 			play.AskBecomePass, _ = cmd.Flags().GetBool("becomepass")
 			play.AskVaultPass = false
+			play.Limit, _ = cmd.Flags().GetString("limit")
 			play.FlushCache, _ = cmd.Flags().GetBool("flushcache")
 			play.Step, _ = cmd.Flags().GetBool("step")
 			play.Verbose, _ = cmd.Flags().GetBool("verbose")
@@ -80,7 +85,7 @@ func NewCommand() *cobra.Command {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
@@ -88,6 +93,7 @@ func NewCommand() *cobra.Command {
 	cmd.Flags().Bool("ask-become-password", false, "ask for privilege escalation password")
 	cmd.Flags().Bool("flush-cache", false, "clear the fact cache for every host in inventory")
 	cmd.Flags().BoolP("step", "s", false, "one-step-at-a-time: confirm each task before running")
+	cmd.Flags().StringP("limit", "l", "", "further limit selected hosts to an additional pattern")
 
 	cmd.AddCommand(compareCmd())
 	cmd.AddCommand(newCmd())
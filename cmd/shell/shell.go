@@ -21,16 +21,18 @@ package shell
 
 import (
 	"errors"
-	"fmt"
 	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/spf13/cobra"
 )
 
-var shellCommand string
+var (
+	limit        string
+	shellCommand string
+)
 
 // NewCommand creates and returns the Cobra command for "ansible-dev shell",
 // which runs an ad-hoc shell command on every host defined in the
@@ -41,8 +43,9 @@ var shellCommand string
 //	ansible-dev shell [flags] -- <command>
 //
 // Everything after the "--" separator is treated as the shell command to
-// execute. Multiple words are joined into a single string and passed to
-// the Ansible "shell" module via its -a argument. For example:
+// execute. Multiple words are joined into a single string and passed
+// unmodified to the Ansible "shell" module via its -a argument, so quotes
+// within the command reach the remote shell intact. For example:
 //
 //	ansible-dev shell -- uptime
 //	ansible-dev shell -- cat /etc/hostname
@@ -52,15 +55,18 @@ var shellCommand string
 //	ansible -i hosts.ini -m shell -a "<command>" all
 //
 // targeting the "all" host pattern, which runs the command on every host
-// in the inventory. If no arguments are supplied, the help text is
-// displayed instead.
+// in the inventory, or the Ansible host pattern given with --limit (-l).
+// In dynamic inventory mode the inventory script is used instead of
+// hosts.ini (see [ansible.InventorySource]).
+// If no arguments are supplied, the help text is displayed instead.
 //
 // A PreRunE hook performs two checks before execution:
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
 //     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present,
-//     since the command targets Vagrant-managed hosts.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present), since the command
+//     targets the development hosts.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "shell [flags] -- <command>",
@@ -75,23 +81,24 @@ func NewCommand() *cobra.Command {
 			}
 
 			param := []string{
-				"-i", "hosts.ini",
+				"-i", ansible.InventorySource(),
 				"-m", "shell",
-				"-a", fmt.Sprintf("\"%s\"", shellCommand),
-				"all",
+				"-a", shellCommand,
+				limit,
 			}
 
-			fmt.Println(param)
-			return execute.ExternalProgram("ansible", param...)
+			return runner.Run("ansible", param...)
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := ansible.EnsureAnsibleDirectory(); err != nil {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
+	cmd.Flags().StringVarP(&limit, "limit", "l", "all", "only run the command on hosts matching the pattern")
+
 	return cmd
 }
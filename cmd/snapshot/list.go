@@ -0,0 +1,67 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"os"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// listCmd creates the Cobra command for "ansible-dev snapshot list", which
+// renders a table of the snapshots of each selected VM as reported by
+// [vagrant.ListSnapshots].
+//
+// Usage:
+//
+//	ansible-dev snapshot list [--vm <vm>]...
+//
+// VMs whose snapshots cannot be listed (for example because they have not
+// been created) are shown without snapshots.
+func listCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the Ansible development vagrant environment",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			names, err := targets()
+			if err != nil {
+				return err
+			}
+
+			table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+			table.Header("VM", "Snapshots")
+
+			for _, vm := range names {
+				snapshots, _ := vagrant.ListSnapshots(vm)
+
+				if err := table.Append([]string{vm, strings.Join(snapshots, ",")}); err != nil {
+					return err
+				}
+			}
+
+			return table.Render()
+		},
+		PreRunE: ensure,
+	}
+
+	return cmd
+}
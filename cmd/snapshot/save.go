@@ -0,0 +1,46 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package snapshot
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/spf13/cobra"
+)
+
+// saveCmd creates the Cobra command for "ansible-dev snapshot save", which
+// saves a snapshot of each selected VM via [vagrant.SaveSnapshot]. An
+// existing snapshot with the same name is replaced.
+//
+// Usage:
+//
+//	ansible-dev snapshot save [name] [--vm <vm>]...
+//
+// When no name is given [vagrant.DefaultSnapshot] is used. Processing
+// stops at the first VM that fails.
+func saveCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "save [name]",
+		Short: "Save a snapshot of the Ansible development vagrant environment",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return forEach(args, vagrant.SaveSnapshot)
+		},
+		PreRunE: ensure,
+	}
+
+	return cmd
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package snapshot implements the "ansible-dev snapshot" command group,
+// which checkpoints and restores the Vagrant virtual machines of the
+// Ansible development environment using "vagrant snapshot". Available
+// subcommands include delete, list, restore, and save.
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/spf13/cobra"
+)
+
+var vms []string
+
+// NewCommand creates and returns the Cobra command for the "snapshot"
+// command group. The command is also aliased as "snapshots" for
+// convenience.
+//
+// When invoked without a subcommand it prints the help text. Every
+// subcommand operates on all hosts in the [vagrant] section of hosts.ini
+// unless one or more --vm flags narrow the selection.
+//
+// The command and every subcommand use a PreRunE hook (see [ensure]) that
+// performs three checks before execution:
+//  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
+//     is a valid Ansible project.
+//  2. the configured backend is "vagrant" — only Vagrant supports
+//     snapshots.
+//  3. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present.
+//
+// The following subcommands are registered:
+//   - delete:  delete a snapshot.
+//   - list:    list the snapshots of each VM.
+//   - restore: restore a snapshot.
+//   - save:    save a snapshot.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "snapshot",
+		Aliases: []string{"snapshots"},
+		Short:   "Manage snapshots of the Ansible development vagrant environment",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+		PreRunE: ensure,
+	}
+
+	cmd.PersistentFlags().StringSliceVar(&vms, "vm", []string{}, "only operate on the specified VM(s)")
+
+	cmd.AddCommand(deleteCmd())
+	cmd.AddCommand(listCmd())
+	cmd.AddCommand(restoreCmd())
+	cmd.AddCommand(saveCmd())
+
+	return cmd
+}
+
+// ensure verifies that the current directory is an Ansible development
+// directory with a Vagrantfile and that the Vagrant backend is configured,
+// since only Vagrant supports snapshots.
+func ensure(_ *cobra.Command, _ []string) error {
+	if err := ansible.EnsureAnsibleDirectory(); err != nil {
+		return errors.New("not an Ansible development directory")
+	}
+
+	b, err := backend.Current()
+	if err != nil {
+		return err
+	}
+
+	if b.Name() != "vagrant" {
+		return fmt.Errorf("the '%s' backend does not support snapshots", b.Name())
+	}
+
+	return vagrant.EnsureVagrantfile()
+}
+
+// targets returns the VMs selected by --vm, or every inventory host when
+// no --vm flag was given (see [ansible.SelectHosts]).
+func targets() ([]string, error) {
+	return ansible.SelectHosts(vms)
+}
+
+// snapshotName returns the snapshot name given as the first argument, or
+// [vagrant.DefaultSnapshot] when none was given.
+func snapshotName(args []string) string {
+	if len(args) > 0 {
+		return args[0]
+	}
+
+	return vagrant.DefaultSnapshot
+}
+
+// forEach calls fn with every selected VM and the snapshot name, stopping
+// at the first error.
+func forEach(args []string, fn func(vm, name string) error) error {
+	names, err := targets()
+	if err != nil {
+		return err
+	}
+
+	name := snapshotName(args)
+
+	for _, vm := range names {
+		if err := fn(vm, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
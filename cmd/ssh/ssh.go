@@ -0,0 +1,167 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ssh implements the "ansible-dev ssh" command, which opens an SSH
+// session on a development host using the connection details Ansible
+// resolves for it, so that the session reaches the host exactly as Ansible
+// does.
+package ssh
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+var become bool
+
+// NewCommand creates and returns the Cobra command for "ansible-dev ssh".
+//
+// Usage:
+//
+//	ansible-dev ssh <vm> [flags] [-- <command>]
+//
+// The command checks the host is in the inventory via [ansible.SelectHosts],
+// resolves its variables with "ansible-inventory --host" via
+// [ansible.ResolveHost], and runs "ssh" with its ansible_host, ansible_port,
+// ansible_user and ansible_ssh_private_key_file values and the "-o" options
+// of ansible_ssh_common_args and ansible_ssh_extra_args. Values set in
+// [all:vars], a group, group_vars/ or host_vars/ are used as well as those
+// set on the host, and in dynamic inventory mode the address reported by
+// the backend is used. Host key checking is disabled, as it is for Ansible,
+// because lab VMs are recreated often. Everything after the "--" separator
+// is run on the host instead of an interactive shell.
+//
+// When the host has no usable SSH details (its address is still the
+// 0.0.0.0 placeholder, no user is set, or it is reached through a
+// container connection plugin) the command falls back to the backend's own
+// channel, e.g. "vagrant ssh" (see [backend.Backend.Console]).
+//
+// VM names are completed from the inventory.
+//
+// Flags:
+//   - --become, -b: open a root login shell ("sudo -i"), or run the command
+//     with sudo (default false).
+//
+// A PreRunE hook performs two checks before execution:
+//  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
+//     is a valid Ansible project.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ssh <vm> [flags] [-- <command>]",
+		Short: "Open an SSH session on a host of the Ansible development environment",
+		Args:  cobra.MinimumNArgs(1),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) > 0 {
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return ansible.HostNames(), cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			name := args[0]
+			command := strings.Join(args[1:], " ")
+
+			if _, err := ansible.SelectHosts(args[:1]); err != nil {
+				return err
+			}
+
+			host, err := ansible.ResolveHost(name)
+			if err != nil {
+				return err
+			}
+
+			if !reachable(host) {
+				b, err := backend.Current()
+				if err != nil {
+					return err
+				}
+
+				return b.Console(name, command, become)
+			}
+
+			return runner.Run("ssh", sshArgs(host, command)...)
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if err := ansible.EnsureAnsibleDirectory(); err != nil {
+				return errors.New("not an Ansible development directory")
+			}
+
+			return backend.Ensure()
+		},
+	}
+
+	cmd.Flags().BoolVarP(&become, "become", "b", false, "open a root shell or run the command with sudo")
+
+	return cmd
+}
+
+// reachable reports whether host has the details needed to connect with
+// ssh directly: a real address, a user and no container connection plugin
+// (see [ansible.HostConnection.Reachable]).
+func reachable(host ansible.HostConnection) bool {
+	return len(host.User) > 0 && host.Reachable()
+}
+
+// sshArgs returns the ssh arguments connecting to host and running command
+// (an interactive shell when empty).
+func sshArgs(host ansible.HostConnection, command string) []string {
+	param := []string{
+		"-o", "StrictHostKeyChecking=no",
+		"-o", "UserKnownHostsFile=/dev/null",
+		"-o", "LogLevel=ERROR",
+	}
+
+	if len(host.Port) > 0 {
+		param = append(param, "-p", host.Port)
+	}
+
+	if len(host.IdentityFile) > 0 {
+		param = append(param, "-i", host.IdentityFile)
+	}
+
+	for _, option := range host.SSHOptions {
+		param = append(param, "-o", option)
+	}
+
+	if len(command) == 0 {
+		param = append(param, "-t")
+	}
+
+	destination := host.Address
+	if len(host.User) > 0 {
+		destination = host.User + "@" + destination
+	}
+
+	param = append(param, destination)
+
+	switch {
+	case len(command) > 0 && become:
+		param = append(param, "sudo sh -c "+runner.Quote(command))
+	case len(command) > 0:
+		param = append(param, command)
+	case become:
+		param = append(param, "sudo -i")
+	}
+
+	return param
+}
@@ -15,42 +15,65 @@ limitations under the License.
 */
 
 // Package start implements the "ansible-dev start" (aliased as "up")
-// command, which boots the Vagrant development environment and
-// optionally provisions it with Ansible roles.
+// command, which boots the development hosts (Vagrant VMs or containers)
+// and optionally provisions them with Ansible roles.
 package start
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/doctor"
 	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/go-toolbox/textformat"
 	"github.com/spf13/cobra"
 )
 
 var (
-	roles   []string
-	tags    []string
-	verbose bool
+	baseline bool
+	parallel int
+	roles    []string
+	tags     []string
+	verbose  bool
+	vms      []string
 )
 
 // NewCommand creates and returns the Cobra command for "ansible-dev start",
-// which boots every host in the project's inventory and optionally
+// which boots the hosts in the project's inventory and optionally
 // provisions them with the specified Ansible roles. The command is also
 // aliased as "up" for convenience.
 //
-// The command proceeds in two phases:
+// The command proceeds in three phases:
 //
-//  1. Start VMs — reads the inventory via [ansible.GetInventory] and
-//     calls [vagrant.Up] for each host, passing the host name and IP
-//     address. Each VM is started sequentially; the command aborts on
-//     the first failure.
+//  1. Start VMs — selects the hosts via [ansible.SelectHosts] and
+//     calls [backend.Backend.Up] of the configured backend with their
+//     names; for Vagrant this is [vagrant.UpAll]. By default each VM is
+//     started sequentially and the command aborts on the first failure.
+//     With --parallel the VMs are started concurrently, their output is
+//     prefixed with the machine name, and a per-VM summary is printed.
 //
-//  2. Provision (optional) — calls [ansible.ApplyRoles] with the
-//     collected roles, tags, and verbose flag. When no roles are
+//  2. Baseline (optional) — with --baseline, calls
+//     [vagrant.SaveBaseline] to record a "baseline" snapshot of every VM
+//     that does not have one yet, so that "ansible-dev reset --fast" can
+//     later return the VMs to this freshly booted state. Only the
+//     Vagrant backend supports snapshots.
+//
+//  3. Provision (optional) — calls [ansible.ApplyRoles] with the
+//     collected roles, tags, and verbose flag, limited to the selected
+//     VMs when --vm is given. When no roles are
 //     specified (empty --role), ApplyRoles controls whether
 //     provisioning is skipped or a default set is applied.
 //
 // Flags:
+//   - --baseline:    record a "baseline" snapshot after the first
+//     successful boot of each VM (default false).
+//   - --parallel:    start VMs concurrently. "--parallel" alone starts
+//     every VM at once; "--parallel=N" starts at most N at a time
+//     (default 1, sequential). Providers that cannot boot machines in
+//     parallel (e.g. VirtualBox) fall back to sequential startup.
 //   - --role, -r:    one or more Ansible roles to provision the VMs
 //     with after startup. Accepts repeated flags or comma-separated
 //     values (default empty).
@@ -59,30 +82,50 @@ var (
 //     values (default empty).
 //   - --verbose, -v: tell Ansible to print more debug messages during
 //     provisioning (default false).
+//   - --vm:          only start (and provision) the named VM. May be
+//     repeated (default: every host in hosts.ini).
 //
-// A PreRunE hook performs two checks before execution:
+// A PreRunE hook performs three checks before execution:
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
-//     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present.
+//     development directory" message on failure. ansible.cfg is then
+//     pointed at hosts.ini or, in dynamic inventory mode, at the inventory
+//     script (see [ansible.EnsureInventorySource]).
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
+//  3. [doctor.PreCheck] — confirms that the machines of the backend and
+//     the hosts of the [vagrant] group agree, so that no VM is silently
+//     skipped; "ansible-dev doctor inventory --fix" reconciles them.
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "start",
 		Aliases: []string{"up"},
 		Short:   "Starts and potentially provision the Ansible development vagrant environment",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			inventory, err := ansible.GetInventory()
+			names, err := ansible.SelectHosts(vms)
 			if err != nil {
 				return err
 			}
 
-			for _, host := range inventory {
-				if err := vagrant.Up(host.Name); err != nil {
+			b, err := backend.Current()
+			if err != nil {
+				return err
+			}
+
+			if err := b.Up(names, parallel); err != nil {
+				return err
+			}
+
+			if baseline {
+				if b.Name() != "vagrant" {
+					fmt.Println(textformat.Yellow(fmt.Sprintf(
+						"\nThe '%s' backend does not support snapshots; no baseline recorded.", b.Name())))
+				} else if err := vagrant.SaveBaseline(names); err != nil {
 					return err
 				}
 			}
 
-			err = ansible.ApplyRoles(roles, tags, verbose)
+			err = ansible.ApplyRoles(roles, tags, strings.Join(vms, ","), verbose)
 			if err != nil {
 				return err
 			}
@@ -98,13 +141,25 @@ func NewCommand() *cobra.Command {
 				return err
 			}
 
-			return vagrant.EnsureVagrantfile()
+			if err := ansible.EnsureInventorySource(); err != nil {
+				return err
+			}
+
+			if err := backend.Ensure(); err != nil {
+				return err
+			}
+
+			return doctor.PreCheck()
 		},
 	}
 
+	cmd.Flags().BoolVar(&baseline, "baseline", false, "record a baseline snapshot after the first successful boot")
+	cmd.Flags().IntVar(&parallel, "parallel", 1, "start up to N VMs concurrently (all when no value is given)")
+	cmd.Flags().Lookup("parallel").NoOptDefVal = "0"
 	cmd.Flags().StringSliceVarP(&roles, "role", "r", []string{}, "provision the VMs with the specified role(s)")
 	cmd.Flags().StringSliceVarP(&tags, "tag", "t", []string{}, "apply the role(s) with the specified tag(s)")
 	cmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "tell Ansible to print more debug messages")
+	cmd.Flags().StringSliceVar(&vms, "vm", []string{}, "only start the specified VM(s)")
 
 	return cmd
 }
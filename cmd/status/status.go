@@ -16,47 +16,223 @@ limitations under the License.
 
 // Package status implements the "ansible-dev status" command, which
 // displays the current state of the Vagrant virtual machines in the
-// Ansible development environment.
+// Ansible development environment together with their inventory address
+// and the roles that have been applied to them.
 package status
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
+var output string
+
+// machine is one row of the status report.
+type machine struct {
+	Name     string                `json:"name" yaml:"name"`
+	State    string                `json:"state" yaml:"state"`
+	Provider string                `json:"provider" yaml:"provider"`
+	Address  string                `json:"address" yaml:"address"`
+	Roles    []ansible.AppliedRole `json:"roles" yaml:"roles"`
+}
+
 // NewCommand creates and returns the Cobra command for "ansible-dev status",
-// which outputs the current state of the Vagrant-managed virtual machines
-// by delegating directly to "vagrant status".
+// which reports the current state of the Vagrant-managed virtual machines.
+//
+// The state and provider of each host are read from the configured backend
+// (see [backend.Backend.Status]; for Vagrant this is
+// "vagrant status --machine-readable") and joined with the address recorded
+// in hosts.ini (see [ansible.GetInventory]) and the roles last applied to
+// the host (see [ansible.ReadProvisioning]). Hosts that are in the
+// inventory but unknown to the backend are reported with the state
+// "unknown", or "not_created" for a container backend, which only reports
+// the containers that exist.
 //
-// The command has no flags or positional arguments. Its output mirrors
-// what "vagrant status" produces — typically a table listing each VM's
-// name, provider, and current state (e.g. running, poweroff, not created).
+// Flags:
+//   - --output, -o: "table" (default), "json", or "yaml".
 //
 // A PreRunE hook performs two checks before execution:
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
 //     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present
-//     so that "vagrant status" has a valid configuration to report on.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present so that "vagrant status"
+//     has a valid configuration to report on).
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Output status of the Ansible development vagrant environment",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return execute.ExternalProgram("vagrant", "status")
+			machines, err := collect()
+			if err != nil {
+				return err
+			}
+
+			switch output {
+			case "json":
+				data, err := json.MarshalIndent(machines, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(data))
+
+				return nil
+			case "yaml":
+				encoder := yaml.NewEncoder(os.Stdout)
+				encoder.SetIndent(2)
+
+				if err := encoder.Encode(machines); err != nil {
+					return err
+				}
+
+				return encoder.Close()
+			default:
+				return render(machines)
+			}
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := ansible.EnsureAnsibleDirectory(); err != nil {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			switch output {
+			case "table", "json", "yaml":
+			default:
+				return fmt.Errorf("unsupported output format '%s' (table, json, yaml)", output)
+			}
+
+			return backend.Ensure()
 		},
 	}
 
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "output format: table, json, or yaml")
+
 	return cmd
 }
+
+// collect joins the Vagrant machine state with the inventory addresses and
+// the provisioning record, in Vagrant's machine order.
+func collect() ([]machine, error) {
+	b, err := backend.Current()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses, err := b.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	inventory, err := ansible.GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	provisioning, err := ansible.ReadProvisioning()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make(map[string]string, len(inventory))
+	for _, host := range inventory {
+		addresses[host.Name] = host.Address
+	}
+
+	if config.Current().Inventory.Dynamic {
+		// hosts.ini is not kept up to date, so ask the backend instead.
+		for _, m := range statuses {
+			if m.State != "running" {
+				continue
+			}
+
+			vars, err := b.Connection(m.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			addresses[m.Name] = ansible.DynamicHost(vars)["ansible_host"]
+		}
+	}
+
+	machines := make([]machine, 0, len(statuses))
+	seen := make(map[string]bool, len(statuses))
+
+	for _, s := range statuses {
+		seen[s.Name] = true
+		machines = append(machines, machine{
+			Name:     s.Name,
+			State:    s.State,
+			Provider: s.Provider,
+			Address:  addresses[s.Name],
+			Roles:    provisioning[s.Name],
+		})
+	}
+
+	missing := "unknown"
+	if b.Name() != "vagrant" {
+		missing = "not_created"
+	}
+
+	for _, host := range inventory {
+		if seen[host.Name] {
+			continue
+		}
+
+		machines = append(machines, machine{
+			Name:    host.Name,
+			State:   missing,
+			Address: host.Address,
+			Roles:   provisioning[host.Name],
+		})
+	}
+
+	for i := range machines {
+		if machines[i].Roles == nil {
+			machines[i].Roles = []ansible.AppliedRole{}
+		}
+	}
+
+	return machines, nil
+}
+
+// render writes machines as a table with one row per VM.
+func render(machines []machine) error {
+	table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+	table.Header("Name", "State", "Provider", "Address", "Roles", "Provisioned")
+
+	for _, m := range machines {
+		var (
+			roles       []string
+			provisioned string
+		)
+
+		for _, r := range m.Roles {
+			roles = append(roles, r.Name)
+		}
+
+		if len(m.Roles) > 0 {
+			provisioned = m.Roles[len(m.Roles)-1].Applied.Local().Format(time.DateTime)
+		}
+
+		row := []string{m.Name, m.State, m.Provider, m.Address, strings.Join(roles, ","), provisioned}
+
+		if err := table.Append(row); err != nil {
+			return err
+		}
+	}
+
+	return table.Render()
+}
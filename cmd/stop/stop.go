@@ -23,43 +23,62 @@ import (
 	"errors"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
 	"github.com/spf13/cobra"
 )
 
+var vms []string
+
 // NewCommand creates and returns the Cobra command for "ansible-dev stop",
-// which gracefully halts every Vagrant VM in the development environment
-// by delegating to [vagrant.Down]. The command is also aliased as "down"
+// which gracefully halts the development hosts by delegating to
+// [backend.Backend.Down] of the configured backend (for Vagrant,
+// [vagrant.Down]). The command is also aliased as "down"
 // for convenience.
 //
-// The command has no flags or positional arguments. It complements the
-// "ansible-dev start" / "up" command: "start" boots and optionally
-// provisions the VMs, while "stop" / "down" shuts them down without
-// destroying their state. To fully tear down the environment (including
+// Flags:
+//   - --vm: only halt the named VM. May be repeated (default: every host
+//     in hosts.ini).
+//
+// The command complements the "ansible-dev start" / "up" command: "start"
+// boots and optionally provisions the VMs, while "stop" / "down" shuts them
+// down without destroying their state. To fully tear down the environment (including
 // removing VM disk images), use "ansible-dev destroy" instead.
 //
 // A PreRunE hook performs two checks before execution:
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
 //     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present
-//     so that Vagrant knows which VMs to halt.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present so that Vagrant knows
+//     which VMs to halt).
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "stop",
 		Aliases: []string{"down"},
 		Short:   "Stops the Ansible development vagrant environment",
 		RunE: func(_ *cobra.Command, _ []string) error {
-			return vagrant.Down()
+			names, err := ansible.SelectHosts(vms)
+			if err != nil {
+				return err
+			}
+
+			b, err := backend.Current()
+			if err != nil {
+				return err
+			}
+
+			return b.Down(names...)
 		},
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if err := ansible.EnsureAnsibleDirectory(); err != nil {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
+	cmd.Flags().StringSliceVar(&vms, "vm", []string{}, "only stop the specified VM(s)")
+
 	return cmd
 }
@@ -22,8 +22,8 @@ import (
 	"errors"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/spf13/cobra"
 )
 
@@ -54,7 +54,8 @@ import (
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
 //     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tags <role>",
@@ -69,7 +70,7 @@ func NewCommand() *cobra.Command {
 				return err
 			}
 
-			err = execute.ExternalProgram("ansible-playbook", "--list-tags", ".tmp/play.yml")
+			err = runner.Run("ansible-playbook", "--list-tags", ".tmp/play.yml")
 			if err != nil {
 				return errors.New("can't execute playbook to list tags")
 			}
@@ -80,7 +81,7 @@ func NewCommand() *cobra.Command {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
@@ -24,8 +24,8 @@ import (
 	"strings"
 
 	"github.com/dcjulian29/ansible-dev/internal/ansible"
-	"github.com/dcjulian29/ansible-dev/internal/vagrant"
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/backend"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/spf13/cobra"
 )
 
@@ -63,7 +63,8 @@ import (
 //  1. [ansible.EnsureAnsibleDirectory] — verifies the current directory
 //     is a valid Ansible project. Returns a simplified "not an Ansible
 //     development directory" message on failure.
-//  2. [vagrant.EnsureVagrantfile] — confirms a Vagrantfile is present.
+//  2. [backend.Ensure] — confirms the configured backend can be used
+//     (for Vagrant, that a Vagrantfile is present).
 func NewCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "tasks <role>",
@@ -90,7 +91,7 @@ func NewCommand() *cobra.Command {
 
 			param = append(param, "--list-tasks", ".tmp/play.yml")
 
-			err = execute.ExternalProgram("ansible-playbook", param...)
+			err = runner.Run("ansible-playbook", param...)
 			if err != nil {
 				return err
 			}
@@ -102,7 +103,7 @@ func NewCommand() *cobra.Command {
 				return errors.New("not an Ansible development directory")
 			}
 
-			return vagrant.EnsureVagrantfile()
+			return backend.Ensure()
 		},
 	}
 
@@ -0,0 +1,109 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vars
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/dcjulian29/ansible-dev/internal/variables"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// explainCmd creates the Cobra command for "ansible-dev vars explain".
+//
+// Usage:
+//
+//	ansible-dev vars explain <host> [variable] [flags]
+//
+// Lists every definition of the variable, or of every variable of the host
+// when none is given, lowest precedence first, with its precedence level
+// (as numbered in the Ansible documentation), source, file and line, and
+// value. The definition that takes effect is marked in the "Wins" column.
+//
+// Flags:
+//   - --output, -o: "table" (default) or "json".
+func explainCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "explain <host> [variable]",
+		Short:             "List every definition of the variables of a host in precedence order",
+		Args:              cobra.RangeArgs(1, 2),
+		ValidArgsFunction: completeHosts,
+		RunE: func(_ *cobra.Command, args []string) error {
+			defs, err := collect(args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(args) > 1 {
+				defs = variables.Explain(defs, args[1])
+				if len(defs) == 0 {
+					return fmt.Errorf("'%s' is not defined for '%s' by any source that can be read", args[1], args[0])
+				}
+			} else {
+				sort.SliceStable(defs, func(i, j int) bool {
+					return defs[i].Name < defs[j].Name
+				})
+			}
+
+			if output == "json" {
+				data, err := json.MarshalIndent(defs, "", "  ")
+				if err != nil {
+					return err
+				}
+
+				fmt.Println(string(data))
+
+				return nil
+			}
+
+			table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+			table.Header("Variable", "Level", "Source", "Location", "Value", "Wins")
+
+			for _, d := range defs {
+				wins := ""
+				if d.Wins {
+					wins = "yes"
+				}
+
+				row := []string{d.Name, strconv.Itoa(d.Precedence), d.Source, d.Location(), d.Value, wins}
+
+				if err := table.Append(row); err != nil {
+					return err
+				}
+			}
+
+			return table.Render()
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if output != "table" && output != "json" {
+				return fmt.Errorf("unsupported output format '%s' (table, json)", output)
+			}
+
+			return ensure(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "output format: table or json")
+
+	return cmd
+}
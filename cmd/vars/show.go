@@ -0,0 +1,112 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vars
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/variables"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// showCmd creates the Cobra command for "ansible-dev vars show".
+//
+// Usage:
+//
+//	ansible-dev vars show <host> [flags]
+//
+// Shows the merged variables of the host, i.e. the value that takes effect
+// for each variable together with the source and location defining it,
+// sorted by variable name. Use "ansible-dev vars explain" to see the
+// definitions that were overridden.
+//
+// Flags:
+//   - --output, -o: "table" (default), "json", or "yaml". The json and
+//     yaml formats map each variable name to its value.
+func showCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "show <host>",
+		Short:             "Show the merged variables of a host",
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeHosts,
+		RunE: func(_ *cobra.Command, args []string) error {
+			defs, err := collect(args[0])
+			if err != nil {
+				return err
+			}
+
+			merged := variables.Merge(defs)
+
+			switch output {
+			case "json", "yaml":
+				values := make(map[string]string, len(merged))
+				for _, d := range merged {
+					values[d.Name] = d.Value
+				}
+
+				if output == "json" {
+					data, err := json.MarshalIndent(values, "", "  ")
+					if err != nil {
+						return err
+					}
+
+					fmt.Println(string(data))
+
+					return nil
+				}
+
+				encoder := yaml.NewEncoder(os.Stdout)
+				encoder.SetIndent(2)
+
+				if err := encoder.Encode(values); err != nil {
+					return err
+				}
+
+				return encoder.Close()
+			}
+
+			table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+			table.Header("Variable", "Value", "Source", "Location")
+
+			for _, d := range merged {
+				if err := table.Append([]string{d.Name, d.Value, d.Source, d.Location()}); err != nil {
+					return err
+				}
+			}
+
+			return table.Render()
+		},
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			switch output {
+			case "table", "json", "yaml":
+			default:
+				return fmt.Errorf("unsupported output format '%s' (table, json, yaml)", output)
+			}
+
+			return ensure(cmd, args)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "output format: table, json, or yaml")
+
+	return cmd
+}
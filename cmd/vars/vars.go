@@ -0,0 +1,114 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vars implements the "ansible-dev vars" command group, which
+// explains where the variables of a development host come from. Available
+// subcommands include explain and show.
+package vars
+
+import (
+	"errors"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/variables"
+	"github.com/spf13/cobra"
+)
+
+var (
+	extraVars []string
+	output    string
+	playbook  string
+	roles     []string
+)
+
+// NewCommand creates and returns the Cobra command for the "vars" command
+// group.
+//
+// When invoked without a subcommand it prints the help text. Every
+// subcommand collects the definitions of the variables of a host with
+// [variables.Collect] from the sources below, in Ansible's precedence
+// order:
+//   - role defaults of the roles given with --role (-r) or applied by the
+//     playbook, and of their dependencies;
+//   - group and host variables set in hosts.ini;
+//   - the group_vars/ and host_vars/ files created by
+//     "ansible-dev initialize" and "ansible-dev vm render", and those next
+//     to the playbook;
+//   - the vars and vars_files of the plays of the playbook given with
+//     --playbook (-p), e.g. .tmp/play.yml or playbooks/runbook.yml;
+//   - role vars;
+//   - extra variables given with --extra-vars (-e) as for ansible-playbook.
+//
+// The following subcommands are registered:
+//   - explain: list every definition of the variables of a host.
+//   - show:    show the merged variables of a host.
+//
+// A PreRunE hook (see [ensure]) verifies that the current directory is an
+// Ansible development directory.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vars",
+		Short: "Explain the variables of the hosts of the Ansible development environment",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+		PreRunE: ensure,
+	}
+
+	cmd.PersistentFlags().StringSliceVarP(&roles, "role", "r", []string{}, "role(s) whose defaults and vars apply")
+	cmd.PersistentFlags().StringVarP(&playbook, "playbook", "p", "", "playbook whose play vars and roles apply")
+	cmd.PersistentFlags().StringArrayVarP(&extraVars, "extra-vars", "e", []string{}, "extra variables as key=value, JSON, or @file")
+
+	cmd.AddCommand(explainCmd())
+	cmd.AddCommand(showCmd())
+
+	return cmd
+}
+
+// ensure verifies that the current directory is an Ansible development
+// directory.
+func ensure(_ *cobra.Command, _ []string) error {
+	if err := ansible.EnsureAnsibleDirectory(); err != nil {
+		return errors.New("not an Ansible development directory")
+	}
+
+	return nil
+}
+
+// collect returns the definitions of the variables of host for the
+// persistent flags.
+func collect(host string) ([]variables.Definition, error) {
+	return variables.Collect(host, variables.Options{
+		Roles:     roles,
+		Playbook:  playbook,
+		ExtraVars: extraVars,
+	})
+}
+
+// completeHosts completes the first argument with the inventory hosts.
+func completeHosts(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	inv, err := inventory.Load(inventory.Path())
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	return inv.HostNames(), cobra.ShellCompDirectiveNoFileComp
+}
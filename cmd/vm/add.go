@@ -0,0 +1,122 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/spf13/cobra"
+)
+
+var validName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// addCmd creates the Cobra command for "ansible-dev vm add", which adds a
+// machine to the "machines" list in the project configuration file
+// (.ansible-dev.yml) and regenerates the Vagrantfile, hosts.ini, and
+// host_vars files from the new list.
+//
+// Usage:
+//
+//	ansible-dev vm add <name> --box <box> [flags]
+//
+// If the project file does not define machines yet, the effective list
+// (by default the built-in Debian and AlmaLinux machines) is copied into it
+// before the new machine is appended.
+//
+// Flags:
+//   - --box, -b:    the Vagrant box to create the machine from (required).
+//   - --cpus:       CPU count overriding "vm.cpus" (default 0, inherit).
+//   - --memory:     memory in MB overriding "vm.memory" (default 0,
+//     inherit).
+//   - --group, -g:  additional inventory group(s) for the host. May be
+//     repeated or comma-separated.
+//   - --network:    extra network as "<type>[:<ip>]", for example
+//     "private_network:192.168.57.10", or as
+//     "forwarded_port:<guest>:<host>". The type is private_network,
+//     public_network or forwarded_port (see [config.ParseNetwork]). May
+//     be repeated.
+//
+// A PreRunE hook calls [ansible.EnsureAnsibleDirectory] to verify the
+// current directory is a valid Ansible project.
+//
+// An error is returned if the name is invalid or already defined, or a
+// network is invalid.
+func addCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a machine to the Ansible development vagrant environment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+
+			if !validName.MatchString(name) {
+				return fmt.Errorf("'%s' is not a valid machine name", name)
+			}
+
+			cfg := config.Current()
+
+			if config.FindMachine(cfg.Machines, name) >= 0 {
+				return fmt.Errorf("machine '%s' already exists", name)
+			}
+
+			machine := config.Machine{Name: name}
+
+			machine.Box, _ = cmd.Flags().GetString("box")
+			machine.CPUs, _ = cmd.Flags().GetInt("cpus")
+			machine.Memory, _ = cmd.Flags().GetInt("memory")
+			machine.Groups, _ = cmd.Flags().GetStringSlice("group")
+
+			networks, _ := cmd.Flags().GetStringArray("network")
+
+			for _, value := range networks {
+				n, err := config.ParseNetwork(value)
+				if err != nil {
+					return err
+				}
+
+				machine.Networks = append(machine.Networks, n)
+			}
+
+			machines := append(cfg.Machines, machine)
+
+			if err := config.Store(config.ProjectFile, "machines", machines); err != nil {
+				return err
+			}
+
+			fmt.Println(textformat.Info(fmt.Sprintf("machine '%s' added to %s", name, config.ProjectFile)))
+
+			return render(cfg.VM, machines)
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return ansible.EnsureAnsibleDirectory()
+		},
+	}
+
+	cmd.Flags().StringP("box", "b", "", "Vagrant box of the machine")
+	cmd.Flags().Int("cpus", 0, "CPU count (overrides vm.cpus)")
+	cmd.Flags().Int("memory", 0, "memory in MB (overrides vm.memory)")
+	cmd.Flags().StringSliceP("group", "g", []string{}, "additional inventory group(s) of the host")
+	cmd.Flags().StringArray("network", []string{}, "extra network as <type>[:<ip>] or forwarded_port:<guest>:<host>")
+
+	_ = cmd.MarkFlagRequired("box")
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/tw"
+	"github.com/spf13/cobra"
+)
+
+// listCmd creates the Cobra command for "ansible-dev vm list", which renders
+// a table of the configured machines with their box, effective CPU and
+// memory (the per-machine override or the "vm" default), inventory groups,
+// and extra networks. A PreRunE hook calls [ansible.EnsureAnsibleDirectory]
+// to verify the current directory is a valid Ansible project.
+func listCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Show the machines of the Ansible development vagrant environment",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			cfg := config.Current()
+			table := tablewriter.NewTable(os.Stdout, tablewriter.WithTrimSpace(tw.Off))
+			table.Header("Name", "Box", "CPU", "Memory", "Groups", "Networks")
+
+			for _, m := range cfg.Machines {
+				cpus := m.CPUs
+				if cpus == 0 {
+					cpus = cfg.VM.CPUs
+				}
+
+				memory := m.Memory
+				if memory == 0 {
+					memory = cfg.VM.Memory
+				}
+
+				var networks []string
+
+				for _, n := range m.Networks {
+					networks = append(networks, n.String())
+				}
+
+				row := []string{
+					m.Name,
+					m.Box,
+					strconv.Itoa(cpus),
+					strconv.Itoa(memory),
+					strings.Join(append([]string{"vagrant"}, m.Groups...), ","),
+					strings.Join(networks, ","),
+				}
+
+				if err := table.Append(row); err != nil {
+					return err
+				}
+			}
+
+			return table.Render()
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return ansible.EnsureAnsibleDirectory()
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/spf13/cobra"
+)
+
+// removeCmd creates the Cobra command for "ansible-dev vm remove", which
+// removes a machine from the "machines" list in the project configuration
+// file and regenerates the Vagrantfile and hosts.ini.
+//
+// Usage:
+//
+//	ansible-dev vm remove <name> [flags]
+//
+// The machine's VM is not destroyed; run "ansible-dev destroy" (or
+// "vagrant destroy <name>") first if it exists.
+//
+// Flags:
+//   - --purge: also delete host_vars/<name>.yml (default false).
+//
+// A PreRunE hook calls [ansible.EnsureAnsibleDirectory] to verify the
+// current directory is a valid Ansible project.
+//
+// An error is returned if the machine is not defined.
+func removeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "remove <name>",
+		Short: "Remove a machine from the Ansible development vagrant environment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			cfg := config.Current()
+
+			i := config.FindMachine(cfg.Machines, name)
+			if i < 0 {
+				return fmt.Errorf("machine '%s' not present", name)
+			}
+
+			machines := append(cfg.Machines[:i:i], cfg.Machines[i+1:]...)
+
+			if err := config.Store(config.ProjectFile, "machines", machines); err != nil {
+				return err
+			}
+
+			fmt.Println(textformat.Info(fmt.Sprintf("machine '%s' removed from %s", name, config.ProjectFile)))
+
+			if purge, _ := cmd.Flags().GetBool("purge"); purge {
+				if err := runner.Remove(filepath.Join("host_vars", name+".yml")); err != nil {
+					return err
+				}
+			}
+
+			return render(cfg.VM, machines)
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return ansible.EnsureAnsibleDirectory()
+		},
+	}
+
+	cmd.Flags().Bool("purge", false, "also delete the host_vars file of the machine")
+
+	return cmd
+}
@@ -0,0 +1,51 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/go-toolbox/textformat"
+	"github.com/spf13/cobra"
+)
+
+// renderCmd creates the Cobra command for "ansible-dev vm render", which
+// regenerates the Vagrantfile, hosts.ini, and any missing host_vars files
+// from the effective "machines" and "vm" configuration. Existing host_vars
+// files are left untouched and known host addresses are preserved in
+// hosts.ini. A PreRunE hook calls [ansible.EnsureAnsibleDirectory] to
+// verify the current directory is a valid Ansible project.
+func renderCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "render",
+		Short: "Regenerate the Vagrantfile, hosts.ini, and host_vars from the machine definitions",
+		RunE: func(_ *cobra.Command, _ []string) error {
+			fmt.Println(textformat.Yellow("Rendering development environment..."))
+
+			cfg := config.Current()
+
+			return render(cfg.VM, cfg.Machines)
+		},
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return ansible.EnsureAnsibleDirectory()
+		},
+	}
+
+	return cmd
+}
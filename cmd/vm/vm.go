@@ -0,0 +1,119 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package vm implements the "ansible-dev vm" command group, which manages
+// the declarative list of development machines kept in the "machines"
+// configuration value and regenerates the Vagrantfile, hosts.ini, and
+// host_vars files from it. Available subcommands include add, list, remove,
+// and render.
+package vm
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/spf13/cobra"
+)
+
+// NewCommand creates and returns the Cobra command for the "vm" command
+// group. The command is also aliased as "vms" for convenience.
+//
+// When invoked without a subcommand it prints the help text. Each
+// subcommand has a PreRunE hook calling [ansible.EnsureAnsibleDirectory]
+// to verify that the current working directory contains an ansible.cfg
+// file before it writes the project configuration or the Vagrantfile.
+//
+// The following subcommands are registered:
+//   - add:    add a machine to the project configuration and regenerate.
+//   - list:   list the configured machines.
+//   - remove: remove a machine from the project configuration and
+//     regenerate.
+//   - render: regenerate the Vagrantfile, hosts.ini, and host_vars files.
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vm",
+		Aliases: []string{"vms"},
+		Short:   "Manage the machines of the Ansible development vagrant environment",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(addCmd())
+	cmd.AddCommand(listCmd())
+	cmd.AddCommand(removeCmd())
+	cmd.AddCommand(renderCmd())
+
+	return cmd
+}
+
+// render regenerates the three artifacts that describe the machines so
+// that they cannot drift apart:
+//   - Vagrantfile, via [vagrant.RenderVagrantfile].
+//   - the inventory (see [inventory.Path]), via [ansible.SyncInventory],
+//     which edits an existing inventory in place so that connection
+//     variables, comments and hand-made groups are kept.
+//   - host_vars/<name>.yml for every machine that does not have one yet,
+//     via [ansible.EnsureHostVars].
+//
+// The networks of every machine are checked first (see
+// [config.Network.Validate]), since a hand-edited configuration file may
+// hold one Vagrant does not understand.
+func render(vm config.VM, machines []config.Machine) error {
+	for _, m := range machines {
+		for _, n := range m.Networks {
+			if err := n.Validate(); err != nil {
+				return fmt.Errorf("machine '%s': invalid network '%s': %w", m.Name, n, err)
+			}
+		}
+	}
+
+	fmt.Println("  ...  Vagrantfile")
+
+	if err := runner.WriteFile("Vagrantfile", vagrant.RenderVagrantfile(vm, machines)); err != nil {
+		return err
+	}
+
+	data, err := ansible.SyncInventory(machines)
+	if err != nil {
+		return err
+	}
+
+	path := inventory.Path()
+
+	fmt.Printf("  ...  %s\n", path)
+
+	if err := runner.WriteFile(path, data); err != nil {
+		return err
+	}
+
+	for _, m := range machines {
+		created, err := ansible.EnsureHostVars(m.Name)
+		if err != nil {
+			return err
+		}
+
+		if created {
+			fmt.Printf("  ...  host_vars/%s.yml\n", m.Name)
+		}
+	}
+
+	return nil
+}
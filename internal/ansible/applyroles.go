@@ -24,11 +24,13 @@ import (
 
 // ApplyRoles iterates over the supplied role names, generates a temporary
 // single-role playbook for each, and executes it via ansible-playbook. The
-// optional tags slice limits task execution and verbose enables increased
-// output. Execution stops on the first error encountered.
-func ApplyRoles(roles []string, tags []string, verbose bool) error {
+// optional tags slice limits task execution, the optional limit host pattern
+// restricts the hosts the roles are applied to, and verbose enables
+// increased output. Execution stops on the first error encountered.
+func ApplyRoles(roles []string, tags []string, limit string, verbose bool) error {
 	play := Play{
 		Tags:       tags,
+		Limit:      limit,
 		FlushCache: true,
 		Verbose:    verbose,
 	}
@@ -0,0 +1,193 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"bytes"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// testInventory is the hosts.ini of the development directories used by
+// the tests of this package.
+const testInventory = `[vagrant]
+debian
+alma
+
+[vagrant:vars]
+ansible_user=vagrant
+`
+
+// record changes to a new development directory holding an ansible.cfg and
+// testInventory and makes a new [runner.Recorder] the current runner until
+// the test ends.
+func record(t *testing.T) *runner.Recorder {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	for name, content := range map[string]string{
+		"ansible.cfg": "[defaults]\ninventory = hosts.ini\n",
+		"hosts.ini":   testInventory,
+	} {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous := runner.Current()
+	t.Cleanup(func() { runner.Set(previous) })
+
+	rec := runner.NewRecorder()
+	runner.Set(rec)
+
+	return rec
+}
+
+func TestApplyRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   []string
+		tags    []string
+		limit   string
+		verbose bool
+		outputs map[string]string
+		want    []string
+		hosts   []string
+	}{
+		{
+			name:  "single role",
+			roles: []string{"nginx"},
+			want: []string{
+				"ansible-playbook --flush-cache .tmp/play.yml",
+			},
+			hosts: []string{"debian", "alma"},
+		},
+		{
+			name:    "tags and verbose",
+			roles:   []string{"nginx"},
+			tags:    []string{"install", "config"},
+			verbose: true,
+			want: []string{
+				"ansible-playbook --tags install,config --flush-cache -v .tmp/play.yml",
+			},
+			hosts: []string{"debian", "alma"},
+		},
+		{
+			name:  "limit",
+			roles: []string{"nginx"},
+			limit: "all:!alma",
+			outputs: map[string]string{
+				"ansible -i hosts.ini all:!alma --list-hosts": "  hosts (1):\n    debian\n",
+			},
+			want: []string{
+				"ansible-playbook --limit all:!alma --flush-cache .tmp/play.yml",
+				"ansible -i hosts.ini all:!alma --list-hosts",
+			},
+			hosts: []string{"debian"},
+		},
+		{
+			name:  "several roles",
+			roles: []string{"nginx", "docker"},
+			want: []string{
+				"ansible-playbook --flush-cache .tmp/play.yml",
+				"ansible-playbook --flush-cache .tmp/play.yml",
+			},
+			hosts: []string{"debian", "alma"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+			for cmd, out := range tt.outputs {
+				rec.Outputs[cmd] = out
+			}
+
+			if err := ApplyRoles(tt.roles, tt.tags, tt.limit, tt.verbose); err != nil {
+				t.Fatalf("ApplyRoles() error = %v", err)
+			}
+
+			if got := rec.Lines(); !slices.Equal(got, tt.want) {
+				t.Errorf("commands = %q, want %q", got, tt.want)
+			}
+
+			// The recorder keeps writes in memory, so the play and the
+			// provisioning record hold what the last role wrote.
+			last := tt.roles[len(tt.roles)-1]
+
+			play := "---\n- name: Test Ansible Role\n  hosts: all\n  any_errors_fatal: true\n  become: true\n\n" +
+				"  roles:\n    - " + last + "\n"
+			if got := string(rec.Files[".tmp/play.yml"]); got != play {
+				t.Errorf(".tmp/play.yml = %q, want %q", got, play)
+			}
+
+			var p Provisioning
+			if err := yaml.Unmarshal(rec.Files[provisioningFile], &p); err != nil {
+				t.Fatalf("can't parse %s: %v", provisioningFile, err)
+			}
+
+			if len(p) != len(tt.hosts) {
+				t.Errorf("provisioned hosts = %d, want %d", len(p), len(tt.hosts))
+			}
+
+			for _, host := range tt.hosts {
+				roles := p[host]
+				if len(roles) != 1 || roles[0].Name != last || !slices.Equal(roles[0].Tags, tt.tags) || roles[0].Applied.IsZero() {
+					t.Errorf("provisioning of %s = %+v, want %s with tags %q", host, roles, last, tt.tags)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyRolesDryRun(t *testing.T) {
+	record(t)
+
+	var out bytes.Buffer
+	runner.Set(runner.NewDryRun(&out))
+
+	if err := ApplyRoles([]string{"nginx"}, []string{"install"}, "debian", false); err != nil {
+		t.Fatalf("ApplyRoles() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"[dry-run] write .tmp/play.yml",
+		"[dry-run]   | ---",
+		"[dry-run]   | - name: Test Ansible Role",
+		"[dry-run]   |   hosts: all",
+		"[dry-run]   |   any_errors_fatal: true",
+		"[dry-run]   |   become: true",
+		"[dry-run]   |",
+		"[dry-run]   |   roles:",
+		"[dry-run]   |     - nginx",
+		"[dry-run] ansible-playbook --tags install --limit debian --flush-cache .tmp/play.yml",
+		"",
+	}, "\n")
+
+	if got := out.String(); got != want {
+		t.Errorf("dry-run output = %q, want %q", got, want)
+	}
+
+	if _, err := os.Stat(".tmp"); !os.IsNotExist(err) {
+		t.Errorf("dry-run created .tmp: %v", err)
+	}
+}
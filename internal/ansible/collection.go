@@ -16,17 +16,136 @@ limitations under the License.
 
 package ansible
 
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// CollectionTypes lists the values of the "type" key of a collection
+// requirement: where the collection named by Name is installed from.
+var CollectionTypes = []string{"galaxy", "git", "url", "file", "dir", "subdirs"}
+
+// collectionName matches a fully qualified collection name: a namespace
+// and a name, each a Python identifier.
+var collectionName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*\.[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Collection describes a single Ansible Galaxy collection dependency
-// declared in the requirements.yml file.
+// declared in the requirements.yml file, in the format accepted by
+// "ansible-galaxy collection install -r".
 //
 // Fields:
-//   - Name:    the fully qualified collection name (e.g. "community.general").
-//   - Source:  an optional URL or Galaxy server where the collection is hosted.
-//   - Type:    the source type (e.g. "galaxy", "git", "url").
-//   - Version: an optional version constraint string (e.g. ">=2.0.0").
+//   - Name:       the fully qualified collection name (e.g.
+//     "community.general") or, for the types other than "galaxy", the
+//     repository URL, archive URL or path to install from.
+//   - Source:     an optional Galaxy server URL or server name (see
+//     galaxy_server_list in ansible.cfg) to download the collection from.
+//   - Type:       the source type, one of [CollectionTypes]; ansible-galaxy
+//     assumes "galaxy" when it is not set.
+//   - Version:    an optional version constraint string (e.g. ">=2.0.0"),
+//     or the branch, tag or commit of a git source.
+//   - Signatures: optional detached signature URLs or files used to verify
+//     the collection.
 type Collection struct {
-	Name    string `yaml:"name"`
-	Source  string `yaml:"src"`
-	Type    string `yaml:"type"`
-	Version string `yaml:"version"`
+	Name       string   `yaml:"name"`
+	Source     string   `yaml:"source,omitempty"`
+	Type       string   `yaml:"type,omitempty"`
+	Version    string   `yaml:"version,omitempty"`
+	Signatures []string `yaml:"signatures,omitempty"`
+}
+
+// Validate reports the first problem that would make ansible-galaxy
+// reject the entry: a missing name, a Galaxy collection whose name is not
+// "namespace.name", an unknown type, a server given for a collection that
+// does not come from Galaxy, or a malformed server URL.
+func (c Collection) Validate() error {
+	if len(c.Name) == 0 {
+		return errors.New("collection requirement needs a name")
+	}
+
+	if len(c.Type) > 0 && !slices.Contains(CollectionTypes, c.Type) {
+		return fmt.Errorf("collection '%s' has unsupported type '%s' (%s)", c.Name, c.Type, strings.Join(CollectionTypes, ", "))
+	}
+
+	galaxy := len(c.Type) == 0 || c.Type == "galaxy"
+
+	if galaxy && !collectionName.MatchString(c.Name) {
+		return fmt.Errorf("collection name '%s' is not 'namespace.name'", c.Name)
+	}
+
+	if len(c.Source) > 0 {
+		if !galaxy {
+			return fmt.Errorf("collection '%s' sets a Galaxy server but its type is '%s'", c.Name, c.Type)
+		}
+
+		if strings.Contains(c.Source, "://") {
+			if u, err := url.Parse(c.Source); err != nil || (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+				return fmt.Errorf("collection '%s' has an invalid Galaxy server URL '%s'", c.Name, c.Source)
+			}
+		} else if strings.ContainsAny(c.Source, " \t/") {
+			return fmt.Errorf("collection '%s' has an invalid Galaxy server name '%s'", c.Name, c.Source)
+		}
+	}
+
+	for _, s := range c.Signatures {
+		if len(strings.TrimSpace(s)) == 0 {
+			return fmt.Errorf("collection '%s' has an empty signature", c.Name)
+		}
+	}
+
+	return nil
+}
+
+// SourceType returns where the collection is installed from: its Type,
+// or the type ansible-galaxy infers when it is not set ("git" for a
+// "git+" URL, "galaxy" otherwise).
+func (c Collection) SourceType() string {
+	switch {
+	case len(c.Type) > 0:
+		return c.Type
+	case strings.HasPrefix(c.Name, "git+"):
+		return "git"
+	default:
+		return "galaxy"
+	}
+}
+
+// Installs reports whether the requirement installs the collection name,
+// whose MANIFEST.json declares repository. A Galaxy requirement matches on
+// its name, a git requirement on the repository URL (see
+// [SameRepository]), a directory requirement on the galaxy.yml it points
+// at, and an archive on its "<namespace>-<name>-" file name prefix.
+func (c Collection) Installs(name, repository string) bool {
+	switch c.SourceType() {
+	case "galaxy":
+		return c.Name == name
+	case "git":
+		return len(repository) > 0 && SameRepository(c.Name, repository)
+	case "dir":
+		info, err := ReadGalaxyInfo(c.Name)
+		return err == nil && info.Namespace+"."+info.Name == name
+	case "file", "url":
+		return strings.HasPrefix(path.Base(c.Name), strings.ReplaceAll(name, ".", "-")+"-")
+	default:
+		return false
+	}
+}
+
+// UnmarshalYAML decodes a collection entry of requirements.yml, which may
+// be a mapping or, as ansible-galaxy accepts, a bare collection name.
+func (c *Collection) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*c = Collection{Name: node.Value}
+		return nil
+	}
+
+	type plain Collection
+
+	return node.Decode((*plain)(c))
 }
@@ -22,7 +22,7 @@ import (
 	"runtime"
 	"strings"
 
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 	"github.com/dcjulian29/go-toolbox/textformat"
 )
@@ -141,5 +141,5 @@ func launchDiff(primaryDir, secondaryDir, filter string) error {
 		params = []string{secondaryDir, primaryDir}
 	}
 
-	return execute.ExternalProgram(program, params...)
+	return runner.Run(program, params...)
 }
@@ -0,0 +1,109 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+)
+
+// DynamicGroup is a group of the JSON inventory printed by a dynamic
+// inventory script.
+type DynamicGroup struct {
+	Hosts    []string          `json:"hosts,omitempty"`
+	Children []string          `json:"children,omitempty"`
+	Vars     map[string]string `json:"vars,omitempty"`
+}
+
+// DynamicInventory builds the inventory printed by "ansible-dev inventory
+// --list", in the JSON schema Ansible expects from an inventory script: one
+// entry per group, plus a "_meta" entry holding the variables of every host
+// so that Ansible does not call the script again with --host for each one.
+//
+// The inventory has the same structure as the hosts.ini rendered by
+// [HostsIni]: every machine is in the [inventory.Managed] ("vagrant")
+// group and in each of its own groups, and the "all" group carries the
+// connection variables of the configured backend (see [allVars]). The
+// variables of each host are taken from connections, which maps a host
+// name to the connection variables reported by the backend for a running
+// host; hosts that are not running get no variables.
+func DynamicInventory(machines []config.Machine, connections map[string][][2]string) map[string]any {
+	groups := map[string]*DynamicGroup{
+		inventory.Managed: {Hosts: []string{}},
+	}
+
+	hostvars := map[string]map[string]string{}
+
+	for _, m := range machines {
+		groups[inventory.Managed].Hosts = append(groups[inventory.Managed].Hosts, m.Name)
+
+		for _, g := range m.Groups {
+			if groups[g] == nil {
+				groups[g] = &DynamicGroup{}
+			}
+
+			if !slices.Contains(groups[g].Hosts, m.Name) {
+				groups[g].Hosts = append(groups[g].Hosts, m.Name)
+			}
+		}
+
+		hostvars[m.Name] = DynamicHost(connections[m.Name])
+	}
+
+	all := &DynamicGroup{Vars: map[string]string{}}
+
+	for i, v := range allVars() {
+		if len(v[1]) > 0 {
+			all.Vars[v[0]] = v[1]
+		}
+
+		if i == 0 {
+			all.Vars["ansible_ssh_common_args"] = sshCommonArgs
+		}
+	}
+
+	result := map[string]any{
+		"_meta": map[string]any{"hostvars": hostvars},
+	}
+
+	for name, g := range groups {
+		all.Children = append(all.Children, name)
+		result[name] = g
+	}
+
+	sort.Strings(all.Children)
+
+	result["all"] = all
+
+	return result
+}
+
+// DynamicHost returns the variables of a single host printed by
+// "ansible-dev inventory --host": the given connection variables as a map.
+// An empty map is returned for a host without any.
+func DynamicHost(vars [][2]string) map[string]string {
+	result := map[string]string{}
+
+	for _, v := range vars {
+		result[v[0]] = v[1]
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"path/filepath"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// EnsureGroupVars creates group_vars/<group>.yml with a single placeholder
+// variable if it does not already exist, like "ansible-dev initialize"
+// does for the vagrant group. Existing files are never modified. It
+// reports whether the file was created.
+func EnsureGroupVars(group string) (bool, error) {
+	file := filepath.Join("group_vars", group+".yml")
+
+	if runner.FileExist(file) {
+		return false, nil
+	}
+
+	return true, runner.WriteFile(file, []byte("---\nvarname: value"))
+}
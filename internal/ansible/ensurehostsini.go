@@ -17,32 +17,109 @@ limitations under the License.
 package ansible
 
 import (
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
-// EnsureHostsIni verifies that a hosts.ini file exists in the current
-// working directory. If the file is missing it is recreated from the
-// standard template with placeholder addresses (0.0.0.0) so that
-// ansible-dev start can proceed and overwrite the addresses once each
-// VM has booted and reported its IP via vagrant ssh-config.
+// EnsureHostsIni verifies that an inventory file (see [inventory.Path])
+// exists in the current working directory. If there is none, hosts.ini is
+// recreated from the configured machines with placeholder addresses
+// (0.0.0.0) so that ansible-dev start can proceed and overwrite the
+// addresses once each VM has booted and reported its IP via vagrant
+// ssh-config. In dynamic inventory mode (see [InventorySource]) Ansible
+// does not read the inventory file, so nothing is done.
 //
 // A non-nil error is returned only if the file is missing and cannot
 // be recreated.
 func EnsureHostsIni() error {
-	if filesystem.FileExist("hosts.ini") {
+	if config.Current().Inventory.Dynamic || runner.FileExist(inventory.Path()) {
 		return nil
 	}
 
-	content := []byte(`[vagrant]
-debian ansible_host=0.0.0.0
-alma   ansible_host=0.0.0.0
+	return runner.WriteFile(inventory.Filename, HostsIni(config.Current().Machines))
+}
+
+// HostsIni renders the standard hosts.ini inventory for machines: a
+// [vagrant] group with one line per machine (names padded so the
+// ansible_host columns line up) and the 0.0.0.0 placeholder address that
+// is replaced when the VM boots, one section per additional group listing
+// its member hosts, and an [all:vars] section with the SSH connection
+// parameters Vagrant boxes expect by default (see [allVars]). It is only
+// used to create a missing inventory; an existing one is brought in line
+// with the machines by [SyncInventory], which keeps everything else in it.
+func HostsIni(machines []config.Machine) []byte {
+	width := 0
+	groups := map[string][]string{}
+
+	for _, m := range machines {
+		width = max(width, len(m.Name))
+
+		for _, g := range m.Groups {
+			groups[g] = append(groups[g], m.Name)
+		}
+	}
+
+	var b strings.Builder
+
+	b.WriteString("[vagrant]\n")
+
+	for _, m := range machines {
+		fmt.Fprintln(&b, HostLine(Inventory{Name: fmt.Sprintf("%-*s", width, m.Name), Address: "0.0.0.0"}))
+	}
+
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		names = append(names, g)
+	}
+
+	sort.Strings(names)
+
+	for _, g := range names {
+		fmt.Fprintf(&b, "\n[%s]\n%s\n", g, strings.Join(groups[g], "\n"))
+	}
+
+	b.WriteString("\n[all:vars]\n")
 
-[all:vars]
-ansible_user=vagrant
-ansible_ssh_common_args='-o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -o CheckHostIP=no'
-ansible_port=22
-ansible_ssh_private_key_file=~/.ssh/insecure_private_key
-`)
+	for i, v := range allVars() {
+		if len(v[1]) > 0 {
+			fmt.Fprintf(&b, "%s=%s\n", v[0], v[1])
+		}
 
-	return filesystem.EnsureFileExist("hosts.ini", content)
+		if i == 0 {
+			fmt.Fprintf(&b, "ansible_ssh_common_args='%s'\n", sshCommonArgs)
+		}
+	}
+
+	return []byte(b.String())
+}
+
+// sshCommonArgs are the ssh options given to every host: development hosts
+// are recreated often, so their host keys are neither checked nor kept.
+const sshCommonArgs = "-o StrictHostKeyChecking=no -o UserKnownHostsFile=/dev/null -o CheckHostIP=no"
+
+// allVars returns the connection variables of the [all:vars] section for
+// the configured backend. Vagrant boxes are reached as the vagrant user
+// with Vagrant's insecure key; containers are reached as root through the
+// connection variables written by the backend, so the port and key are
+// empty, meaning unset.
+func allVars() [][2]string {
+	switch config.Current().Backend {
+	case "docker", "podman":
+		return [][2]string{
+			{"ansible_user", "root"},
+			{"ansible_port", ""},
+			{"ansible_ssh_private_key_file", ""},
+		}
+	default:
+		return [][2]string{
+			{"ansible_user", "vagrant"},
+			{"ansible_port", "22"},
+			{"ansible_ssh_private_key_file", "~/.ssh/insecure_private_key"},
+		}
+	}
 }
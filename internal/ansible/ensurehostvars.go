@@ -0,0 +1,37 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"path/filepath"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// EnsureHostVars creates host_vars/<host>.yml with a single placeholder
+// variable if it does not already exist. Existing files are never modified,
+// so variables a developer has added survive regeneration. It reports
+// whether the file was created.
+func EnsureHostVars(host string) (bool, error) {
+	file := filepath.Join("host_vars", host+".yml")
+
+	if runner.FileExist(file) {
+		return false, nil
+	}
+
+	return true, runner.WriteFile(file, []byte("---\nvarname: value"))
+}
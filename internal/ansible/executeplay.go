@@ -18,18 +18,19 @@ package ansible
 
 import (
 	"fmt"
-	"os"
 	"strings"
 
-	"github.com/dcjulian29/go-toolbox/execute"
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // ExecutePlay runs the ansible-playbook command using the temporary playbook
 // located at ".tmp/play.yml". Command-line flags are derived from the fields
 // of the supplied Play struct. If an "ansible.log" file exists it is removed
-// before execution. An error is returned if the log cannot be removed or if
-// ansible-playbook exits with a non-zero status.
+// before execution. After a successful run the role named by [Play.Name] is
+// recorded against every inventory host matched by [Play.Limit] (see
+// [RecordProvisioning] and [ListHosts]). An error is returned if the log
+// cannot be removed, if ansible-playbook exits with a non-zero status, or
+// if the hosts that ran the play cannot be listed or recorded.
 func ExecutePlay(play Play) error {
 	var param []string
 
@@ -38,6 +39,10 @@ func ExecutePlay(play Play) error {
 		param = append(param, strings.Join(play.Tags, ","))
 	}
 
+	if len(play.Limit) > 0 {
+		param = append(param, "--limit", play.Limit)
+	}
+
 	if play.FlushCache {
 		param = append(param, "--flush-cache")
 	}
@@ -56,18 +61,37 @@ func ExecutePlay(play Play) error {
 
 	param = append(param, ".tmp/play.yml")
 
-	if filesystem.FileExist("ansible.log") {
-		err := os.Remove("ansible.log")
+	if runner.FileExist("ansible.log") {
+		err := runner.Remove("ansible.log")
 		if err != nil {
 			return fmt.Errorf("can't remove ansible.log: %v", err)
 		}
 	}
 
-	if filesystem.FileExist(".tmp/play.yml") {
-		err := execute.ExternalProgram("ansible-playbook", param...)
+	if runner.FileExist(".tmp/play.yml") {
+		err := runner.Run("ansible-playbook", param...)
 		if err != nil {
 			return fmt.Errorf("can't execute playbook: %v", err)
 		}
+
+		if runner.IsDryRun() {
+			return nil
+		}
+
+		hosts, err := SelectHosts(nil)
+		if err != nil {
+			return fmt.Errorf("can't record provisioning: %v", err)
+		}
+
+		if len(play.Limit) > 0 {
+			if hosts, err = ListHosts(play.Limit); err != nil {
+				return fmt.Errorf("can't record provisioning: %v", err)
+			}
+		}
+
+		if err := RecordProvisioning(play.Name, play.Tags, hosts); err != nil {
+			return fmt.Errorf("can't record provisioning: %v", err)
+		}
 	}
 
 	return nil
@@ -17,10 +17,7 @@ limitations under the License.
 package ansible
 
 import (
-	"os"
-
-	"github.com/dcjulian29/go-toolbox/execute"
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // ExecuteRunbook runs the fixed runbook playbook located at
@@ -31,6 +28,7 @@ import (
 //
 // Command-line flags are derived from the supplied [Play] struct:
 //
+//   - [Play.Limit]:         passes --limit to restrict the run to matching hosts.
 //   - [Play.FlushCache]:    passes --flush-cache to clear the fact cache.
 //   - [Play.AskVaultPass]:  passes --ask-vault-password for encrypted vaults.
 //   - [Play.Verbose]:       passes -v for increased output verbosity.
@@ -45,6 +43,10 @@ import (
 func ExecuteRunbook(play Play) error {
 	var param []string
 
+	if len(play.Limit) > 0 {
+		param = append(param, "--limit", play.Limit)
+	}
+
 	if play.FlushCache {
 		param = append(param, "--flush-cache")
 	}
@@ -63,11 +65,11 @@ func ExecuteRunbook(play Play) error {
 
 	param = append(param, "playbooks/runbook.yml")
 
-	if filesystem.FileExist("ansible.log") {
-		if err := os.Remove("ansible.log"); err != nil {
+	if runner.FileExist("ansible.log") {
+		if err := runner.Remove("ansible.log"); err != nil {
 			return err
 		}
 	}
 
-	return execute.ExternalProgram("ansible-playbook", param...)
+	return runner.Run("ansible-playbook", param...)
 }
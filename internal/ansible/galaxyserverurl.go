@@ -0,0 +1,46 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"gopkg.in/ini.v1"
+)
+
+// GalaxyServerURL returns the URL of the Galaxy server named name in the
+// local ansible.cfg, read from the "url" key of its [galaxy_server.<name>]
+// section, as used by the "source" of a collection requirement.
+//
+// An error is returned if ansible.cfg cannot be loaded or does not define
+// the server.
+func GalaxyServerURL(name string) (string, error) {
+	cfg, err := ini.Load("ansible.cfg")
+	if err != nil {
+		return "", err
+	}
+
+	section, err := cfg.GetSection("galaxy_server." + name)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := section.GetKey("url")
+	if err != nil {
+		return "", err
+	}
+
+	return url.String(), nil
+}
@@ -17,39 +17,17 @@ limitations under the License.
 package ansible
 
 import (
-	"errors"
 	"fmt"
-	"os"
 
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // GenerateRolePlay creates a temporary Ansible playbook at ".tmp/play.yml"
 // that applies a single role identified by roleName. The generated YAML
-// targets the "all" host group. An error is returned if the ".tmp" directory
-// cannot be created or the file cannot be written.
+// targets the "all" host group. Any previous playbook is replaced. An error
+// is returned if the ".tmp" directory cannot be created or the file cannot
+// be written.
 func GenerateRolePlay(roleName string) error {
-	if !filesystem.DirectoryExist(".tmp") {
-		err := os.Mkdir(".tmp", 0755)
-		if err != nil {
-			return err
-		}
-	}
-
-	if filesystem.FileExist(".tmp/play.yml") {
-		err := os.Remove(".tmp/play.yml")
-		if err != nil {
-			return err
-		}
-	}
-
-	file, err := os.Create(".tmp/play.yml")
-	if err != nil {
-		return errors.New("can't create temporary play file")
-	}
-
-	defer file.Close() //nolint
-
 	content := `---
 - name: Test Ansible Role
   hosts: all
@@ -61,9 +39,5 @@ func GenerateRolePlay(roleName string) error {
 
 	content = fmt.Sprintf("%s%s", content, fmt.Sprintf("    - %s\n", roleName))
 
-	if _, err = file.WriteString(content); err != nil {
-		return err
-	}
-
-	return nil
+	return runner.WriteFile(".tmp/play.yml", []byte(content))
 }
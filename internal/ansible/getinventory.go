@@ -17,37 +17,49 @@ limitations under the License.
 package ansible
 
 import (
-	"errors"
-	"strings"
+	"fmt"
 
-	"gopkg.in/ini.v1"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
 )
 
-// GetInventory reads the "hosts.ini" file in the current directory, parses
-// the [vagrant] INI section, and returns a slice of Inventory entries—one
-// per host defined in that section. An error is returned if the file cannot
-// be loaded or the [vagrant] section is missing.
+// GetInventory reads the "hosts.ini" file in the current directory with
+// [inventory.Load] and returns one Inventory entry per host of the
+// [inventory.Managed] ("vagrant") group, including the hosts of its child
+// groups, with the ansible_host, ansible_port, ansible_user,
+// ansible_ssh_private_key_file and ansible_connection variables set on each
+// host. Hosts that are only in other groups are not development hosts and
+// are left out. An error is returned if the file cannot be loaded or the
+// group is missing.
+//
+// In dynamic inventory mode (see [InventorySource]) hosts.ini is not kept
+// up to date, so one entry per configured machine is returned instead, with
+// no connection variables; callers that need an address ask the backend.
 func GetInventory() ([]Inventory, error) {
-	inv, err := ini.Load("hosts.ini")
-	if err != nil {
-		return []Inventory{}, err
+	if cfg := config.Current(); cfg.Inventory.Dynamic {
+		hosts := make([]Inventory, 0, len(cfg.Machines))
+
+		for _, m := range cfg.Machines {
+			hosts = append(hosts, Inventory{Name: m.Name})
+		}
+
+		return hosts, nil
 	}
 
-	section, err := inv.GetSection("vagrant")
+	inv, err := inventory.Load(inventory.Path())
 	if err != nil {
-		return []Inventory{}, errors.New("can't find the 'vagrant' section in the hosts.ini file")
+		return []Inventory{}, err
 	}
 
-	inventory := []Inventory{}
+	if inv.Group(inventory.Managed) == nil {
+		return []Inventory{}, fmt.Errorf("can't find the '%s' group in the %s file", inventory.Managed, inv.Filename)
+	}
 
-	for _, vm := range section.KeyStrings() {
-		i := Inventory{
-			Name:    strings.Split(vm, " ")[0],
-			Address: section.Key(vm).String(),
-		}
+	hosts := []Inventory{}
 
-		inventory = append(inventory, i)
+	for _, name := range inv.GroupHosts(inventory.Managed) {
+		hosts = append(hosts, inventoryHost(inv.Host(name)))
 	}
 
-	return inventory, nil
+	return hosts, nil
 }
@@ -0,0 +1,165 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// HostConnection holds what another tool needs to reach an inventory host
+// over SSH, as resolved by Ansible.
+//
+// Fields:
+//   - Name:         the inventory host name.
+//   - Address:      ansible_host, or the host name when it is not set.
+//   - Port:         ansible_port, empty when not set.
+//   - User:         ansible_user, empty when not set.
+//   - IdentityFile: ansible_ssh_private_key_file, empty when not set.
+//   - Connection:   ansible_connection, empty when not set.
+//   - SSHOptions:   the "-o" options of ansible_ssh_common_args and
+//     ansible_ssh_extra_args as "Key=Value" strings, in order.
+type HostConnection struct {
+	Name         string   `json:"name"`
+	Address      string   `json:"address"`
+	Port         string   `json:"port,omitempty"`
+	User         string   `json:"user,omitempty"`
+	IdentityFile string   `json:"identity_file,omitempty"`
+	Connection   string   `json:"connection,omitempty"`
+	SSHOptions   []string `json:"ssh_options,omitempty"`
+}
+
+// Reachable reports whether the host can be reached with ssh: it is not
+// reached through a container connection plugin and has a real address,
+// not the 0.0.0.0 placeholder of a host that has not booted yet.
+func (h HostConnection) Reachable() bool {
+	if h.Address == "0.0.0.0" {
+		return false
+	}
+
+	return len(h.Connection) == 0 || h.Connection == "ssh" || h.Connection == "paramiko"
+}
+
+// HostConnections returns the connection details of every inventory host,
+// sorted by host name. The variables are resolved by running
+// "ansible-inventory --list" against the inventory (see [InventorySource]),
+// so that [all:vars], group variables and the group_vars/ and host_vars/
+// files are all taken into account, as are the live addresses of a
+// dynamic inventory. Variables Ansible cannot show without a vault
+// password are ignored. During a dry run no host is returned.
+//
+// An error is returned if ansible-inventory fails or its output cannot be
+// parsed.
+func HostConnections() ([]HostConnection, error) {
+	out, err := runner.Capture("ansible-inventory", "-i", InventorySource(), "--list")
+	if err != nil {
+		return nil, err
+	}
+
+	if runner.IsDryRun() {
+		return nil, nil
+	}
+
+	var inventory struct {
+		Meta struct {
+			HostVars map[string]map[string]any `json:"hostvars"`
+		} `json:"_meta"`
+	}
+
+	if err := json.Unmarshal([]byte(out), &inventory); err != nil {
+		return nil, fmt.Errorf("can't parse the ansible-inventory output: %w", err)
+	}
+
+	names := make([]string, 0, len(inventory.Meta.HostVars))
+	for name := range inventory.Meta.HostVars {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	hosts := make([]HostConnection, 0, len(names))
+
+	for _, name := range names {
+		hosts = append(hosts, newHostConnection(name, inventory.Meta.HostVars[name]))
+	}
+
+	return hosts, nil
+}
+
+// newHostConnection builds the connection details of the host name from
+// its variables as resolved by ansible-inventory.
+func newHostConnection(name string, vars map[string]any) HostConnection {
+	host := HostConnection{
+		Name:         name,
+		Address:      hostVar(vars, "ansible_host", "ansible_ssh_host"),
+		Port:         hostVar(vars, "ansible_port", "ansible_ssh_port"),
+		User:         hostVar(vars, "ansible_user", "ansible_ssh_user"),
+		IdentityFile: hostVar(vars, "ansible_ssh_private_key_file", "ansible_private_key_file"),
+		Connection:   hostVar(vars, "ansible_connection"),
+	}
+
+	if len(host.Address) == 0 {
+		host.Address = name
+	}
+
+	for _, key := range []string{"ansible_ssh_common_args", "ansible_ssh_extra_args"} {
+		host.SSHOptions = append(host.SSHOptions, sshOptions(hostVar(vars, key))...)
+	}
+
+	return host
+}
+
+// hostVar returns the first of keys set in vars as a string. Mappings,
+// such as the placeholder of a vault-encrypted value, are skipped.
+func hostVar(vars map[string]any, keys ...string) string {
+	for _, key := range keys {
+		switch v := vars[key].(type) {
+		case nil, map[string]any, []any:
+			continue
+		case float64:
+			return fmt.Sprintf("%g", v)
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+
+	return ""
+}
+
+// sshOptions extracts the "-o Key=Value" (or "-oKey=Value") options from
+// ssh arguments. Other arguments are ignored.
+func sshOptions(args string) []string {
+	var options []string
+
+	fields := strings.Fields(strings.NewReplacer(`'`, "", `"`, "").Replace(args))
+
+	for i := 0; i < len(fields); i++ {
+		switch {
+		case fields[i] == "-o" && i+1 < len(fields):
+			i++
+			options = append(options, fields[i])
+		case strings.HasPrefix(fields[i], "-o") && len(fields[i]) > 2:
+			options = append(options, fields[i][2:])
+		}
+	}
+
+	return options
+}
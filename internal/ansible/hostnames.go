@@ -0,0 +1,34 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+// HostNames returns the names of the hosts in the [vagrant] group of
+// hosts.ini, or nil when the inventory cannot be read. It is intended for
+// shell completion, where errors cannot be reported.
+func HostNames() []string {
+	inventory, err := GetInventory()
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(inventory))
+	for _, host := range inventory {
+		names = append(names, host.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// IncludedRoles returns the roles listed in the requirements file named by
+// the "include" entry of requirements.yml. The file holds a list of role
+// entries, as the "roles" section does; a relative path is taken relative
+// to requirements.yml. As with ansible-galaxy, the entries of an included
+// file are not searched for further includes.
+//
+// An error is returned if the file cannot be read or is not a list of
+// roles.
+func IncludedRoles(include string) ([]Role, error) {
+	path := include
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(RequirementsFilename), path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []Role{}
+
+	if err := yaml.Unmarshal(data, &roles); err != nil {
+		return nil, fmt.Errorf("%s: %w", include, err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// InstalledCollection is a collection installed in the collections path of
+// the project, as described by its MANIFEST.json.
+//
+// Fields:
+//   - Name:       the fully qualified collection name.
+//   - Version:    the installed version.
+//   - Repository: the repository URL declared by the collection, if any.
+//   - Dependencies: the collections it depends on, with their version
+//     constraints.
+//   - Path:       the directory it is installed in.
+type InstalledCollection struct {
+	Name         string
+	Version      string
+	Repository   string
+	Dependencies map[string]string
+	Path         string
+}
+
+// InstalledCollections returns the collections installed under
+// [CollectionsFolder], sorted by name. Directories without a MANIFEST.json
+// (for example a collection being developed in place) are skipped.
+//
+// An error is returned if ansible.cfg cannot be read or a MANIFEST.json
+// cannot be parsed.
+func InstalledCollections() ([]InstalledCollection, error) {
+	folder, err := CollectionsFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := filepath.Glob(filepath.Join(folder, "*", "*", "MANIFEST.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	collections := make([]InstalledCollection, 0, len(manifests))
+
+	for _, file := range manifests {
+		c, err := ReadCollectionManifest(filepath.Dir(file))
+		if err != nil {
+			return nil, err
+		}
+
+		collections = append(collections, c)
+	}
+
+	sort.Slice(collections, func(i, j int) bool { return collections[i].Name < collections[j].Name })
+
+	return collections, nil
+}
+
+// ReadCollectionManifest reads the MANIFEST.json of the collection
+// installed in dir.
+//
+// An error is returned if the file cannot be read or parsed.
+func ReadCollectionManifest(dir string) (InstalledCollection, error) {
+	var manifest struct {
+		CollectionInfo struct {
+			Namespace    string            `json:"namespace"`
+			Name         string            `json:"name"`
+			Version      string            `json:"version"`
+			Repository   string            `json:"repository"`
+			Dependencies map[string]string `json:"dependencies"`
+		} `json:"collection_info"`
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "MANIFEST.json"))
+	if err != nil {
+		return InstalledCollection{}, err
+	}
+
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return InstalledCollection{}, err
+	}
+
+	info := manifest.CollectionInfo
+
+	return InstalledCollection{
+		Name:         info.Namespace + "." + info.Name,
+		Version:      info.Version,
+		Repository:   info.Repository,
+		Dependencies: info.Dependencies,
+		Path:         dir,
+	}, nil
+}
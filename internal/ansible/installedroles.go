@@ -0,0 +1,108 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InstalledRole is a role installed by ansible-galaxy in the roles path of
+// the project, as described by its meta/.galaxy_install_info.
+//
+// Fields:
+//   - Name:        the role name, which is its directory name.
+//   - Version:     the installed version, or the branch, tag or commit
+//     of a repository source, as requested at install time.
+//   - InstallDate: when ansible-galaxy installed it.
+//   - Path:        the directory it is installed in.
+type InstalledRole struct {
+	Name        string
+	Version     string
+	InstallDate string
+	Path        string
+}
+
+// InstalledRoles returns the roles installed by ansible-galaxy under
+// [RootRoleFolder], sorted by name. Roles without a
+// meta/.galaxy_install_info file, such as roles developed in place, are
+// skipped.
+//
+// An error is returned if ansible.cfg cannot be read or an install info
+// file cannot be parsed.
+func InstalledRoles() ([]InstalledRole, error) {
+	folder, err := RootRoleFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(folder, "*", "meta", ".galaxy_install_info"))
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]InstalledRole, 0, len(files))
+
+	for _, file := range files {
+		r, err := ReadRoleInstallInfo(filepath.Dir(filepath.Dir(file)))
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, r)
+	}
+
+	sort.Slice(roles, func(i, j int) bool { return roles[i].Name < roles[j].Name })
+
+	return roles, nil
+}
+
+// ReadRoleInstallInfo reads the meta/.galaxy_install_info of the role
+// installed in dir.
+//
+// An error is returned if the file cannot be read or parsed.
+func ReadRoleInstallInfo(dir string) (InstalledRole, error) {
+	var info struct {
+		Version     any    `yaml:"version"`
+		InstallDate string `yaml:"install_date"`
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "meta", ".galaxy_install_info"))
+	if err != nil {
+		return InstalledRole{}, err
+	}
+
+	if err := yaml.Unmarshal(data, &info); err != nil {
+		return InstalledRole{}, err
+	}
+
+	role := InstalledRole{
+		Name:        filepath.Base(dir),
+		InstallDate: info.InstallDate,
+		Path:        dir,
+	}
+
+	if info.Version != nil {
+		role.Version = fmt.Sprint(info.Version)
+	}
+
+	return role, nil
+}
@@ -16,10 +16,83 @@ limitations under the License.
 
 package ansible
 
-// Inventory represents a single host entry from the Ansible inventory file.
-// Name is the short hostname (the first whitespace-delimited token on the
-// line) and Address is the value associated with that key in the INI section.
+import (
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+)
+
+// Inventory represents a single development host from the Ansible
+// inventory file. Name is the inventory host name; the remaining fields are
+// the connection variables set on the host in the inventory file, empty
+// when it does not set them.
+//
+// Fields:
+//   - Name:           the inventory host name.
+//   - Address:        the ansible_host variable.
+//   - Port:           the ansible_port variable.
+//   - User:           the ansible_user variable.
+//   - PrivateKeyFile: the ansible_ssh_private_key_file variable.
+//   - Connection:     the ansible_connection variable, set for hosts that
+//     are not reached over SSH (e.g. containers).
 type Inventory struct {
-	Name    string
-	Address string
+	Name           string
+	Address        string
+	Port           string
+	User           string
+	PrivateKeyFile string
+	Connection     string
+}
+
+// HostLine renders host as an INI inventory line: the host name followed by
+// each non-empty connection variable. Values containing whitespace are
+// double-quoted.
+func HostLine(host Inventory) string {
+	fields := []string{host.Name}
+
+	for _, v := range [][2]string{
+		{"ansible_host", host.Address},
+		{"ansible_port", host.Port},
+		{"ansible_user", host.User},
+		{"ansible_ssh_private_key_file", host.PrivateKeyFile},
+		{"ansible_connection", host.Connection},
+	} {
+		if len(v[1]) > 0 {
+			fields = append(fields, v[0]+"="+quoteValue(v[1]))
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
+
+// inventoryHost converts a host of the inventory file into an Inventory
+// entry. Variables other than the connection variables are ignored.
+func inventoryHost(h *inventory.Host) Inventory {
+	host := Inventory{Name: h.Name}
+
+	for _, v := range h.Vars {
+		switch v[0] {
+		case "ansible_host":
+			host.Address = v[1]
+		case "ansible_port":
+			host.Port = v[1]
+		case "ansible_user":
+			host.User = v[1]
+		case "ansible_ssh_private_key_file":
+			host.PrivateKeyFile = v[1]
+		case "ansible_connection":
+			host.Connection = v[1]
+		}
+	}
+
+	return host
+}
+
+// quoteValue double-quotes v when it contains whitespace.
+func quoteValue(v string) string {
+	if strings.ContainsAny(v, " \t") {
+		return `"` + v + `"`
+	}
+
+	return v
 }
@@ -0,0 +1,129 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// InventoryScript is the executable inventory script written to the
+// project when the "inventory.dynamic" configuration value is true. Ansible
+// runs it with --list to get the whole inventory; it changes to the project
+// directory, so the project configuration is found, and delegates to
+// "ansible-dev inventory".
+const InventoryScript = "inventory.sh"
+
+// inventoryScript is the content of [InventoryScript].
+const inventoryScript = `#!/bin/sh
+# Dynamic inventory of the development hosts, generated by ansible-dev.
+cd "$(dirname "$0")" || exit 1
+exec ansible-dev inventory "$@"
+`
+
+// inventoryLine matches the "inventory" setting of ansible.cfg.
+var inventoryLine = regexp.MustCompile(`^(\s*inventory\s*=\s*)(.*)$`)
+
+// InventorySource returns the inventory Ansible is given with -i:
+// [InventoryScript] when the "inventory.dynamic" configuration value is
+// true and the inventory file (see [inventory.Path]) otherwise.
+func InventorySource() string {
+	if config.Current().Inventory.Dynamic {
+		return InventoryScript
+	}
+
+	return inventory.Path()
+}
+
+// EnsureInventorySource points the "inventory" setting of ansible.cfg at
+// the inventory returned by [InventorySource]. In dynamic mode the
+// inventory script is written and made executable first. The setting is
+// replaced in place, keeping the rest of the file; when ansible.cfg does not
+// have one it is added to the [defaults] section. Nothing is written when
+// the setting is already correct.
+//
+// An error is returned if ansible.cfg cannot be read, has no [defaults]
+// section, or a file cannot be written.
+func EnsureInventorySource() error {
+	source := InventorySource()
+
+	if source == InventoryScript {
+		if err := runner.WriteFile(InventoryScript, []byte(inventoryScript)); err != nil {
+			return err
+		}
+
+		if err := runner.Run("chmod", "+x", InventoryScript); err != nil {
+			return err
+		}
+	}
+
+	data, err := os.ReadFile("ansible.cfg")
+	if os.IsNotExist(err) && runner.IsDryRun() {
+		return nil // ansible.cfg is only being written by this dry run
+	}
+
+	if err != nil {
+		return err
+	}
+
+	value := "./" + source
+	lines := strings.Split(string(data), "\n")
+	section := ""
+	defaults := -1
+
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]") {
+			section = trimmed[1 : len(trimmed)-1]
+
+			if section == "defaults" {
+				defaults = i
+			}
+
+			continue
+		}
+
+		if section != "defaults" {
+			continue
+		}
+
+		if m := inventoryLine.FindStringSubmatch(line); m != nil {
+			if strings.TrimSpace(m[2]) == value {
+				return nil
+			}
+
+			lines[i] = m[1] + value
+
+			return runner.WriteFile("ansible.cfg", []byte(strings.Join(lines, "\n")))
+		}
+	}
+
+	if defaults < 0 {
+		return errors.New("can't find the [defaults] section in ansible.cfg")
+	}
+
+	lines = append(lines[:defaults+1], append([]string{"inventory = " + value}, lines[defaults+1:]...)...)
+
+	return runner.WriteFile("ansible.cfg", []byte(strings.Join(lines, "\n")))
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// ListHosts resolves an Ansible host pattern (as accepted by --limit, e.g.
+// "alma", "debian:&webservers" or "all:!alma") against hosts.ini by running
+// "ansible -i hosts.ini <pattern> --list-hosts" and returns the matching
+// host names. An empty pattern is treated as "all". In dynamic inventory
+// mode the pattern is resolved against the inventory script instead (see
+// [InventorySource]).
+func ListHosts(pattern string) ([]string, error) {
+	if len(pattern) == 0 {
+		pattern = "all"
+	}
+
+	out, err := runner.Capture("ansible", "-i", InventorySource(), pattern, "--list-hosts")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseListHosts(out), nil
+}
+
+// parseListHosts extracts the host names from the output of
+// "ansible --list-hosts", which prints a "hosts (N):" header followed by
+// one indented host name per line.
+func parseListHosts(out string) []string {
+	var hosts []string
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "hosts (") || strings.HasPrefix(line, "[WARNING]") {
+			continue
+		}
+
+		hosts = append(hosts, line)
+	}
+
+	return hosts
+}
@@ -0,0 +1,30 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"encoding/json"
+)
+
+// ModuleArgs renders args as the -a value of an ad hoc module call. They
+// are passed as a JSON object rather than "key=value" pairs, so values
+// containing spaces, quotes or "=" reach the module unchanged.
+func ModuleArgs(args map[string]string) string {
+	data, _ := json.Marshal(args) // a map of strings always marshals
+
+	return string(data)
+}
@@ -20,7 +20,7 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // NewRole scaffolds a new Ansible role using "ansible-galaxy init". The role
@@ -45,7 +45,7 @@ func NewRole(role string, verbose bool) error {
 		param = append(param, "--verbose")
 	}
 
-	if err := execute.ExternalProgram("ansible-galaxy", param...); err != nil {
+	if err := runner.Run("ansible-galaxy", param...); err != nil {
 		return err
 	}
 
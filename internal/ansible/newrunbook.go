@@ -22,22 +22,24 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dcjulian29/ansible-dev/internal/config"
 	"github.com/dcjulian29/ansible-dev/internal/templates"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 )
 
 // NewRunbook renders the embedded runbook scaffolding into a new directory
-// named by the ANSIBLE_RUNBOOKS environment variable joined with name,
+// named by the runbook source directory ("paths.runbooks", which defaults to
+// the ANSIBLE_RUNBOOKS environment variable) joined with name,
 // substituting !!RUNBOOK_NAME!! and !!RUNBOOK_DESC!!. It returns the absolute
 // path of the created directory.
 //
 // Unlike a role, a runbook has no ansible-galaxy skeleton: the embedded
-// template is the entire scaffold. An error is returned if ANSIBLE_RUNBOOKS is
-// unset or the destination already exists.
+// template is the entire scaffold. An error is returned if the runbook source
+// directory is not configured or the destination already exists.
 func NewRunbook(name, description string) (string, error) {
-	runbooks := os.Getenv("ANSIBLE_RUNBOOKS")
+	runbooks := config.Current().Paths.Runbooks
 	if len(runbooks) == 0 {
-		return "", fmt.Errorf("the 'ANSIBLE_RUNBOOKS' environment variable is not defined")
+		return "", fmt.Errorf("the runbook source directory is not configured (set 'paths.runbooks' or ANSIBLE_RUNBOOKS)")
 	}
 
 	dest := filepath.Join(strings.ReplaceAll(runbooks, "\\", string(os.PathSeparator)), name)
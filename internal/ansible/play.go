@@ -21,6 +21,7 @@ package ansible
 // Fields:
 //   - Name:          human-readable identifier for the play (typically a role or playbook name).
 //   - Tags:          optional Ansible tags used to limit which tasks are executed.
+//   - Limit:         optional Ansible host pattern passed as --limit to restrict the play to matching hosts.
 //   - AskVaultPass:  when true, the --ask-vault-password flag is passed to ansible-playbook.
 //   - AskBecomePass: when true, the --ask-become-pass flag is passed to ansible-playbook.
 //   - FlushCache:    when true, the --flush-cache flag is passed to clear the fact cache.
@@ -29,6 +30,7 @@ package ansible
 type Play struct {
 	Name          string
 	Tags          []string
+	Limit         string
 	AskVaultPass  bool
 	AskBecomePass bool
 	FlushCache    bool
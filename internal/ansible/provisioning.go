@@ -0,0 +1,139 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// StateFolder is the per-project directory where ansible-dev keeps state
+// that is not part of the Ansible project itself.
+const StateFolder = ".ansible-dev"
+
+// provisioningFile records which roles were applied to each VM.
+var provisioningFile = filepath.Join(StateFolder, "provisioned.yml")
+
+// AppliedRole records a single successful role application.
+//
+// Fields:
+//   - Name:    the role that was applied.
+//   - Tags:    the tags the run was limited to, if any.
+//   - Applied: when the run finished.
+type AppliedRole struct {
+	Name    string    `yaml:"name" json:"name"`
+	Tags    []string  `yaml:"tags,omitempty" json:"tags,omitempty"`
+	Applied time.Time `yaml:"applied" json:"applied"`
+}
+
+// Provisioning maps each inventory host to the roles applied to it since
+// the VM was created, most recent last. A role applied again replaces its
+// earlier entry.
+type Provisioning map[string][]AppliedRole
+
+// ReadProvisioning loads the provisioning record. A missing record is not
+// an error and yields an empty [Provisioning].
+func ReadProvisioning() (Provisioning, error) {
+	p := Provisioning{}
+
+	data, err := os.ReadFile(provisioningFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return p, nil
+		}
+
+		return p, err
+	}
+
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return Provisioning{}, err
+	}
+
+	if p == nil {
+		p = Provisioning{}
+	}
+
+	return p, nil
+}
+
+// RecordProvisioning records that role (limited to tags) was applied to
+// every host in hosts and saves the record.
+func RecordProvisioning(role string, tags []string, hosts []string) error {
+	p, err := ReadProvisioning()
+	if err != nil {
+		return err
+	}
+
+	entry := AppliedRole{Name: role, Tags: tags, Applied: time.Now().UTC().Truncate(time.Second)}
+
+	for _, host := range hosts {
+		var kept []AppliedRole
+
+		for _, r := range p[host] {
+			if r.Name != role {
+				kept = append(kept, r)
+			}
+		}
+
+		p[host] = append(kept, entry)
+	}
+
+	return p.save()
+}
+
+// ClearProvisioning forgets the recorded role applications of the given
+// hosts, for example after their VMs have been destroyed. When no host is
+// given the whole record is removed.
+func ClearProvisioning(hosts ...string) error {
+	if len(hosts) == 0 {
+		return runner.Remove(provisioningFile)
+	}
+
+	p, err := ReadProvisioning()
+	if err != nil {
+		return err
+	}
+
+	for _, host := range hosts {
+		delete(p, host)
+	}
+
+	return p.save()
+}
+
+// save writes the provisioning record.
+func (p Provisioning) save() error {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(p); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return runner.WriteFile(provisioningFile, buf.Bytes())
+}
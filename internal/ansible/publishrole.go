@@ -22,7 +22,8 @@ import (
 	"path/filepath"
 	"strings"
 
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/filesystem"
 )
 
@@ -38,20 +39,37 @@ func BaseRoleName(role string) string {
 	return role
 }
 
+// RoleRepository returns the "<owner>/<prefix><name>" GitHub repository a
+// role is published to, using the "github.owner" and "github.role_prefix"
+// configuration values (for example "dcjulian29/ansible-role-nginx").
+func RoleRepository(role string) string {
+	gh := config.Current().GitHub
+
+	return gh.Owner + "/" + gh.RolePrefix + BaseRoleName(role)
+}
+
+// RoleRepositorySource returns the git URL of the repository a role is
+// published to, suitable for the "src" field of requirements.yml.
+func RoleRepositorySource(role string) string {
+	return fmt.Sprintf("https://github.com/%s.git", RoleRepository(role))
+}
+
 // PublishRole copies a freshly-scaffolded role from its workspace location
-// into the directory named by the ANSIBLE_ROLES environment variable (using
-// the role's bare name), initializes a git repository there, and then creates
-// and pushes a public GitHub repository named "ansible-role-<name>".
+// into the role source directory ("paths.roles", which defaults to the
+// ANSIBLE_ROLES environment variable) using the role's bare name, initializes
+// a git repository there, and then creates and pushes a public GitHub
+// repository named by [RoleRepository].
 //
 // It relies on the "git" and "gh" executables being installed and, in the case
-// of gh, already authenticated. An error is returned if ANSIBLE_ROLES is unset,
-// the destination already exists, or any external command fails.
+// of gh, already authenticated. An error is returned if the role source
+// directory is not configured, the destination already exists, or any
+// external command fails.
 func PublishRole(workspaceDir, role, description string) error {
 	base := BaseRoleName(role)
 
-	roles := os.Getenv("ANSIBLE_ROLES")
+	roles := config.Current().Paths.Roles
 	if len(roles) == 0 {
-		return fmt.Errorf("the 'ANSIBLE_ROLES' environment variable is not defined")
+		return fmt.Errorf("the role source directory is not configured (set 'paths.roles' or ANSIBLE_ROLES)")
 	}
 
 	dest := filepath.Join(strings.ReplaceAll(roles, "\\", string(os.PathSeparator)), base)
@@ -60,11 +78,11 @@ func PublishRole(workspaceDir, role, description string) error {
 		return fmt.Errorf("published role already exists at '%s'", dest)
 	}
 
-	if err := filesystem.EnsureDirectoryExist(filepath.Dir(dest)); err != nil {
+	if err := runner.MkdirAll(filepath.Dir(dest)); err != nil {
 		return err
 	}
 
-	if err := os.CopyFS(dest, os.DirFS(workspaceDir)); err != nil {
+	if err := runner.CopyDir(workspaceDir, dest); err != nil {
 		return err
 	}
 
@@ -76,13 +94,13 @@ func PublishRole(workspaceDir, role, description string) error {
 	}
 
 	for _, c := range commands {
-		if err := execute.ExternalProgram("git", c...); err != nil {
+		if err := runner.Run("git", c...); err != nil {
 			return err
 		}
 	}
 
-	return execute.ExternalProgram("gh", "repo", "create",
-		"dcjulian29/ansible-role-"+base,
+	return runner.Run("gh", "repo", "create",
+		RoleRepository(role),
 		"--source", dest,
 		"--remote", "origin",
 		"--push",
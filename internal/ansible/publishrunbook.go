@@ -17,12 +17,15 @@ limitations under the License.
 package ansible
 
 import (
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // PublishRunbook initializes a git repository in the already-rendered runbook
 // directory dir, then creates and pushes a public GitHub repository named
-// "ansible-runbook-<name>".
+// "<owner>/<prefix><name>" using the "github.owner" and
+// "github.runbook_prefix" configuration values (by default
+// "dcjulian29/ansible-runbook-<name>").
 //
 // It relies on the "git" and "gh" executables being installed and, in the case
 // of gh, already authenticated. An error is returned if any external command
@@ -36,13 +39,15 @@ func PublishRunbook(dir, name, description string) error {
 	}
 
 	for _, c := range commands {
-		if err := execute.ExternalProgram("git", c...); err != nil {
+		if err := runner.Run("git", c...); err != nil {
 			return err
 		}
 	}
 
-	return execute.ExternalProgram("gh", "repo", "create",
-		"dcjulian29/ansible-runbook-"+name,
+	gh := config.Current().GitHub
+
+	return runner.Run("gh", "repo", "create",
+		gh.Owner+"/"+gh.RunbookPrefix+name,
 		"--source", dir,
 		"--remote", "origin",
 		"--push",
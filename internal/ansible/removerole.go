@@ -20,7 +20,7 @@ import (
 	"fmt"
 	"path/filepath"
 
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/textformat"
 )
 
@@ -47,7 +47,7 @@ func RemoveRole(role string) error {
 		return fmt.Errorf("role '%s' files not present", role)
 	}
 
-	err = filesystem.RemoveDirectory(folder)
+	err = runner.Remove(folder)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,75 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"strings"
+)
+
+// SplitRepository splits the repository source of a role or collection
+// requirement into the URL git understands, without a "git+" prefix, and
+// the ref given by a ",ref" suffix, if any.
+func SplitRepository(source string) (string, string) {
+	url, ref, _ := strings.Cut(strings.TrimPrefix(source, "git+"), ",")
+
+	return url, ref
+}
+
+// SameRepository reports whether the repository sources a and b name the
+// same repository, ignoring the scheme, the user of an ssh URL, a ".git"
+// suffix, a ",ref" suffix and case, so that the https and ssh URLs of one
+// repository match.
+func SameRepository(a, b string) bool {
+	return repositoryKey(a) == repositoryKey(b)
+}
+
+// repositoryKey reduces a repository source to the form compared by
+// [SameRepository].
+func repositoryKey(source string) string {
+	url, _ := SplitRepository(source)
+
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		url = rest
+	}
+
+	if _, rest, ok := strings.Cut(url, "@"); ok {
+		url = strings.Replace(rest, ":", "/", 1)
+	}
+
+	url = strings.TrimSuffix(strings.TrimSuffix(url, "/"), ".git")
+
+	return strings.ToLower(url)
+}
+
+// PinnedVersion returns the single version a version constraint of
+// requirements.yml asks for, without a leading "==", and whether the
+// constraint pins one version rather than a range.
+func PinnedVersion(constraint string) (string, bool) {
+	if len(constraint) == 0 || constraint == "*" || strings.Contains(constraint, ",") {
+		return "", false
+	}
+
+	if strings.HasPrefix(constraint, "==") {
+		return strings.TrimPrefix(constraint, "=="), true
+	}
+
+	if strings.ContainsAny(constraint[:1], "<>!=~^") {
+		return "", false
+	}
+
+	return constraint, true
+}
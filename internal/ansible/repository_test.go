@@ -0,0 +1,49 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import "testing"
+
+func TestPinnedVersion(t *testing.T) {
+	tests := []struct {
+		constraint string
+		want       string
+		ok         bool
+	}{
+		{"", "", false},
+		{"*", "", false},
+		{"1.2.3", "1.2.3", true},
+		{"==1.2.3", "1.2.3", true},
+		{"v2.0.0", "v2.0.0", true},
+		{"main", "main", true},
+		{">=1.0.0", "", false},
+		{"<2.0.0", "", false},
+		{"!=1.0.0", "", false},
+		{">=1.0.0,<2.0.0", "", false},
+		{"~1.2", "", false},
+		{"^1.2", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, ok := PinnedVersion(tt.constraint)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("PinnedVersion(%q) = %q, %v, want %q, %v", tt.constraint, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
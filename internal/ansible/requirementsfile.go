@@ -0,0 +1,531 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// RequirementsFilename is the requirements file of the project.
+const RequirementsFilename = "requirements.yml"
+
+// RequirementsFile is requirements.yml loaded as a YAML node tree, so that
+// it can be edited without losing what the [Requirements] struct does not
+// model: comments, the order of the entries and their keys, and keys such
+// as "scm" or "include". Entries may be mappings or, as ansible-galaxy
+// accepts, bare strings (a role source or a collection name); a bare entry
+// is turned into a mapping only when it is changed.
+type RequirementsFile struct {
+	original []byte
+	loaded   []byte
+	root     *yaml.Node
+}
+
+// LoadRequirementsFile loads requirements.yml from the current working
+// directory. A missing file yields an empty document, which [Save]
+// creates.
+//
+// An error is returned if the file cannot be read or is not a YAML mapping.
+func LoadRequirementsFile() (*RequirementsFile, error) {
+	data, err := os.ReadFile(RequirementsFilename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	f := &RequirementsFile{original: data, root: &yaml.Node{}}
+
+	if err := yaml.Unmarshal(data, f.root); err != nil {
+		return nil, err
+	}
+
+	if len(f.root.Content) == 0 {
+		f.root = &yaml.Node{
+			Kind:    yaml.DocumentNode,
+			Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}},
+		}
+	}
+
+	if f.root.Content[0].Kind != yaml.MappingNode {
+		return nil, errors.New("requirements.yml is not a mapping of roles and collections")
+	}
+
+	if f.loaded, err = f.Bytes(); err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// HasRole reports whether name is a role of the file. Entries without a
+// name are matched on the role name ansible-galaxy derives from their
+// source (see [RequirementRoleName]).
+func (f *RequirementsFile) HasRole(name string) bool {
+	return f.find("roles", name) != nil
+}
+
+// HasCollection reports whether name is a collection of the file.
+func (f *RequirementsFile) HasCollection(name string) bool {
+	return f.find("collections", name) != nil
+}
+
+// SetRole adds role to the roles of the file or, when it is already
+// listed, updates its entry in place. Only the non-empty fields of role are
+// written, so that updating an entry keeps the fields that are not given;
+// every other key and comment of the entry is kept.
+//
+// An error is returned, and the file is left unchanged, if the resulting
+// entry is not valid (see [Role.Validate]).
+func (f *RequirementsFile) SetRole(role Role) error {
+	merged := role
+
+	if entry := f.find("roles", role.Name); entry != nil {
+		if err := entry.Decode(&merged); err != nil {
+			return err
+		}
+
+		merged.Source = firstNonEmpty(role.Source, merged.Source)
+		merged.Scm = firstNonEmpty(role.Scm, merged.Scm)
+		merged.Version = firstNonEmpty(role.Version, merged.Version)
+	}
+
+	if err := merged.Validate(); err != nil {
+		return err
+	}
+
+	f.set("roles", role.Name, []requirementField{
+		{"name", role.Name},
+		{"src", role.Source},
+		{"scm", role.Scm},
+		{"version", role.Version},
+	})
+
+	return nil
+}
+
+// SetCollection adds collection to the collections of the file or, when
+// it is already listed, updates its entry in place; see [SetRole]. Given
+// signatures replace the current ones.
+//
+// An error is returned, and the file is left unchanged, if the resulting
+// entry is not valid (see [Collection.Validate]).
+func (f *RequirementsFile) SetCollection(collection Collection) error {
+	merged := collection
+
+	if entry := f.find("collections", collection.Name); entry != nil {
+		if err := entry.Decode(&merged); err != nil {
+			return err
+		}
+
+		merged.Source = firstNonEmpty(collection.Source, merged.Source)
+		merged.Type = firstNonEmpty(collection.Type, merged.Type)
+		merged.Version = firstNonEmpty(collection.Version, merged.Version)
+
+		if len(collection.Signatures) > 0 {
+			merged.Signatures = collection.Signatures
+		}
+	}
+
+	if err := merged.Validate(); err != nil {
+		return err
+	}
+
+	f.set("collections", collection.Name, []requirementField{
+		{"name", collection.Name},
+		{"type", collection.Type},
+		{"source", collection.Source},
+		{"version", collection.Version},
+		{"signatures", collection.Signatures},
+	})
+
+	return nil
+}
+
+// RemoveRole removes the entry of the role name, together with its
+// comments, and reports whether it was found.
+func (f *RequirementsFile) RemoveRole(name string) bool {
+	return f.remove("roles", name)
+}
+
+// RemoveCollection removes the entry of the collection name, together with
+// its comments, and reports whether it was found.
+func (f *RequirementsFile) RemoveCollection(name string) bool {
+	return f.remove("collections", name)
+}
+
+// Bytes renders the file with the two-space indentation used for every
+// YAML file ansible-dev writes. A leading "---" document marker and the
+// blank lines separating the entries are kept.
+func (f *RequirementsFile) Bytes() ([]byte, error) {
+	var buf bytes.Buffer
+
+	if bytes.HasPrefix(f.original, []byte("---")) || len(f.original) == 0 {
+		buf.WriteString("---\n")
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(f.root); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return restoreBlankLines(f.original, buf.Bytes()), nil
+}
+
+// Changed reports whether the edits changed the file. The file is compared
+// as rendered by [Bytes] when it was loaded, so that an edit that changes
+// nothing is not reported because of a difference in layout alone.
+func (f *RequirementsFile) Changed() bool {
+	data, err := f.Bytes()
+
+	return err != nil || !bytes.Equal(data, f.loaded)
+}
+
+// Save writes the file when it changed. During a dry run the change is
+// shown as a unified diff instead (see [runner.DryRun.WriteFile]).
+func (f *RequirementsFile) Save() error {
+	if !f.Changed() {
+		return nil
+	}
+
+	data, err := f.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return runner.WriteFile(RequirementsFilename, data)
+}
+
+// restoreBlankLines puts back the blank lines of original, which the YAML
+// encoder drops, into rendered: each run of blank lines is inserted before
+// the line that followed it in original or, when that line was changed (for
+// example a bare entry turned into a mapping), after the line that preceded
+// it. A run is dropped when it would follow a line that original never
+// separated from its successor, such as the "roles:" key whose first entry
+// was removed.
+func restoreBlankLines(original, rendered []byte) []byte {
+	type anchor struct {
+		prev  string
+		line  string
+		count int
+	}
+
+	var anchors []anchor
+
+	// tight and loose hold the lines of original followed by a non-blank
+	// and by a blank line respectively.
+	tight := map[string]bool{}
+	loose := map[string]bool{}
+
+	blank := 0
+	prev := ""
+
+	for _, line := range strings.Split(string(original), "\n") {
+		if len(strings.TrimSpace(line)) == 0 {
+			blank++
+			continue
+		}
+
+		if blank > 0 {
+			anchors = append(anchors, anchor{prev, line, blank})
+			loose[prev] = true
+			blank = 0
+		} else if len(prev) > 0 {
+			tight[prev] = true
+		}
+
+		prev = line
+	}
+
+	lines := strings.Split(string(rendered), "\n")
+	result := make([]string, 0, len(lines))
+	next := 0
+
+	for _, a := range anchors {
+		at := slices.Index(lines[next:], a.line)
+		if at < 0 {
+			if at = slices.Index(lines[next:], a.prev); at < 0 {
+				continue
+			}
+
+			at++
+		}
+
+		result = append(result, lines[next:next+at]...)
+		next += at
+
+		if before := lines[max(next-1, 0)]; next == 0 || (before != a.prev && tight[before] && !loose[before]) {
+			continue
+		}
+
+		for range a.count {
+			result = append(result, "")
+		}
+	}
+
+	return []byte(strings.Join(append(result, lines[next:]...), "\n"))
+}
+
+// RequirementRoleName returns the name ansible-galaxy gives a role
+// installed from src: a Galaxy name is used as is, while for a repository
+// URL or archive the last path element is used without its ".git" or
+// ".tar.gz" extension. A "src,version" or "src,version,name" shorthand is
+// honored.
+func RequirementRoleName(src string) string {
+	parts := strings.Split(src, ",")
+	if len(parts) > 2 {
+		return strings.TrimSpace(parts[2])
+	}
+
+	src = strings.TrimSpace(parts[0])
+	if !strings.Contains(src, "/") {
+		return src
+	}
+
+	name := path.Base(strings.TrimSuffix(src, "/"))
+
+	for _, ext := range []string{".git", ".tar.gz", ".tgz", ".tar"} {
+		name = strings.TrimSuffix(name, ext)
+	}
+
+	return name
+}
+
+// section returns the sequence node of key ("roles" or "collections"),
+// creating it when create is set.
+func (f *RequirementsFile) section(key string, create bool) *yaml.Node {
+	top := f.root.Content[0]
+
+	for i := 0; i+1 < len(top.Content); i += 2 {
+		if top.Content[i].Value != key {
+			continue
+		}
+
+		value := top.Content[i+1]
+
+		if value.Kind == yaml.ScalarNode && len(value.Value) == 0 && create {
+			*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+
+		if value.Kind != yaml.SequenceNode {
+			return nil
+		}
+
+		return value
+	}
+
+	if !create {
+		return nil
+	}
+
+	value := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	top.Content = append(top.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+
+	return value
+}
+
+// find returns the entry named name in the key section, or nil.
+func (f *RequirementsFile) find(key, name string) *yaml.Node {
+	section := f.section(key, false)
+	if section == nil {
+		return nil
+	}
+
+	for _, entry := range section.Content {
+		if entryName(key, entry) == name {
+			return entry
+		}
+	}
+
+	return nil
+}
+
+// entryName returns the name of an entry of the key section: its "name"
+// key, or the name derived from its source for a role.
+func entryName(key string, entry *yaml.Node) string {
+	switch entry.Kind {
+	case yaml.ScalarNode:
+		if key == "roles" {
+			return RequirementRoleName(entry.Value)
+		}
+
+		return entry.Value
+	case yaml.MappingNode:
+		var name, src string
+
+		for i := 0; i+1 < len(entry.Content); i += 2 {
+			switch entry.Content[i].Value {
+			case "name":
+				name = entry.Content[i+1].Value
+			case "src":
+				src = entry.Content[i+1].Value
+			}
+		}
+
+		if len(name) == 0 && key == "roles" {
+			return RequirementRoleName(src)
+		}
+
+		return name
+	}
+
+	return ""
+}
+
+// requirementField is a key of a requirement entry and its value, a string
+// or a list of strings.
+type requirementField struct {
+	key   string
+	value any
+}
+
+// set updates the entry named name in the key section with the non-empty
+// fields, or appends a new entry with them. Values are written as strings,
+// so that a version such as "1.10" is quoted rather than read back as a
+// number.
+func (f *RequirementsFile) set(key, name string, fields []requirementField) {
+	entry := f.find(key, name)
+
+	if entry == nil {
+		entry = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		section := f.section(key, true)
+		section.Content = append(section.Content, entry)
+	}
+
+	if entry.Kind == yaml.ScalarNode {
+		// A bare role entry is its source, a bare collection its name.
+		first := "name"
+		if key == "roles" {
+			first = "src"
+		}
+
+		*entry = yaml.Node{
+			Kind:        yaml.MappingNode,
+			Tag:         "!!map",
+			HeadComment: entry.HeadComment,
+			LineComment: entry.LineComment,
+			FootComment: entry.FootComment,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: first},
+				{Kind: yaml.ScalarNode, Value: entry.Value},
+			},
+		}
+	}
+
+	for _, rf := range fields {
+		field, value := rf.key, rf.value
+
+		var list []string
+
+		switch v := value.(type) {
+		case string:
+			if len(v) == 0 {
+				continue
+			}
+
+			if current := mappingValue(entry, field); current != nil && current.Kind == yaml.ScalarNode {
+				if current.Value != v {
+					current.Tag = "!!str"
+					current.Value = v
+				}
+
+				continue
+			}
+
+			if field == "name" && key == "roles" && entryName(key, entry) == name {
+				// A role identified by its source keeps doing so.
+				continue
+			}
+
+			list = []string{v}
+		case []string:
+			if len(v) == 0 {
+				continue
+			}
+
+			list = v
+		}
+
+		node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: list[0]}
+
+		if _, ok := value.([]string); ok {
+			node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+
+			for _, item := range list {
+				node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: item})
+			}
+		}
+
+		if current := mappingValue(entry, field); current != nil {
+			*current = *node
+			continue
+		}
+
+		entry.Content = append(entry.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: field}, node)
+	}
+}
+
+// firstNonEmpty returns the first of values that is not empty.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if len(v) > 0 {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// remove deletes the entry named name from the key section.
+func (f *RequirementsFile) remove(key, name string) bool {
+	section := f.section(key, false)
+	if section == nil {
+		return false
+	}
+
+	for i, entry := range section.Content {
+		if entryName(key, entry) == name {
+			section.Content = slices.Delete(section.Content, i, i+1)
+			return true
+		}
+	}
+
+	return false
+}
+
+// mappingValue returns the value of key in the mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
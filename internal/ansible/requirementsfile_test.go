@@ -0,0 +1,470 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// testRequirements is the requirements.yml the tests of this package edit.
+const testRequirements = `---
+roles:
+  - name: geerlingguy.docker
+    version: 7.4.1
+`
+
+func TestRequirementsFileSave(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(f *RequirementsFile) error
+		want map[string][]byte
+	}{
+		{
+			name: "unchanged",
+			edit: func(f *RequirementsFile) error {
+				return f.SetRole(Role{Name: "geerlingguy.docker", Version: "7.4.1"})
+			},
+			want: map[string][]byte{},
+		},
+		{
+			name: "changed",
+			edit: func(f *RequirementsFile) error {
+				return f.SetCollection(Collection{Name: "community.general", Version: "9.0.0"})
+			},
+			want: map[string][]byte{
+				RequirementsFilename: []byte(testRequirements +
+					"collections:\n  - name: community.general\n    version: 9.0.0\n"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+
+			if err := os.WriteFile(RequirementsFilename, []byte(testRequirements), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := LoadRequirementsFile()
+			if err != nil {
+				t.Fatalf("LoadRequirementsFile() error = %v", err)
+			}
+
+			if err := tt.edit(f); err != nil {
+				t.Fatalf("edit error = %v", err)
+			}
+
+			if err := f.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			if len(rec.Files) != len(tt.want) {
+				t.Errorf("written files = %d, want %d", len(rec.Files), len(tt.want))
+			}
+
+			for name, content := range tt.want {
+				if got := rec.Files[name]; !bytes.Equal(got, content) {
+					t.Errorf("%s = %q, want %q", name, got, content)
+				}
+			}
+
+			if lines := rec.Lines(); len(lines) > 0 {
+				t.Errorf("commands = %q, want none", lines)
+			}
+		})
+	}
+}
+
+func TestRequirementsFileSaveDryRun(t *testing.T) {
+	record(t)
+
+	if err := os.WriteFile(RequirementsFilename, []byte(testRequirements), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	runner.Set(runner.NewDryRun(&out))
+
+	f, err := LoadRequirementsFile()
+	if err != nil {
+		t.Fatalf("LoadRequirementsFile() error = %v", err)
+	}
+
+	if err := f.SetRole(Role{Name: "geerlingguy.docker", Version: "7.5.0"}); err != nil {
+		t.Fatalf("SetRole() error = %v", err)
+	}
+
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	want := []string{
+		"[dry-run] write requirements.yml",
+		"[dry-run]   | --- a/requirements.yml",
+		"[dry-run]   | +++ b/requirements.yml",
+		"[dry-run]   | @@ -1,4 +1,4 @@",
+		"[dry-run]   |  ---",
+		"[dry-run]   |  roles:",
+		"[dry-run]   |    - name: geerlingguy.docker",
+		"[dry-run]   | -    version: 7.4.1",
+		"[dry-run]   | +    version: 7.5.0",
+	}
+
+	if got := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n"); !slices.Equal(got, want) {
+		t.Errorf("dry-run output = %q, want %q", got, want)
+	}
+
+	data, err := os.ReadFile(RequirementsFilename)
+	if err != nil || string(data) != testRequirements {
+		t.Errorf("dry-run changed requirements.yml: %q, %v", data, err)
+	}
+}
+
+// commentedRequirements is a requirements.yml with comments, blank lines,
+// bare entries and keys that [Requirements] does not model.
+const commentedRequirements = `---
+# Roles used by the development VMs.
+roles:
+  # Docker engine, pinned for the CI images.
+  - name: geerlingguy.docker
+    version: 7.4.1 # last release tested
+
+  - geerlingguy.pip
+
+  # Internal hardening role.
+  - src: https://git.example.com/ops/hardening.git
+    scm: git
+    version: main
+
+collections:
+  - community.general
+  # Needed by the docker role.
+  - name: community.docker
+    version: ">=3.0.0"
+`
+
+func TestRequirementsFileRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(f *RequirementsFile) error
+		want string
+		diff []string
+	}{
+		{
+			name: "unchanged",
+			edit: func(f *RequirementsFile) error {
+				return f.SetRole(Role{Name: "geerlingguy.docker", Version: "7.4.1"})
+			},
+			want: commentedRequirements,
+		},
+		{
+			name: "add",
+			edit: func(f *RequirementsFile) error {
+				if err := f.SetRole(Role{Name: "geerlingguy.nginx", Version: "3.2.0"}); err != nil {
+					return err
+				}
+
+				return f.SetCollection(Collection{Name: "ansible.posix", Version: "1.10"})
+			},
+			want: `---
+# Roles used by the development VMs.
+roles:
+  # Docker engine, pinned for the CI images.
+  - name: geerlingguy.docker
+    version: 7.4.1 # last release tested
+
+  - geerlingguy.pip
+
+  # Internal hardening role.
+  - src: https://git.example.com/ops/hardening.git
+    scm: git
+    version: main
+  - name: geerlingguy.nginx
+    version: 3.2.0
+
+collections:
+  - community.general
+  # Needed by the docker role.
+  - name: community.docker
+    version: ">=3.0.0"
+  - name: ansible.posix
+    version: "1.10"
+`,
+			diff: []string{
+				"@@ -11,9 +11,13 @@",
+				"   - src: https://git.example.com/ops/hardening.git",
+				"     scm: git",
+				"     version: main",
+				"+  - name: geerlingguy.nginx",
+				"+    version: 3.2.0",
+				" ",
+				" collections:",
+				"   - community.general",
+				"   # Needed by the docker role.",
+				"   - name: community.docker",
+				`     version: ">=3.0.0"`,
+				"+  - name: ansible.posix",
+				`+    version: "1.10"`,
+			},
+		},
+		{
+			name: "update",
+			edit: func(f *RequirementsFile) error {
+				if err := f.SetRole(Role{Name: "geerlingguy.docker", Version: "7.5.0"}); err != nil {
+					return err
+				}
+
+				return f.SetCollection(Collection{Name: "community.docker", Version: "4.0.0"})
+			},
+			want: `---
+# Roles used by the development VMs.
+roles:
+  # Docker engine, pinned for the CI images.
+  - name: geerlingguy.docker
+    version: 7.5.0 # last release tested
+
+  - geerlingguy.pip
+
+  # Internal hardening role.
+  - src: https://git.example.com/ops/hardening.git
+    scm: git
+    version: main
+
+collections:
+  - community.general
+  # Needed by the docker role.
+  - name: community.docker
+    version: "4.0.0"
+`,
+			diff: []string{
+				"@@ -3,7 +3,7 @@",
+				" roles:",
+				"   # Docker engine, pinned for the CI images.",
+				"   - name: geerlingguy.docker",
+				"-    version: 7.4.1 # last release tested",
+				"+    version: 7.5.0 # last release tested",
+				" ",
+				"   - geerlingguy.pip",
+				" ",
+				"@@ -16,4 +16,4 @@",
+				"   - community.general",
+				"   # Needed by the docker role.",
+				"   - name: community.docker",
+				`-    version: ">=3.0.0"`,
+				`+    version: "4.0.0"`,
+			},
+		},
+		{
+			name: "update bare entries",
+			edit: func(f *RequirementsFile) error {
+				if err := f.SetRole(Role{Name: "geerlingguy.pip", Version: "3.0.0"}); err != nil {
+					return err
+				}
+
+				return f.SetCollection(Collection{Name: "community.general", Version: "9.0.0"})
+			},
+			want: `---
+# Roles used by the development VMs.
+roles:
+  # Docker engine, pinned for the CI images.
+  - name: geerlingguy.docker
+    version: 7.4.1 # last release tested
+
+  - src: geerlingguy.pip
+    version: 3.0.0
+
+  # Internal hardening role.
+  - src: https://git.example.com/ops/hardening.git
+    scm: git
+    version: main
+
+collections:
+  - name: community.general
+    version: 9.0.0
+  # Needed by the docker role.
+  - name: community.docker
+    version: ">=3.0.0"
+`,
+			diff: []string{
+				"@@ -5,7 +5,8 @@",
+				"   - name: geerlingguy.docker",
+				"     version: 7.4.1 # last release tested",
+				" ",
+				"-  - geerlingguy.pip",
+				"+  - src: geerlingguy.pip",
+				"+    version: 3.0.0",
+				" ",
+				"   # Internal hardening role.",
+				"   - src: https://git.example.com/ops/hardening.git",
+				"@@ -13,7 +14,8 @@",
+				"     version: main",
+				" ",
+				" collections:",
+				"-  - community.general",
+				"+  - name: community.general",
+				"+    version: 9.0.0",
+				"   # Needed by the docker role.",
+				"   - name: community.docker",
+				`     version: ">=3.0.0"`,
+			},
+		},
+		{
+			name: "remove",
+			edit: func(f *RequirementsFile) error {
+				if !f.RemoveRole("geerlingguy.docker") || !f.RemoveCollection("community.docker") {
+					return errors.New("entry not found")
+				}
+
+				return nil
+			},
+			want: `---
+# Roles used by the development VMs.
+roles:
+  - geerlingguy.pip
+
+  # Internal hardening role.
+  - src: https://git.example.com/ops/hardening.git
+    scm: git
+    version: main
+
+collections:
+  - community.general
+`,
+			diff: []string{
+				"@@ -1,10 +1,6 @@",
+				" ---",
+				" # Roles used by the development VMs.",
+				" roles:",
+				"-  # Docker engine, pinned for the CI images.",
+				"-  - name: geerlingguy.docker",
+				"-    version: 7.4.1 # last release tested",
+				"-",
+				"   - geerlingguy.pip",
+				" ",
+				"   # Internal hardening role.",
+				"@@ -14,6 +10,3 @@",
+				" ",
+				" collections:",
+				"   - community.general",
+				"-  # Needed by the docker role.",
+				"-  - name: community.docker",
+				`-    version: ">=3.0.0"`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+
+			if err := os.WriteFile(RequirementsFilename, []byte(commentedRequirements), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			f, err := LoadRequirementsFile()
+			if err != nil {
+				t.Fatalf("LoadRequirementsFile() error = %v", err)
+			}
+
+			if err := tt.edit(f); err != nil {
+				t.Fatalf("edit error = %v", err)
+			}
+
+			got, err := f.Bytes()
+			if err != nil {
+				t.Fatalf("Bytes() error = %v", err)
+			}
+
+			if string(got) != tt.want {
+				t.Errorf("Bytes() =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			if err := f.Save(); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			if written, ok := rec.Files[RequirementsFilename]; ok != (len(tt.diff) > 0) || (ok && !bytes.Equal(written, got)) {
+				t.Errorf("Save() wrote %q, want %q", written, got)
+			}
+
+			diff := tt.diff
+			if len(diff) > 0 {
+				diff = append([]string{"--- a/requirements.yml", "+++ b/requirements.yml"}, diff...)
+			}
+
+			if lines := runner.Diff(RequirementsFilename, []byte(commentedRequirements), got); !slices.Equal(lines, diff) {
+				t.Errorf("Diff() =\n%s\nwant\n%s", strings.Join(lines, "\n"), strings.Join(diff, "\n"))
+			}
+		})
+	}
+}
+
+func TestRestoreBlankLines(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		rendered string
+		want     string
+	}{
+		{
+			name:     "kept before the next line",
+			original: "a:\n  - b\n\n  - c\n",
+			rendered: "a:\n  - b\n  - c\n",
+			want:     "a:\n  - b\n\n  - c\n",
+		},
+		{
+			name:     "several blank lines",
+			original: "a:\n  - b\n\n\nc:\n  - d\n",
+			rendered: "a:\n  - b\nc:\n  - d\n",
+			want:     "a:\n  - b\n\n\nc:\n  - d\n",
+		},
+		{
+			name:     "kept after the previous line when the next changed",
+			original: "a:\n  - b\n\n  - c\n",
+			rendered: "a:\n  - b\n  - src: c\n",
+			want:     "a:\n  - b\n\n  - src: c\n",
+		},
+		{
+			name:     "dropped when both lines are gone",
+			original: "a:\n  - b\n\n  - c\n",
+			rendered: "a:\n  - d\n",
+			want:     "a:\n  - d\n",
+		},
+		{
+			name:     "dropped after a line that was not separated",
+			original: "a:\n  - b\n\n  - c\n",
+			rendered: "a:\n  - c\n",
+			want:     "a:\n  - c\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(restoreBlankLines([]byte(tt.original), []byte(tt.rendered))); got != tt.want {
+				t.Errorf("restoreBlankLines() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
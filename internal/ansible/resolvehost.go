@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// ResolveHost returns the connection details of the inventory host name as
+// Ansible resolves them. The variables are read with "ansible-inventory
+// --host <name>" against the inventory (see [InventorySource]), so that a
+// user or port set in [all:vars], a group or the group_vars/ and host_vars/
+// files is found as well as one set on the host, and in dynamic inventory
+// mode the live address reported by the backend is used. During a dry run
+// only the command is printed and a HostConnection holding just the name
+// is returned.
+//
+// An error is returned if ansible-inventory fails, e.g. because name is not
+// an inventory host, or its output cannot be parsed.
+func ResolveHost(name string) (HostConnection, error) {
+	out, err := runner.Capture("ansible-inventory", "-i", InventorySource(), "--host", name)
+	if err != nil {
+		return HostConnection{}, fmt.Errorf("can't resolve the variables of '%s': %w", name, err)
+	}
+
+	if runner.IsDryRun() {
+		return HostConnection{Name: name}, nil
+	}
+
+	var vars map[string]any
+
+	if err := json.Unmarshal([]byte(out), &vars); err != nil {
+		return HostConnection{}, fmt.Errorf("can't parse the ansible-inventory output: %w", err)
+	}
+
+	return newHostConnection(name, vars), nil
+}
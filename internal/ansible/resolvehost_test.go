@@ -0,0 +1,101 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestResolveHost(t *testing.T) {
+	const command = "ansible-inventory -i hosts.ini --host debian"
+
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		want    HostConnection
+		wantErr bool
+	}{
+		{
+			name: "variables of groups and files",
+			output: `{
+				"ansible_host": "192.168.121.10",
+				"ansible_port": 2222,
+				"ansible_user": "vagrant",
+				"ansible_ssh_private_key_file": ".vagrant/id",
+				"ansible_ssh_common_args": "-o ProxyJump=bastion -oIdentitiesOnly=yes"
+			}`,
+			want: HostConnection{
+				Name:         "debian",
+				Address:      "192.168.121.10",
+				Port:         "2222",
+				User:         "vagrant",
+				IdentityFile: ".vagrant/id",
+				SSHOptions:   []string{"ProxyJump=bastion", "IdentitiesOnly=yes"},
+			},
+		},
+		{
+			name:   "legacy variable names",
+			output: `{"ansible_ssh_host": "10.0.0.2", "ansible_ssh_user": "root"}`,
+			want:   HostConnection{Name: "debian", Address: "10.0.0.2", User: "root"},
+		},
+		{
+			name:   "no address",
+			output: `{"ansible_connection": "community.docker.docker"}`,
+			want:   HostConnection{Name: "debian", Address: "debian", Connection: "community.docker.docker"},
+		},
+		{
+			name:   "vault-encrypted user",
+			output: `{"ansible_host": "10.0.0.2", "ansible_user": {"__ansible_vault": "$ANSIBLE_VAULT;1.1;AES256"}}`,
+			want:   HostConnection{Name: "debian", Address: "10.0.0.2"},
+		},
+		{
+			name:    "unknown host",
+			err:     errors.New("exit status 1"),
+			wantErr: true,
+		},
+		{
+			name:    "output that is not JSON",
+			output:  "debian | ansible_host=10.0.0.2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+			rec.Outputs[command] = tt.output
+			rec.Errors[command] = tt.err
+
+			got, err := ResolveHost("debian")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ResolveHost() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if lines := rec.Lines(); !slices.Equal(lines, []string{command}) {
+				t.Errorf("commands = %q, want %q", lines, command)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResolveHost() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
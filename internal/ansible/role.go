@@ -16,15 +16,89 @@ limitations under the License.
 
 package ansible
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
 // Role describes a single Ansible role dependency declared in the
-// requirements.yml file.
+// requirements.yml file, in the format accepted by
+// "ansible-galaxy role install -r".
 //
 // Fields:
 //   - Name:    the role name as it appears in the Galaxy namespace or local path.
 //   - Source:  an optional URL or Galaxy reference where the role is hosted.
-//   - Version: an optional version constraint string (e.g. "v1.2.0").
+//   - Scm:     the source control system of a repository Source, "git" or
+//     "hg".
+//   - Version: an optional version constraint string (e.g. "v1.2.0"), or
+//     the branch, tag or commit of a repository Source.
+//   - Include: the path of another requirements file whose roles are
+//     installed too; an entry with Include has no other field.
 type Role struct {
-	Name    string `yaml:"name"`
-	Source  string `yaml:"src"`
-	Version string `yaml:"version"`
+	Name    string `yaml:"name,omitempty"`
+	Source  string `yaml:"src,omitempty"`
+	Scm     string `yaml:"scm,omitempty"`
+	Version string `yaml:"version,omitempty"`
+	Include string `yaml:"include,omitempty"`
+}
+
+// Validate reports the first problem that would make ansible-galaxy
+// reject the entry: an include entry with other fields, an entry with
+// neither a name nor a source, an unsupported scm, or an scm given for a
+// source that is not a repository URL.
+func (r Role) Validate() error {
+	if len(r.Include) > 0 {
+		if len(r.Name)+len(r.Source)+len(r.Scm)+len(r.Version) > 0 {
+			return fmt.Errorf("role include '%s' can't have other fields", r.Include)
+		}
+
+		return nil
+	}
+
+	if len(r.Name) == 0 && len(r.Source) == 0 {
+		return errors.New("role requirement needs a name or a src")
+	}
+
+	switch r.Scm {
+	case "", "git", "hg":
+	default:
+		return fmt.Errorf("role '%s' has unsupported scm '%s' (git, hg)", r.Name, r.Scm)
+	}
+
+	if len(r.Scm) > 0 && !strings.ContainsAny(r.Source, "/:") {
+		return fmt.Errorf("role '%s' sets scm but its src '%s' is not a repository URL", r.Name, r.Source)
+	}
+
+	return nil
+}
+
+// IsRepository reports whether the role is installed from a git
+// repository.
+func (r Role) IsRepository() bool {
+	return r.Scm == "git" || strings.HasPrefix(r.Source, "git+")
+}
+
+// UnmarshalYAML decodes a role entry of requirements.yml. Besides a
+// mapping, ansible-galaxy accepts a bare source string; the name of a role
+// without one is derived from its source (see [RequirementRoleName]).
+func (r *Role) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*r = Role{Name: RequirementRoleName(node.Value), Source: node.Value}
+		return nil
+	}
+
+	type plain Role
+
+	if err := node.Decode((*plain)(r)); err != nil {
+		return err
+	}
+
+	if len(r.Name) == 0 && len(r.Include) == 0 {
+		r.Name = RequirementRoleName(r.Source)
+	}
+
+	return nil
 }
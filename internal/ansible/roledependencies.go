@@ -0,0 +1,80 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RoleDependencies returns the names of the roles the role in dir depends
+// on, read from the "dependencies" list of its meta/main.yml. An entry may
+// be a role name, or a mapping naming the role with "role", "name" or
+// "src" (see [RequirementRoleName]). A role without meta/main.yml has no
+// dependencies.
+//
+// An error is returned if meta/main.yml cannot be read or parsed.
+func RoleDependencies(dir string) ([]string, error) {
+	var meta struct {
+		Dependencies []yaml.Node `yaml:"dependencies"`
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "meta", "main.yml"))
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+
+	for _, node := range meta.Dependencies {
+		if node.Kind == yaml.ScalarNode {
+			names = append(names, RequirementRoleName(node.Value))
+			continue
+		}
+
+		var dependency struct {
+			Role   string `yaml:"role"`
+			Name   string `yaml:"name"`
+			Source string `yaml:"src"`
+		}
+
+		if err := node.Decode(&dependency); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case len(dependency.Role) > 0:
+			names = append(names, RequirementRoleName(dependency.Role))
+		case len(dependency.Name) > 0:
+			names = append(names, dependency.Name)
+		case len(dependency.Source) > 0:
+			names = append(names, RequirementRoleName(dependency.Source))
+		}
+	}
+
+	return names, nil
+}
@@ -0,0 +1,223 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// ModuleResult is the outcome of an ad-hoc module run on a single host, as
+// reported by the ansible JSON stdout callback.
+//
+// Fields:
+//   - Host:    the inventory host name.
+//   - Status:  "CHANGED", "SUCCESS", "FAILED" or "UNREACHABLE".
+//   - Changed: whether the module reported a change.
+//   - RC:      the return code for modules that report one (command, shell,
+//     raw, script), otherwise nil.
+//   - Stdout:  the standard output of the module, or for modules without
+//     one the remaining result values rendered as compact JSON.
+//   - Stderr:  the standard error of the module, if any.
+//   - Msg:     the message reported by the module, typically set when it
+//     failed or the host was unreachable.
+type ModuleResult struct {
+	Host    string `json:"host"`
+	Status  string `json:"status"`
+	Changed bool   `json:"changed"`
+	RC      *int   `json:"rc,omitempty"`
+	Stdout  string `json:"stdout,omitempty"`
+	Stderr  string `json:"stderr,omitempty"`
+	Msg     string `json:"msg,omitempty"`
+}
+
+// moduleEnvironment enables the JSON stdout callback of the ansible.posix
+// collection for ad-hoc commands, which ignore callbacks by default.
+var moduleEnvironment = []string{
+	"ANSIBLE_LOAD_CALLBACK_PLUGINS=1",
+	"ANSIBLE_STDOUT_CALLBACK=ansible.posix.json",
+}
+
+// resultKeys are the result values reported in dedicated fields of a
+// [ModuleResult] or that only describe the module invocation; they are
+// left out when the remaining values are rendered as output.
+var resultKeys = map[string]bool{
+	"changed":      true,
+	"cmd":          true,
+	"delta":        true,
+	"deprecations": true,
+	"end":          true,
+	"failed":       true,
+	"invocation":   true,
+	"msg":          true,
+	"rc":           true,
+	"start":        true,
+	"stderr":       true,
+	"stderr_lines": true,
+	"stdout":       true,
+	"stdout_lines": true,
+	"unreachable":  true,
+	"warnings":     true,
+}
+
+// RunModule runs "ansible -i hosts.ini -m <module> -a <args> <pattern>"
+// with the JSON stdout callback enabled and returns the result reported for
+// each host, sorted by host name. An empty pattern targets "all"; become
+// adds "--become"; empty args omits "-a". In dynamic inventory mode the
+// inventory script is used instead of hosts.ini (see [InventorySource]).
+//
+// The results are returned even when ansible exits with an error because
+// some hosts failed, so that callers can report them; in that case the
+// error is returned as well. During a dry run the command is only printed
+// and no results are returned.
+func RunModule(module, args, pattern string, become bool) ([]ModuleResult, error) {
+	if len(pattern) == 0 {
+		pattern = "all"
+	}
+
+	param := []string{"-i", InventorySource(), "-m", module}
+
+	if len(args) > 0 {
+		param = append(param, "-a", args)
+	}
+
+	if become {
+		param = append(param, "--become")
+	}
+
+	param = append(param, pattern)
+
+	out, runErr := runner.CaptureEnv(moduleEnvironment, "ansible", param...)
+
+	if runner.IsDryRun() {
+		return nil, nil
+	}
+
+	results, err := parseModuleResults(out)
+	if err != nil {
+		if runErr != nil {
+			return nil, fmt.Errorf("the '%s' module failed: %w", module, runErr)
+		}
+
+		return nil, fmt.Errorf("unable to parse the ansible JSON output: %w", err)
+	}
+
+	if runErr != nil {
+		return results, fmt.Errorf("the '%s' module failed on one or more hosts: %w", module, runErr)
+	}
+
+	return results, nil
+}
+
+// parseModuleResults extracts the per-host results from the output of the
+// JSON stdout callback, i.e. plays[].tasks[].hosts.<host>.
+func parseModuleResults(out string) ([]ModuleResult, error) {
+	var report struct {
+		Plays []struct {
+			Tasks []struct {
+				Hosts map[string]map[string]any `json:"hosts"`
+			} `json:"tasks"`
+		} `json:"plays"`
+	}
+
+	// Warnings printed by ansible itself may precede the JSON document.
+	if i := strings.Index(out, "{"); i > 0 {
+		out = out[i:]
+	}
+
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		return nil, err
+	}
+
+	var results []ModuleResult
+
+	for _, play := range report.Plays {
+		for _, task := range play.Tasks {
+			for host, values := range task.Hosts {
+				results = append(results, moduleResult(host, values))
+			}
+		}
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Host < results[j].Host
+	})
+
+	return results, nil
+}
+
+// moduleResult converts the result values of one host into a ModuleResult.
+func moduleResult(host string, values map[string]any) ModuleResult {
+	r := ModuleResult{
+		Host:   host,
+		Status: "SUCCESS",
+	}
+
+	r.Changed, _ = values["changed"].(bool)
+	r.Stdout, _ = values["stdout"].(string)
+	r.Stderr, _ = values["stderr"].(string)
+	r.Msg, _ = values["msg"].(string)
+
+	if rc, ok := values["rc"].(float64); ok {
+		code := int(rc)
+		r.RC = &code
+	}
+
+	switch {
+	case values["unreachable"] == true:
+		r.Status = "UNREACHABLE"
+	case values["failed"] == true:
+		r.Status = "FAILED"
+	case r.Changed:
+		r.Status = "CHANGED"
+	}
+
+	if _, ok := values["stdout"]; !ok {
+		r.Stdout = remainingValues(values)
+	}
+
+	return r
+}
+
+// remainingValues renders the result values that are not reported in a
+// dedicated field as compact JSON, or returns "" if there are none.
+func remainingValues(values map[string]any) string {
+	rest := make(map[string]any)
+
+	for k, v := range values {
+		if resultKeys[k] || strings.HasPrefix(k, "_ansible") {
+			continue
+		}
+
+		rest[k] = v
+	}
+
+	if len(rest) == 0 {
+		return ""
+	}
+
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
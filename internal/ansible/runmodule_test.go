@@ -0,0 +1,102 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// copyOutput is the output of the JSON stdout callback for a copy to two
+// hosts where one host failed with a multi-line message, preceded by a
+// warning as printed with -v.
+const copyOutput = `[WARNING]: Platform linux on host alma is using the discovered Python
+interpreter
+{
+  "plays": [{
+    "tasks": [{
+      "hosts": {
+        "debian": {"changed": true, "dest": "/tmp/motd", "checksum": "abc", "_ansible_no_log": false},
+        "alma": {"changed": false, "failed": true, "msg": "Destination directory /tmp does not exist\nsecond line"},
+        "rocky": {"unreachable": true, "msg": "Failed to connect to the host via ssh"},
+        "fedora": {"changed": false, "dest": "/tmp/motd"}
+      }
+    }]
+  }]
+}
+`
+
+func TestRunModule(t *testing.T) {
+	const command = `ANSIBLE_LOAD_CALLBACK_PLUGINS=1 ANSIBLE_STDOUT_CALLBACK=ansible.posix.json ansible -i hosts.ini -m copy -a '{"dest":"/tmp/motd","src":"motd"}' --become all`
+
+	tests := []struct {
+		name    string
+		output  string
+		err     error
+		want    []ModuleResult
+		wantErr bool
+	}{
+		{
+			name:    "results of every host",
+			output:  copyOutput,
+			err:     errors.New("exit status 2"),
+			wantErr: true,
+			want: []ModuleResult{
+				{Host: "alma", Status: "FAILED", Msg: "Destination directory /tmp does not exist\nsecond line"},
+				{Host: "debian", Status: "CHANGED", Changed: true, Stdout: `{"checksum":"abc","dest":"/tmp/motd"}`},
+				{Host: "fedora", Status: "SUCCESS", Stdout: `{"dest":"/tmp/motd"}`},
+				{Host: "rocky", Status: "UNREACHABLE", Msg: "Failed to connect to the host via ssh"},
+			},
+		},
+		{
+			name:   "no hosts",
+			output: `{"plays": []}`,
+		},
+		{
+			name:    "output that is not JSON",
+			output:  "debian | CHANGED => {",
+			wantErr: true,
+		},
+		{
+			name:    "failure without output",
+			err:     errors.New("exit status 1"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+			rec.Outputs[command] = tt.output
+			rec.Errors[command] = tt.err
+
+			got, err := RunModule("copy", ModuleArgs(map[string]string{"src": "motd", "dest": "/tmp/motd"}), "", true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RunModule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if lines := rec.Lines(); !slices.Equal(lines, []string{command}) {
+				t.Errorf("commands = %q, want %q", lines, command)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("RunModule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SelectHosts returns the inventory hosts selected by the --vm flag of the
+// lifecycle commands. When vms is empty every host in the [vagrant] group
+// of hosts.ini is returned, in inventory order. Otherwise vms is returned
+// unchanged after checking that each name is an inventory host.
+//
+// An error is returned if hosts.ini cannot be read or a name is not in the
+// inventory.
+func SelectHosts(vms []string) ([]string, error) {
+	inventory, err := GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	hosts := make([]string, 0, len(inventory))
+	for _, host := range inventory {
+		hosts = append(hosts, host.Name)
+	}
+
+	if len(vms) == 0 {
+		return hosts, nil
+	}
+
+	for _, vm := range vms {
+		if !slices.Contains(hosts, vm) {
+			return nil, fmt.Errorf("'%s' is not a host in hosts.ini", vm)
+		}
+	}
+
+	return vms, nil
+}
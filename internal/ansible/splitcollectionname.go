@@ -0,0 +1,42 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// galaxyName matches the namespace or the name of a collection as Galaxy
+// allows them.
+var galaxyName = regexp.MustCompile(`^[a-z0-9_]+$`)
+
+// SplitCollectionName splits the fully qualified collection name
+// "namespace.name" into its namespace and name. Both parts are safe to use
+// as directory names under [CollectionsFolder].
+//
+// An error is returned if name does not have exactly two parts made of
+// lowercase letters, digits and underscores.
+func SplitCollectionName(name string) (string, string, error) {
+	namespace, collection, ok := strings.Cut(name, ".")
+	if !ok || !galaxyName.MatchString(namespace) || !galaxyName.MatchString(collection) {
+		return "", "", fmt.Errorf("collection name '%s' is not 'namespace.name'", name)
+	}
+
+	return namespace, collection, nil
+}
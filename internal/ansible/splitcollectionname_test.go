@@ -0,0 +1,49 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import "testing"
+
+func TestSplitCollectionName(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespace  string
+		collection string
+		ok         bool
+	}{
+		{"community.general", "community", "general", true},
+		{"my_ns.role_2", "my_ns", "role_2", true},
+		{"community", "", "", false},
+		{".general", "", "", false},
+		{"community.", "", "", false},
+		{"community.general.extra", "", "", false},
+		{"Community.General", "", "", false},
+		{"foo.bar/../..", "", "", false},
+		{"a.b/../../..", "", "", false},
+		{"../x.y", "", "", false},
+		{"a b.c", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, collection, err := SplitCollectionName(tt.name)
+			if namespace != tt.namespace || collection != tt.collection || (err == nil) != tt.ok {
+				t.Errorf("SplitCollectionName(%q) = %q, %q, %v", tt.name, namespace, collection, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// SummarizeAdHoc prints one line per host of the results of [RunModule]:
+// changed hosts in yellow, unchanged hosts in green and failed or
+// unreachable hosts in red, followed by the message the module reported
+// for them. The verb describes the operation, e.g. "copied". Nothing is
+// printed during a dry run, since no host was contacted.
+func SummarizeAdHoc(results []ModuleResult, verb string) {
+	if runner.IsDryRun() {
+		return
+	}
+
+	width := 0
+	changed := 0
+
+	for _, r := range results {
+		width = max(width, len(r.Host))
+	}
+
+	fmt.Println(textformat.Yellow("Summary:"))
+
+	for _, r := range results {
+		switch r.Status {
+		case "CHANGED":
+			changed++
+			fmt.Println(textformat.Yellow(fmt.Sprintf("  %-*s  [Changed] %s", width, r.Host, verb)))
+		case "SUCCESS":
+			fmt.Println(textformat.Green(fmt.Sprintf("  %-*s  [OK] unchanged", width, r.Host)))
+		case "UNREACHABLE":
+			fmt.Println(textformat.Red(fmt.Sprintf("  %-*s  [Unreachable] %s", width, r.Host, r.Msg)))
+		default:
+			fmt.Println(textformat.Red(fmt.Sprintf("  %-*s  [Failed] %s", width, r.Host, r.Msg)))
+		}
+	}
+
+	fmt.Printf("\n%d of %d hosts changed\n", changed, len(results))
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"slices"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// SyncInventory returns the contents of the inventory file (see
+// [inventory.Path]) brought in line with machines. When the file does not
+// exist it is rendered from scratch by [HostsIni]. Otherwise it is edited in place with the inventory package,
+// so that comments, host variables and any group ansible-dev does not
+// manage (e.g. [web] or [db] added by hand) are kept:
+//   - a machine missing from the [vagrant] group is added with the 0.0.0.0
+//     placeholder address, and to each of its groups that does not list
+//     it yet;
+//   - a host of the [vagrant] group that is no longer a machine is removed
+//     from every group;
+//   - the connection variables of [all:vars] are set for the configured
+//     backend (see [allVars]).
+func SyncInventory(machines []config.Machine) ([]byte, error) {
+	path := inventory.Path()
+	if !runner.FileExist(path) {
+		return HostsIni(machines), nil
+	}
+
+	inv, err := inventory.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var managed []string
+	if g := inv.Group(inventory.Managed); g != nil {
+		managed = g.Hosts
+	}
+
+	for _, host := range managed {
+		if config.FindMachine(machines, host) < 0 {
+			if err := inv.RemoveHost(host); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, m := range machines {
+		if g := inv.Group(inventory.Managed); g == nil || !slices.Contains(g.Hosts, m.Name) {
+			var vars [][2]string
+			if inv.Host(m.Name) == nil {
+				vars = [][2]string{{"ansible_host", "0.0.0.0"}}
+			}
+
+			if err := inv.AddHost(inventory.Managed, m.Name, vars); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, name := range m.Groups {
+			if g := inv.Group(name); g == nil || !slices.Contains(g.Hosts, m.Name) {
+				if err := inv.AddHost(name, m.Name, nil); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	for _, v := range allVars() {
+		if len(v[1]) == 0 {
+			err = inv.UnsetGroupVar("all", v[0])
+		} else {
+			err = inv.SetGroupVar("all", v[0], v[1])
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return inv.Bytes()
+}
@@ -19,11 +19,10 @@ package ansible
 import (
 	"bytes"
 	"io/fs"
-	"os"
 	"path/filepath"
 
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/ansible-dev/internal/templates"
-	"github.com/dcjulian29/go-toolbox/filesystem"
 )
 
 // ApplyTemplate walks the template filesystem src and writes each file into
@@ -46,7 +45,7 @@ func ApplyTemplate(src fs.FS, dest string, replacements map[string]string) error
 		target := filepath.Join(dest, filepath.FromSlash(path))
 
 		if d.IsDir() {
-			return filesystem.EnsureDirectoryExist(target)
+			return runner.MkdirAll(target)
 		}
 
 		content, err := fs.ReadFile(src, path)
@@ -58,11 +57,7 @@ func ApplyTemplate(src fs.FS, dest string, replacements map[string]string) error
 			content = bytes.ReplaceAll(content, []byte(sentinel), []byte(value))
 		}
 
-		if err := filesystem.EnsureDirectoryExist(filepath.Dir(target)); err != nil {
-			return err
-		}
-
-		return os.WriteFile(target, content, 0o644)
+		return runner.WriteFile(target, content)
 	})
 }
 
@@ -0,0 +1,77 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+// UnusedCollections returns the installed collections (see
+// [InstalledCollections]) that are neither installed from a requirement of
+// requirements.yml (see [Collection.Installs]) nor dependencies, direct or
+// transitive, of such a collection according to their MANIFEST.json.
+//
+// An error is returned if requirements.yml or ansible.cfg cannot be read,
+// or a MANIFEST.json cannot be parsed.
+func UnusedCollections() ([]InstalledCollection, error) {
+	requirements, err := ReadRequirements()
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := InstalledCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	installed := map[string]InstalledCollection{}
+	pending := []string{}
+
+	for _, c := range collections {
+		installed[c.Name] = c
+
+		for _, r := range requirements.Collections {
+			if r.Installs(c.Name, c.Repository) {
+				pending = append(pending, c.Name)
+				break
+			}
+		}
+	}
+
+	used := map[string]bool{}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+
+		if used[name] {
+			continue
+		}
+
+		used[name] = true
+
+		for dependency := range installed[name].Dependencies {
+			pending = append(pending, dependency)
+		}
+	}
+
+	unused := []InstalledCollection{}
+
+	for _, c := range collections {
+		if !used[c.Name] {
+			unused = append(unused, c)
+		}
+	}
+
+	return unused, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"path/filepath"
+)
+
+// UnusedRoles returns the roles installed by ansible-galaxy (see
+// [InstalledRoles]) that are neither declared in requirements.yml, or in a
+// file it includes (see [IncludedRoles]), nor dependencies, direct or
+// transitive, of a declared role (see [RoleDependencies]). Roles developed
+// in place, which have no meta/.galaxy_install_info, are never reported.
+//
+// An error is returned if requirements.yml, an included file or ansible.cfg
+// cannot be read, or the metadata of an installed role cannot be read.
+func UnusedRoles() ([]InstalledRole, error) {
+	requirements, err := ReadRequirements()
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := RootRoleFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	used := map[string]bool{}
+	pending := []string{}
+
+	for _, r := range requirements.Roles {
+		if len(r.Include) == 0 {
+			pending = append(pending, r.Name)
+			continue
+		}
+
+		included, err := IncludedRoles(r.Include)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range included {
+			pending = append(pending, i.Name)
+		}
+	}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+
+		if used[name] {
+			continue
+		}
+
+		used[name] = true
+
+		dependencies, err := RoleDependencies(filepath.Join(folder, name))
+		if err != nil {
+			return nil, err
+		}
+
+		pending = append(pending, dependencies...)
+	}
+
+	roles, err := InstalledRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	unused := []InstalledRole{}
+
+	for _, r := range roles {
+		if !used[r.Name] {
+			unused = append(unused, r)
+		}
+	}
+
+	return unused, nil
+}
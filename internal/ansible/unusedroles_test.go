@@ -0,0 +1,202 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// writeFiles changes to a new directory and writes files, keyed by their
+// slash-separated path, creating the directories they are in.
+func writeFiles(t *testing.T, files map[string]string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	for name, content := range files {
+		path := filepath.FromSlash(name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// installInfo is the meta/.galaxy_install_info of a role installed by
+// ansible-galaxy.
+const installInfo = "install_date: 'Mon Jan  5 10:00:00 2026'\nversion: 1.0.0\n"
+
+// manifest returns the MANIFEST.json of the collection namespace.name
+// depending on dependencies.
+func manifest(namespace, name string, dependencies ...string) string {
+	deps := ""
+	for i, d := range dependencies {
+		if i > 0 {
+			deps += ", "
+		}
+
+		deps += `"` + d + `": ">=1.0.0"`
+	}
+
+	return `{"collection_info": {"namespace": "` + namespace + `", "name": "` + name +
+		`", "version": "1.0.0", "dependencies": {` + deps + `}}}`
+}
+
+func TestUnusedRoles(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements string
+		files        map[string]string
+		want         []string
+	}{
+		{
+			name:         "nothing required",
+			requirements: "roles: []\n",
+			want:         []string{"common", "docker", "extra", "nginx", "pip"},
+		},
+		{
+			name:         "required roles and their dependencies",
+			requirements: "roles:\n  - name: nginx\n",
+			want:         []string{"docker", "extra", "pip"},
+		},
+		{
+			name:         "transitive dependencies",
+			requirements: "roles:\n  - name: docker\n",
+			want:         []string{"extra", "nginx"},
+		},
+		{
+			name:         "bare and source entries",
+			requirements: "roles:\n  - extra\n  - src: https://github.com/example/pip.git\n    scm: git\n",
+			want:         []string{"docker", "nginx"},
+		},
+		{
+			name:         "included roles",
+			requirements: "roles:\n  - include: requirements/webservers.yml\n",
+			files: map[string]string{
+				"requirements/webservers.yml": "- name: nginx\n- extra\n",
+			},
+			want: []string{"docker", "pip"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]string{
+				"ansible.cfg":                            "[defaults]\nroles_path = roles\n",
+				"requirements.yml":                       tt.requirements,
+				"roles/nginx/meta/.galaxy_install_info":  installInfo,
+				"roles/nginx/meta/main.yml":              "dependencies:\n  - role: common\n",
+				"roles/common/meta/.galaxy_install_info": installInfo,
+				"roles/docker/meta/.galaxy_install_info": installInfo,
+				"roles/docker/meta/main.yml":             "dependencies:\n  - name: pip\n",
+				"roles/pip/meta/.galaxy_install_info":    installInfo,
+				"roles/pip/meta/main.yml":                "dependencies:\n  - src: https://github.com/example/common.git\n",
+				"roles/extra/meta/.galaxy_install_info":  installInfo,
+				"roles/local/tasks/main.yml":             "---\n",
+			}
+
+			for name, content := range tt.files {
+				files[name] = content
+			}
+
+			writeFiles(t, files)
+
+			roles, err := UnusedRoles()
+			if err != nil {
+				t.Fatalf("UnusedRoles() error = %v", err)
+			}
+
+			got := []string{}
+			for _, r := range roles {
+				got = append(got, r.Name)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UnusedRoles() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnusedRolesMissingInclude(t *testing.T) {
+	writeFiles(t, map[string]string{
+		"ansible.cfg":                           "[defaults]\nroles_path = roles\n",
+		"requirements.yml":                      "roles:\n  - include: missing.yml\n",
+		"roles/nginx/meta/.galaxy_install_info": installInfo,
+	})
+
+	if roles, err := UnusedRoles(); err == nil {
+		t.Errorf("UnusedRoles() = %v, want an error for the missing include", roles)
+	}
+}
+
+func TestUnusedCollections(t *testing.T) {
+	tests := []struct {
+		name         string
+		requirements string
+		want         []string
+	}{
+		{
+			name:         "nothing required",
+			requirements: "collections: []\n",
+			want:         []string{"ansible.posix", "community.docker", "community.library"},
+		},
+		{
+			name:         "required collections and their dependencies",
+			requirements: "collections:\n  - name: community.docker\n",
+			want:         []string{"ansible.posix"},
+		},
+		{
+			name:         "bare entries",
+			requirements: "collections:\n  - ansible.posix\n",
+			want:         []string{"community.docker", "community.library"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeFiles(t, map[string]string{
+				"ansible.cfg":      "[defaults]\ncollections_path = collections\n",
+				"requirements.yml": tt.requirements,
+				"collections/ansible_collections/ansible/posix/MANIFEST.json":     manifest("ansible", "posix"),
+				"collections/ansible_collections/community/docker/MANIFEST.json":  manifest("community", "docker", "community.library"),
+				"collections/ansible_collections/community/library/MANIFEST.json": manifest("community", "library"),
+				"collections/ansible_collections/local/dev/galaxy.yml":            "namespace: local\nname: dev\n",
+			})
+
+			collections, err := UnusedCollections()
+			if err != nil {
+				t.Fatalf("UnusedCollections() error = %v", err)
+			}
+
+			got := []string{}
+			for _, c := range collections {
+				got = append(got, c.Name)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("UnusedCollections() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ansible
+
+import (
+	"sync"
+
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+)
+
+// inventoryMu serializes the read-modify-write cycle of
+// [UpdateInventoryHost] so that hosts brought up concurrently cannot
+// overwrite each other's connection details.
+var inventoryMu sync.Mutex
+
+// UpdateInventoryHost sets the given connection variables on the named
+// machine in the given inventory file (see [inventory.Inventory.SetHostVars]).
+// Variables already set are replaced in place and missing ones are
+// appended in order. The other connection variables (see [HostLine]) are
+// removed, so that details left by a previous backend do not linger, while
+// any other variables, groups and comments are kept. It is safe for
+// concurrent use and is called by the backends once a host is up.
+func UpdateInventoryHost(filename, name string, vars [][2]string) error {
+	inventoryMu.Lock()
+	defer inventoryMu.Unlock()
+
+	inv, err := inventory.Load(filename)
+	if err != nil {
+		return err
+	}
+
+	var unset []string
+
+	for _, key := range connectionVars {
+		if !hasVar(vars, key) {
+			unset = append(unset, key)
+		}
+	}
+
+	if err := inv.SetHostVars(name, vars, unset); err != nil {
+		return err
+	}
+
+	return inv.Save()
+}
+
+// connectionVars lists the variables written by [HostLine].
+var connectionVars = []string{
+	"ansible_host",
+	"ansible_port",
+	"ansible_user",
+	"ansible_ssh_private_key_file",
+	"ansible_connection",
+}
+
+// hasVar reports whether vars sets key.
+func hasVar(vars [][2]string, key string) bool {
+	for _, v := range vars {
+		if v[0] == key {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,128 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backend selects what provides the development hosts of a
+// project. The [Backend] interface covers the host lifecycle used by the
+// start, stop, reset, destroy and status commands; [Vagrant] implements it
+// with the VMs of the vagrant package and [Container] with the Docker or
+// Podman containers of the container package.
+//
+// The backend is chosen per project with the "backend" configuration value
+// ("vagrant", "docker" or "podman") and returned by [Current].
+package backend
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+)
+
+// Machine is the state of a single development host.
+//
+// Fields:
+//   - Name:     the inventory host name.
+//   - Provider: what runs the host (e.g. "libvirt" or "docker").
+//   - State:    the host state as reported by the provider (e.g.
+//     "running", "poweroff", "exited", "not_created").
+type Machine struct {
+	Name     string
+	Provider string
+	State    string
+}
+
+// Backend provides the development hosts.
+//
+// Methods:
+//   - Name:       the configured backend name.
+//   - Ensure:     verifies the backend can be used in the current
+//     directory (e.g. a Vagrantfile exists or the runtime is installed).
+//   - Up:         creates or starts the named hosts and writes their
+//     connection details to hosts.ini. parallel has the meaning of the
+//     --parallel flag of "start".
+//   - Down:       stops the named hosts, or every host when none is given.
+//   - Destroy:    removes the named hosts, or every host together with the
+//     local artifacts when none is given.
+//   - Status:     reports the state of the hosts that exist.
+//   - Connection: the Ansible connection variables of a running host.
+//   - Console:    opens an interactive shell on a host, or runs command
+//     there, through the backend's own channel (e.g. "vagrant ssh"),
+//     optionally as root.
+type Backend interface {
+	Name() string
+	Ensure() error
+	Up(names []string, parallel int) error
+	Down(names ...string) error
+	Destroy(names ...string) error
+	Status() ([]Machine, error)
+	Connection(name string) ([][2]string, error)
+	Console(name, command string, become bool) error
+}
+
+// Current returns the backend selected by the "backend" configuration
+// value. An error is returned for an unknown backend.
+func Current() (Backend, error) {
+	cfg := config.Current()
+
+	switch cfg.Backend {
+	case "", "vagrant":
+		return Vagrant{}, nil
+	case "docker", "podman":
+		return Container{Runtime: cfg.Backend}, nil
+	default:
+		return nil, fmt.Errorf("unknown backend '%s' (vagrant, docker, podman)", cfg.Backend)
+	}
+}
+
+// Ensure verifies that the current backend can be used; see
+// [Backend.Ensure].
+func Ensure() error {
+	b, err := Current()
+	if err != nil {
+		return err
+	}
+
+	return b.Ensure()
+}
+
+// Connections returns the connection variables of every running host of b
+// (see [Backend.Connection]), keyed by host name. Hosts in any other state
+// are left out, since they have no address to report.
+//
+// An error is returned if the state or the connection variables of a host
+// cannot be read.
+func Connections(b Backend) (map[string][][2]string, error) {
+	machines, err := b.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	connections := map[string][][2]string{}
+
+	for _, m := range machines {
+		if m.State != "running" {
+			continue
+		}
+
+		vars, err := b.Connection(m.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		connections[m.Name] = vars
+	}
+
+	return connections, nil
+}
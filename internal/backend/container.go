@@ -0,0 +1,141 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/container"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+)
+
+// Container is the [Backend] backed by systemd-capable containers run by
+// Runtime ("docker" or "podman"). Machines use their "image" when set and
+// "container.image" otherwise; "container.connection" selects whether
+// Ansible reaches them through the runtime's connection plugin or SSH.
+type Container struct {
+	Runtime string
+}
+
+// Name returns the container runtime.
+func (c Container) Name() string {
+	return c.Runtime
+}
+
+// Ensure verifies that the container runtime is installed.
+func (c Container) Ensure() error {
+	if _, err := exec.LookPath(c.Runtime); err != nil {
+		return fmt.Errorf("the '%s' backend requires '%s' to be installed", c.Runtime, c.Runtime)
+	}
+
+	return nil
+}
+
+// Up starts the container of each named machine in turn (see
+// [container.Up]) and writes its connection variables to hosts.ini, unless
+// the inventory is dynamic (see [ansible.InventorySource]). With
+// the "ssh" connection it then waits for sshd as for a VM (see
+// [vagrant.WaitForSSH]). Containers start in seconds, so parallel is
+// ignored.
+func (c Container) Up(names []string, _ int) error {
+	cfg := config.Current()
+
+	for _, name := range names {
+		image := cfg.Container.Image
+		if i := config.FindMachine(cfg.Machines, name); i >= 0 && len(cfg.Machines[i].Image) > 0 {
+			image = cfg.Machines[i].Image
+		}
+
+		if err := container.Up(c.Runtime, name, image, cfg.Ready); err != nil {
+			return err
+		}
+
+		if runner.IsDryRun() {
+			continue
+		}
+
+		vars, err := c.Connection(name)
+		if err != nil {
+			return err
+		}
+
+		if !cfg.Inventory.Dynamic {
+			if err := ansible.UpdateInventoryHost(inventory.Path(), name, vars); err != nil {
+				return fmt.Errorf("failed to update the inventory for %s: %w", name, err)
+			}
+		}
+
+		if cfg.Container.Connection == "ssh" {
+			if err := vagrant.WaitForSSH(os.Stdout, name, vars[0][1], "22", cfg.Ready); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Down stops the containers; see [container.Down]. When no name is given
+// every host in hosts.ini is stopped.
+func (c Container) Down(names ...string) error {
+	if len(names) == 0 {
+		var err error
+
+		if names, err = ansible.SelectHosts(nil); err != nil {
+			return err
+		}
+	}
+
+	return container.Down(c.Runtime, names...)
+}
+
+// Destroy removes the containers; see [container.Destroy].
+func (c Container) Destroy(names ...string) error {
+	return container.Destroy(c.Runtime, names...)
+}
+
+// Status reports the containers of the project; see [container.Status].
+func (c Container) Status() ([]Machine, error) {
+	statuses, err := container.Status(c.Runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	machines := make([]Machine, 0, len(statuses))
+	for _, s := range statuses {
+		machines = append(machines, Machine{Name: s.Name, Provider: c.Runtime, State: s.State})
+	}
+
+	return machines, nil
+}
+
+// Connection returns the connection variables of the container; see
+// [container.Connection].
+func (c Container) Connection(name string) ([][2]string, error) {
+	return container.Connection(c.Runtime, config.Current().Container.Connection, name)
+}
+
+// Console opens a shell in the container; see [container.Exec].
+func (c Container) Console(name, command string, become bool) error {
+	return container.Exec(c.Runtime, name, command, become)
+}
@@ -0,0 +1,291 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/container"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// fakeDocker is a stand-in for the docker command line. It appends each
+// invocation to $FAKE_DOCKER_LOG and keeps the "<name>\t<state>" lines of
+// the containers it knows in $FAKE_DOCKER_STATE.
+const fakeDocker = `#!/bin/sh
+echo "$*" >> "$FAKE_DOCKER_LOG"
+
+case "$1" in
+container)
+	state=$(grep "^$5	" "$FAKE_DOCKER_STATE" | cut -f2)
+	if [ -z "$state" ]; then
+		echo "Error: No such container: $5" >&2
+		exit 1
+	fi
+	echo "$state"
+	;;
+run)
+	while [ $# -gt 0 ]; do
+		[ "$1" = "--name" ] && name=$2
+		shift
+	done
+	printf '%s\trunning\n' "$name" >> "$FAKE_DOCKER_STATE"
+	echo 4f1c2b3a
+	;;
+start)
+	sed -i "s/^$2	.*/$2	running/" "$FAKE_DOCKER_STATE"
+	echo "$2"
+	;;
+ps)
+	cat "$FAKE_DOCKER_STATE"
+	;;
+inspect)
+	echo "172.17.0.5 10.88.0.5 "
+	;;
+*)
+	echo "unexpected command: $*" >&2
+	exit 1
+	;;
+esac
+`
+
+// lab changes to a new "lab" development directory with a hosts.ini for
+// the debian and alma hosts, puts fakeDocker first on PATH with the
+// containers of state, and loads the configuration with overrides. It
+// returns the path of the log of docker invocations.
+func lab(t *testing.T, state string, overrides ...string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "bin")
+	work := filepath.Join(dir, "lab")
+
+	for _, d := range []string{bin, work} {
+		if err := os.Mkdir(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(bin, "docker"):       fakeDocker,
+		filepath.Join(dir, "state"):        state,
+		filepath.Join(work, "ansible.cfg"): "[defaults]\ninventory = hosts.ini\n",
+		filepath.Join(work, "hosts.ini"):   "[vagrant]\ndebian\nalma\n",
+	}
+
+	for name, content := range files {
+		if err := os.WriteFile(name, []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("FAKE_DOCKER_LOG", filepath.Join(dir, "log"))
+	t.Setenv("FAKE_DOCKER_STATE", filepath.Join(dir, "state"))
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Chdir(work)
+
+	if err := config.Load(overrides); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = config.Load(nil) })
+
+	return filepath.Join(dir, "log")
+}
+
+// invocations returns the docker invocations recorded in log.
+func invocations(t *testing.T, log string) []string {
+	t.Helper()
+
+	data, err := os.ReadFile(log)
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+
+	if len(data) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestContainerUp(t *testing.T) {
+	log := lab(t, "lab-alma\texited\n", "container.image=example/systemd:1")
+
+	c := Container{Runtime: "docker"}
+
+	if err := c.Ensure(); err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+
+	if err := c.Up([]string{"debian", "alma"}, 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	want := []string{
+		"container inspect --format {{.State.Status}} lab-debian",
+		"run --detach --name lab-debian --hostname debian --label " + container.ProjectLabel + "=" + container.Project() +
+			" --tmpfs /run --tmpfs /tmp --privileged --cgroupns=host --volume /sys/fs/cgroup:/sys/fs/cgroup:rw example/systemd:1",
+		"container inspect --format {{.State.Status}} lab-alma",
+		"start lab-alma",
+	}
+
+	if got := invocations(t, log); !slices.Equal(got, want) {
+		t.Errorf("docker invocations =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	hosts := "[vagrant]\n" +
+		"debian ansible_host=lab-debian ansible_connection=community.docker.docker\n" +
+		"alma ansible_host=lab-alma ansible_connection=community.docker.docker\n"
+
+	if got, err := os.ReadFile("hosts.ini"); err != nil || string(got) != hosts {
+		t.Errorf("hosts.ini = %q, %v, want %q", got, err, hosts)
+	}
+
+	// Running containers are left alone and their entries stay the same.
+	if err := c.Up([]string{"debian"}, 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	if got := invocations(t, log); len(got) != len(want)+1 || got[len(want)] != want[0] {
+		t.Errorf("docker invocations after a second Up = %q", got[len(want):])
+	}
+
+	if got, err := os.ReadFile("hosts.ini"); err != nil || string(got) != hosts {
+		t.Errorf("hosts.ini after a second Up = %q, %v, want %q", got, err, hosts)
+	}
+}
+
+func TestContainerUpDynamicInventory(t *testing.T) {
+	lab(t, "", "inventory.dynamic=true")
+
+	if err := (Container{Runtime: "docker"}).Up([]string{"debian"}, 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	if got, err := os.ReadFile("hosts.ini"); err != nil || string(got) != "[vagrant]\ndebian\nalma\n" {
+		t.Errorf("hosts.ini = %q, %v, want it unchanged", got, err)
+	}
+}
+
+func TestContainerUpDryRun(t *testing.T) {
+	log := lab(t, "")
+
+	var out bytes.Buffer
+
+	previous := runner.Current()
+	t.Cleanup(func() { runner.Set(previous) })
+	runner.Set(runner.NewDryRun(&out))
+
+	if err := (Container{Runtime: "podman"}).Up([]string{"debian"}, 0); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	want := "[dry-run] podman container inspect --format {{.State.Status}} lab-debian\n" +
+		"[dry-run] podman run --detach --name lab-debian --hostname debian --label " +
+		container.ProjectLabel + "=" + container.Project() +
+		" --tmpfs /run --tmpfs /tmp --systemd=always docker.io/geerlingguy/docker-debian12-ansible:latest\n"
+
+	if got := out.String(); got != want {
+		t.Errorf("dry-run output =\n%s\nwant\n%s", got, want)
+	}
+
+	if got := invocations(t, log); len(got) > 0 {
+		t.Errorf("docker invocations = %q, want none", got)
+	}
+
+	if got, err := os.ReadFile("hosts.ini"); err != nil || string(got) != "[vagrant]\ndebian\nalma\n" {
+		t.Errorf("hosts.ini = %q, %v, want it unchanged", got, err)
+	}
+}
+
+func TestContainerStatus(t *testing.T) {
+	log := lab(t, "lab-debian\trunning\nlab-alma\tExited\n")
+
+	got, err := (Container{Runtime: "docker"}).Status()
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	want := []Machine{
+		{Name: "debian", Provider: "docker", State: "running"},
+		{Name: "alma", Provider: "docker", State: "exited"},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("Status() = %+v, want %+v", got, want)
+	}
+
+	ps := "ps --all --filter label=" + container.ProjectLabel + "=" + container.Project() + " --format {{.Names}}\t{{.State}}"
+	if got := invocations(t, log); !slices.Equal(got, []string{ps}) {
+		t.Errorf("docker invocations = %q, want %q", got, ps)
+	}
+}
+
+func TestContainerConnection(t *testing.T) {
+	tests := []struct {
+		name       string
+		connection string
+		want       [][2]string
+		docker     []string
+	}{
+		{
+			name:       "docker",
+			connection: "docker",
+			want: [][2]string{
+				{"ansible_host", "lab-debian"},
+				{"ansible_connection", "community.docker.docker"},
+			},
+		},
+		{
+			name:       "ssh",
+			connection: "ssh",
+			want: [][2]string{
+				{"ansible_host", "172.17.0.5"},
+				{"ansible_port", "22"},
+				{"ansible_connection", "ssh"},
+			},
+			docker: []string{"inspect --format {{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}} lab-debian"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := lab(t, "lab-debian\trunning\n", "container.connection="+tt.connection)
+
+			got, err := (Container{Runtime: "docker"}).Connection("debian")
+			if err != nil {
+				t.Fatalf("Connection() error = %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Connection() = %q, want %q", got, tt.want)
+			}
+
+			if docker := invocations(t, log); !slices.Equal(docker, tt.docker) {
+				t.Errorf("docker invocations = %q, want %q", docker, tt.docker)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backend
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+)
+
+// Vagrant is the [Backend] backed by Vagrant VMs. Every method delegates to
+// the matching function of the vagrant package.
+type Vagrant struct{}
+
+// Name returns "vagrant".
+func (Vagrant) Name() string {
+	return "vagrant"
+}
+
+// Ensure verifies that a Vagrantfile exists; see [vagrant.EnsureVagrantfile].
+func (Vagrant) Ensure() error {
+	return vagrant.EnsureVagrantfile()
+}
+
+// Up brings the VMs online; see [vagrant.UpAll].
+func (Vagrant) Up(names []string, parallel int) error {
+	return vagrant.UpAll(names, parallel)
+}
+
+// Down halts the VMs; see [vagrant.Down].
+func (Vagrant) Down(names ...string) error {
+	return vagrant.Down(names...)
+}
+
+// Destroy destroys the VMs; see [vagrant.Destroy].
+func (Vagrant) Destroy(names ...string) error {
+	return vagrant.Destroy(names...)
+}
+
+// Status reports the VMs known to Vagrant; see [vagrant.Status].
+func (Vagrant) Status() ([]Machine, error) {
+	statuses, err := vagrant.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	machines := make([]Machine, 0, len(statuses))
+	for _, s := range statuses {
+		machines = append(machines, Machine(s))
+	}
+
+	return machines, nil
+}
+
+// Connection returns the connection variables imported from
+// "vagrant ssh-config"; see [vagrant.SSHConfig.InventoryVars].
+func (Vagrant) Connection(name string) ([][2]string, error) {
+	ssh, err := vagrant.GetSSHConfig(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ssh.InventoryVars(), nil
+}
+
+// Console opens a session with "vagrant ssh"; see [vagrant.SSH].
+func (Vagrant) Console(name, command string, become bool) error {
+	return vagrant.SSH(name, command, become)
+}
@@ -0,0 +1,236 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+// Config is the effective ansible-dev configuration.
+//
+// Fields:
+//   - GitHub:    owner and repository prefixes used when publishing.
+//   - Galaxy:    the Galaxy namespace of the roles being developed.
+//   - Paths:     directories holding the role and runbook source
+//     repositories.
+//   - Compare:   ignore lists and diff filters used by "role compare" and
+//     "runbook compare".
+//   - Backend:   what provides the development hosts: "vagrant" (VMs),
+//     "docker" or "podman" (containers).
+//   - Container: settings used by the container backends.
+//   - VM:        default resources for every development VM.
+//   - Ready:     how long and how often to probe a booted VM for SSH
+//     readiness.
+//   - Inventory: where Ansible gets the inventory of the development hosts.
+//   - Machines:  the development hosts generated by "initialize".
+type Config struct {
+	GitHub    GitHub    `yaml:"github"`
+	Galaxy    Galaxy    `yaml:"galaxy"`
+	Paths     Paths     `yaml:"paths"`
+	Compare   Compare   `yaml:"compare"`
+	Backend   string    `yaml:"backend"`
+	Container Container `yaml:"container"`
+	VM        VM        `yaml:"vm"`
+	Ready     Ready     `yaml:"ready"`
+	Inventory Inventory `yaml:"inventory"`
+	Machines  []Machine `yaml:"machines"`
+}
+
+// GitHub holds the settings used to create repositories for published roles
+// and runbooks. A role named "nginx" is published as
+// "<Owner>/<RolePrefix>nginx".
+type GitHub struct {
+	Owner         string `yaml:"owner"`
+	RolePrefix    string `yaml:"role_prefix"`
+	RunbookPrefix string `yaml:"runbook_prefix"`
+}
+
+// Galaxy holds Ansible Galaxy settings. Namespace is the prefix stripped
+// from installed role names (e.g. "dcjulian29.nginx") to find their source
+// repository. Server is the URL of the Galaxy server "outdated" asks for
+// the latest versions of roles and collections that do not name a server
+// of their own.
+type Galaxy struct {
+	Namespace string `yaml:"namespace"`
+	Server    string `yaml:"server"`
+}
+
+// Paths holds the directories containing the role and runbook source
+// repositories. They default to the ANSIBLE_ROLES and ANSIBLE_RUNBOOKS
+// environment variables.
+type Paths struct {
+	Roles    string `yaml:"roles"`
+	Runbooks string `yaml:"runbooks"`
+}
+
+// Compare holds the ignore lists and WinMerge file filter names used by the
+// compare commands.
+type Compare struct {
+	RoleIgnore    []string `yaml:"role_ignore"`
+	RoleFilter    string   `yaml:"role_filter"`
+	RunbookIgnore []string `yaml:"runbook_ignore"`
+	RunbookFilter string   `yaml:"runbook_filter"`
+}
+
+// Container holds the settings of the "docker" and "podman" backends.
+//
+// Fields:
+//   - Image:      the default image for machines without their own image.
+//     It must run systemd as its init process.
+//   - Connection: how Ansible reaches the containers: "docker" (the
+//     community.docker.docker or containers.podman.podman connection
+//     plugin) or "ssh" (the image must run sshd).
+type Container struct {
+	Image      string `yaml:"image"`
+	Connection string `yaml:"connection"`
+}
+
+// VM holds the resources given to every development VM.
+type VM struct {
+	CPUs   int `yaml:"cpus"`
+	Memory int `yaml:"memory"`
+}
+
+// Ready controls how a VM is judged ready after "vagrant up".
+//
+// Fields:
+//   - Timeout:  seconds to wait for the SSH port to present an SSH banner
+//     (and for Command to succeed) before giving up.
+//   - Interval: seconds between probes.
+//   - Command:  optional command run on the VM with "vagrant ssh -c" until
+//     it succeeds, e.g. "cloud-init status --wait" or
+//     "test -f /var/lib/cloud/instance/boot-finished".
+type Ready struct {
+	Timeout  int    `yaml:"timeout"`
+	Interval int    `yaml:"interval"`
+	Command  string `yaml:"command"`
+}
+
+// Inventory controls where Ansible gets the inventory of the development
+// hosts.
+//
+// Fields:
+//   - Dynamic: when true, ansible.cfg points at an inventory script that
+//     runs "ansible-dev inventory --list", which builds the inventory from
+//     the machines and their groups and reads the addresses from the
+//     backend, instead of at hosts.ini. hosts.ini is then no longer
+//     rewritten when a host starts, so the inventory structure can be
+//     committed with the project.
+type Inventory struct {
+	Dynamic bool `yaml:"dynamic"`
+}
+
+// Machine describes a single development VM. The Vagrantfile, hosts.ini and
+// host_vars/<name>.yml are all generated from the list of machines.
+//
+// Fields:
+//   - Name:     the Vagrant machine name and inventory host name.
+//   - Box:      the Vagrant box the machine is created from.
+//   - Image:    optional container image overriding "container.image"
+//     when a container backend is used.
+//   - CPUs:     optional CPU count overriding "vm.cpus".
+//   - Memory:   optional memory size in MB overriding "vm.memory".
+//   - Groups:   optional inventory groups the host belongs to in addition
+//     to [vagrant].
+//   - Networks: optional extra networks attached to the machine.
+type Machine struct {
+	Name     string    `yaml:"name"`
+	Box      string    `yaml:"box"`
+	Image    string    `yaml:"image,omitempty"`
+	CPUs     int       `yaml:"cpus,omitempty"`
+	Memory   int       `yaml:"memory,omitempty"`
+	Groups   []string  `yaml:"groups,omitempty"`
+	Networks []Network `yaml:"networks,omitempty"`
+}
+
+// Network describes an extra Vagrant network attached to a [Machine].
+//
+// Fields:
+//   - Type:   the Vagrant network type (see [NetworkTypes]).
+//   - IP:     optional static address of a private or public network.
+//   - Bridge: optional host interface to bridge a public network to.
+//   - Guest:  the guest port of a forwarded port.
+//   - Host:   the host port of a forwarded port.
+type Network struct {
+	Type   string `yaml:"type"`
+	IP     string `yaml:"ip,omitempty"`
+	Bridge string `yaml:"bridge,omitempty"`
+	Guest  int    `yaml:"guest,omitempty"`
+	Host   int    `yaml:"host,omitempty"`
+}
+
+// FindMachine returns the index of the machine named name, or -1.
+func FindMachine(machines []Machine, name string) int {
+	for i, m := range machines {
+		if m.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Default returns the built-in configuration, matching the values
+// ansible-dev used before it was configurable.
+func Default() Config {
+	return Config{
+		GitHub: GitHub{
+			Owner:         "dcjulian29",
+			RolePrefix:    "ansible-role-",
+			RunbookPrefix: "ansible-runbook-",
+		},
+		Galaxy: Galaxy{
+			Namespace: "dcjulian29",
+			Server:    "https://galaxy.ansible.com",
+		},
+		Compare: Compare{
+			RoleIgnore:    []string{"\\.git", "\\.github", ".galaxy_install_info", ".ansible"},
+			RoleFilter:    "AnsibleRoles",
+			RunbookFilter: "AnsibleRunbooks",
+			RunbookIgnore: []string{
+				".ansible-lint",
+				".editorconfig",
+				".gitattributes",
+				".gitignore",
+				".yamllint",
+				"build.cmd",
+				"FILES.json",
+				"galaxy.yml",
+				"MANIFEST.json",
+				"README.md",
+				".tar.gz",
+				".devcontainer",
+				".git",
+				".github",
+				".vscode",
+			},
+		},
+		Backend: "vagrant",
+		Container: Container{
+			Image:      "docker.io/geerlingguy/docker-debian12-ansible:latest",
+			Connection: "docker",
+		},
+		VM: VM{
+			CPUs:   2,
+			Memory: 4096,
+		},
+		Ready: Ready{
+			Timeout:  300,
+			Interval: 5,
+		},
+		Machines: []Machine{
+			{Name: "debian", Box: "dcjulian29/debian-13"},
+			{Name: "alma", Box: "dcjulian29/almalinux-10"},
+		},
+	}
+}
@@ -0,0 +1,38 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config loads the ansible-dev configuration: the values that used
+// to be hard-coded in the commands (VM definitions, GitHub owner and
+// repository prefixes, the role and runbook source directories, and the
+// compare ignore lists).
+//
+// The effective configuration is built from the following layers, each
+// overriding the one before it:
+//
+//  1. built-in defaults ([Default]).
+//  2. the user configuration file, ~/.config/ansible-dev/config.yml
+//     ($XDG_CONFIG_HOME is honored when set).
+//  3. the project configuration file, .ansible-dev.yml in the current
+//     directory.
+//  4. environment variables: ANSIBLE_DEV_<KEY> for every key (dots replaced
+//     by underscores, upper-cased), plus the legacy ANSIBLE_ROLES and
+//     ANSIBLE_RUNBOOKS variables.
+//  5. --set key=value flags given on the command line.
+//
+// Every value is addressed by a dotted key such as "github.owner". The
+// layer that supplied each key is recorded so that "ansible-dev config"
+// can explain where a value came from.
+package config
@@ -0,0 +1,380 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFile is the name of the project configuration file, read from the
+// current working directory.
+const ProjectFile = ".ansible-dev.yml"
+
+// Value is a single effective configuration value together with a
+// description of the layer that supplied it (for example "default",
+// "project (.ansible-dev.yml)" or "env (ANSIBLE_ROLES)").
+type Value struct {
+	Key    string
+	Value  any
+	Source string
+}
+
+var (
+	mu      sync.RWMutex
+	current *Config
+	values  map[string]Value
+)
+
+// legacyEnv maps the environment variables ansible-dev has always honored
+// onto their configuration keys.
+var legacyEnv = map[string]string{
+	"ANSIBLE_ROLES":    "paths.roles",
+	"ANSIBLE_RUNBOOKS": "paths.runbooks",
+}
+
+// Load builds the effective configuration from every layer and makes it
+// available through [Current] and [Values]. The overrides are "key=value"
+// strings taken from the --set flag. It is called once by the root command
+// before any subcommand runs.
+//
+// An error is returned if a configuration file cannot be read or parsed,
+// names an unknown key, or an override is malformed, and if a value does
+// not have the type of its key; that error names the key and the layer
+// that set it, e.g. "project (.ansible-dev.yml): invalid value '4G' for
+// 'vm.memory': expected an integer".
+func Load(overrides []string) error {
+	merged, err := flatten(Default(), "default")
+	if err != nil {
+		return err
+	}
+
+	known := map[string]bool{}
+	for k := range merged {
+		known[k] = true
+	}
+
+	if user, err := UserFile(); err == nil {
+		if err := mergeFile(merged, known, user, "user"); err != nil {
+			return err
+		}
+	}
+
+	if err := mergeFile(merged, known, ProjectFile, "project"); err != nil {
+		return err
+	}
+
+	for name, key := range legacyEnv {
+		if v, ok := os.LookupEnv(name); ok && len(v) > 0 {
+			merged[key] = Value{Key: key, Value: v, Source: fmt.Sprintf("env (%s)", name)}
+		}
+	}
+
+	for key := range known {
+		name := EnvName(key)
+		if v, ok := os.LookupEnv(name); ok {
+			parsed, err := ParseValue(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+
+			merged[key] = Value{Key: key, Value: parsed, Source: fmt.Sprintf("env (%s)", name)}
+		}
+	}
+
+	for _, o := range overrides {
+		key, raw, ok := strings.Cut(o, "=")
+		if !ok {
+			return fmt.Errorf("invalid --set value '%s', expected key=value", o)
+		}
+
+		if !known[key] {
+			return fmt.Errorf("unknown configuration key '%s'", key)
+		}
+
+		parsed, err := ParseValue(raw)
+		if err != nil {
+			return fmt.Errorf("--set %s: %w", key, err)
+		}
+
+		merged[key] = Value{Key: key, Value: parsed, Source: "flag (--set)"}
+	}
+
+	cfg, err := decode(merged)
+	if err != nil {
+		return invalid(merged, err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = &cfg
+	values = merged
+
+	return nil
+}
+
+// Current returns the effective configuration. If [Load] has not been
+// called the built-in defaults are returned.
+func Current() Config {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if current == nil {
+		return Default()
+	}
+
+	return *current
+}
+
+// Values returns every effective configuration value sorted by key.
+func Values() []Value {
+	mu.RLock()
+	m := values
+	mu.RUnlock()
+
+	if m == nil {
+		m, _ = flatten(Default(), "default")
+	}
+
+	list := make([]Value, 0, len(m))
+	for _, v := range m {
+		list = append(list, v)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+
+	return list
+}
+
+// Lookup returns the effective value for key.
+func Lookup(key string) (Value, bool) {
+	for _, v := range Values() {
+		if v.Key == key {
+			return v, true
+		}
+	}
+
+	return Value{}, false
+}
+
+// UserFile returns the path of the user configuration file,
+// $XDG_CONFIG_HOME/ansible-dev/config.yml or ~/.config/ansible-dev/config.yml.
+func UserFile() (string, error) {
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); len(xdg) > 0 {
+		return filepath.Join(xdg, "ansible-dev", "config.yml"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".config", "ansible-dev", "config.yml"), nil
+}
+
+// EnvName returns the environment variable that overrides key, for example
+// ANSIBLE_DEV_GITHUB_OWNER for "github.owner".
+func EnvName(key string) string {
+	return "ANSIBLE_DEV_" + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// ParseValue interprets raw as a YAML value so that numbers and lists given
+// on the command line or in the environment keep their type.
+func ParseValue(raw string) (any, error) {
+	var v any
+
+	if err := yaml.Unmarshal([]byte(raw), &v); err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		return raw, nil
+	}
+
+	return v, nil
+}
+
+// FormatValue renders a configuration value on a single line.
+func FormatValue(v any) string {
+	switch v.(type) {
+	case []any, map[string]any:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Sprint(v)
+		}
+
+		setFlow(&node)
+
+		data, _ = yaml.Marshal(&node)
+
+		return strings.TrimSpace(string(data))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func setFlow(n *yaml.Node) {
+	n.Style |= yaml.FlowStyle
+
+	for _, c := range n.Content {
+		setFlow(c)
+	}
+}
+
+func mergeFile(merged map[string]Value, known map[string]bool, path, layer string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	var raw map[string]any
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	source := fmt.Sprintf("%s (%s)", layer, path)
+
+	for key, v := range flattenMap(raw, "") {
+		if !known[key] {
+			return fmt.Errorf("%s: unknown configuration key '%s'", path, key)
+		}
+
+		merged[key] = Value{Key: key, Value: v, Source: source}
+	}
+
+	return nil
+}
+
+func flatten(cfg Config, source string) (map[string]Value, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	result := map[string]Value{}
+
+	for key, v := range flattenMap(raw, "") {
+		result[key] = Value{Key: key, Value: v, Source: source}
+	}
+
+	return result, nil
+}
+
+func flattenMap(m map[string]any, prefix string) map[string]any {
+	result := map[string]any{}
+
+	for k, v := range m {
+		key := k
+		if len(prefix) > 0 {
+			key = prefix + "." + k
+		}
+
+		if child, ok := v.(map[string]any); ok {
+			for ck, cv := range flattenMap(child, key) {
+				result[ck] = cv
+			}
+
+			continue
+		}
+
+		result[key] = v
+	}
+
+	return result
+}
+
+// invalid returns the error of the first value of merged, by key, that
+// does not have the type of its key, prefixed with where it was set, or
+// err when every value has the right type on its own.
+func invalid(merged map[string]Value, err error) error {
+	keys := make([]string, 0, len(merged))
+	for key := range merged {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if v := merged[key]; v.Source != "default" {
+			if e := check(key, v.Value); e != nil {
+				return fmt.Errorf("%s: %w", v.Source, e)
+			}
+		}
+	}
+
+	return err
+}
+
+func decode(merged map[string]Value) (Config, error) {
+	nested := map[string]any{}
+
+	for key, v := range merged {
+		setNested(nested, key, v.Value)
+	}
+
+	var cfg Config
+
+	data, err := yaml.Marshal(nested)
+	if err != nil {
+		return cfg, err
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+
+	return cfg, nil
+}
+
+func setNested(m map[string]any, key string, v any) {
+	parts := strings.Split(key, ".")
+
+	for _, p := range parts[:len(parts)-1] {
+		child, ok := m[p].(map[string]any)
+		if !ok {
+			child = map[string]any{}
+			m[p] = child
+		}
+
+		m = child
+	}
+
+	m[parts[len(parts)-1]] = v
+}
@@ -0,0 +1,111 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// NetworkTypes are the Vagrant network types a [Network] may have.
+var NetworkTypes = []string{"private_network", "public_network", "forwarded_port"}
+
+// ParseNetwork parses the "--network" value of "vm add":
+// "private_network[:<ip>]", "public_network[:<ip>]" or
+// "forwarded_port:<guest>:<host>".
+//
+// An error is returned if the value does not describe a valid network (see
+// [Network.Validate]).
+func ParseNetwork(value string) (Network, error) {
+	kind, rest, _ := strings.Cut(value, ":")
+	n := Network{Type: kind}
+
+	if kind == "forwarded_port" {
+		guest, host, ok := strings.Cut(rest, ":")
+		if !ok {
+			return Network{}, fmt.Errorf("invalid network '%s': expected forwarded_port:<guest>:<host>", value)
+		}
+
+		var err error
+
+		if n.Guest, err = strconv.Atoi(guest); err != nil {
+			return Network{}, fmt.Errorf("invalid network '%s': guest port '%s' is not a number", value, guest)
+		}
+
+		if n.Host, err = strconv.Atoi(host); err != nil {
+			return Network{}, fmt.Errorf("invalid network '%s': host port '%s' is not a number", value, host)
+		}
+	} else {
+		n.IP = rest
+	}
+
+	if err := n.Validate(); err != nil {
+		return Network{}, fmt.Errorf("invalid network '%s': %w", value, err)
+	}
+
+	return n, nil
+}
+
+// Validate reports whether n can be rendered as a "config.vm.network"
+// line: its type is one of [NetworkTypes], its IP, if any, is an IPv4 or
+// IPv6 address, and a forwarded port has guest and host ports between 1
+// and 65535 but no IP or bridge.
+func (n Network) Validate() error {
+	if !slices.Contains(NetworkTypes, n.Type) {
+		return fmt.Errorf("unknown network type '%s', expected one of %s", n.Type, strings.Join(NetworkTypes, ", "))
+	}
+
+	if len(n.IP) > 0 && net.ParseIP(n.IP) == nil {
+		return fmt.Errorf("'%s' is not an IP address", n.IP)
+	}
+
+	if n.Type != "forwarded_port" {
+		if n.Guest != 0 || n.Host != 0 {
+			return errors.New("ports are only valid for forwarded_port networks")
+		}
+
+		return nil
+	}
+
+	if len(n.IP) > 0 || len(n.Bridge) > 0 {
+		return errors.New("a forwarded_port network has no IP or bridge")
+	}
+
+	for _, port := range []int{n.Guest, n.Host} {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d is not between 1 and 65535", port)
+		}
+	}
+
+	return nil
+}
+
+// String returns n in the form accepted by [ParseNetwork].
+func (n Network) String() string {
+	switch {
+	case n.Type == "forwarded_port":
+		return fmt.Sprintf("%s:%d:%d", n.Type, n.Guest, n.Host)
+	case len(n.IP) > 0:
+		return n.Type + ":" + n.IP
+	default:
+		return n.Type
+	}
+}
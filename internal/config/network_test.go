@@ -0,0 +1,92 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseNetwork(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Network
+		wantErr string
+	}{
+		{value: "private_network", want: Network{Type: "private_network"}},
+		{value: "private_network:192.168.57.10", want: Network{Type: "private_network", IP: "192.168.57.10"}},
+		{value: "public_network:fd00::10", want: Network{Type: "public_network", IP: "fd00::10"}},
+		{value: "forwarded_port:80:8080", want: Network{Type: "forwarded_port", Guest: 80, Host: 8080}},
+		{value: "foo:1.2.3.4", wantErr: "unknown network type 'foo'"},
+		{value: "", wantErr: "unknown network type ''"},
+		{value: "private_network:192.168.57", wantErr: "'192.168.57' is not an IP address"},
+		{value: `private_network:1.2.3.4", bridge: "x`, wantErr: "is not an IP address"},
+		{value: "forwarded_port:80", wantErr: "expected forwarded_port:<guest>:<host>"},
+		{value: "forwarded_port:http:8080", wantErr: "guest port 'http' is not a number"},
+		{value: "forwarded_port:80:x", wantErr: "host port 'x' is not a number"},
+		{value: "forwarded_port:0:8080", wantErr: "port 0 is not between 1 and 65535"},
+		{value: "forwarded_port:80:65536", wantErr: "port 65536 is not between 1 and 65535"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseNetwork(tt.value)
+
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ParseNetwork() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseNetwork() error = %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseNetwork() = %+v, want %+v", got, tt.want)
+			}
+
+			if got.String() != tt.value {
+				t.Errorf("String() = %q, want %q", got.String(), tt.value)
+			}
+		})
+	}
+}
+
+func TestNetworkValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		network Network
+		wantErr bool
+	}{
+		{"bridged public network", Network{Type: "public_network", Bridge: "eth0"}, false},
+		{"ports on a private network", Network{Type: "private_network", Guest: 80, Host: 8080}, true},
+		{"forwarded port with an IP", Network{Type: "forwarded_port", IP: "10.0.0.1", Guest: 80, Host: 8080}, true},
+		{"forwarded port with a bridge", Network{Type: "forwarded_port", Bridge: "eth0", Guest: 80, Host: 8080}, true},
+		{"forwarded port without ports", Network{Type: "forwarded_port"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.network.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// SetValue stores key with the given raw value in the configuration file at
+// path, creating the file if needed. The raw value is interpreted with
+// [ParseValue] and stored with [Store].
+//
+// An error is returned if key is not a known configuration key, the value
+// does not have the type of key, the file cannot be parsed, or the write
+// fails.
+func SetValue(path, key, raw string) error {
+	v, err := ParseValue(raw)
+	if err != nil {
+		return err
+	}
+
+	return Store(path, key, v)
+}
+
+// Store stores key with the value v in the configuration file at path,
+// creating the file if needed. v may be any value that marshals to YAML,
+// such as a []Machine for the "machines" key. Only the node of key is
+// replaced, so the other keys, their order and the comments of the file
+// are kept.
+//
+// An error is returned if key is not a known configuration key, v does not
+// have the type of key (e.g. "4G" for the integer "vm.memory"), the file
+// cannot be parsed, or the write fails.
+func Store(path, key string, v any) error {
+	if err := check(key, v); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	if len(doc.Content) == 0 {
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}}
+	}
+
+	value := &yaml.Node{}
+	if err := value.Encode(v); err != nil {
+		return err
+	}
+
+	if err := setNode(doc.Content[0], strings.Split(key, "."), value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	var buf bytes.Buffer
+
+	if bytes.HasPrefix(data, []byte("---")) || len(bytes.TrimSpace(data)) == 0 {
+		buf.WriteString("---\n")
+	}
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(&doc); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return runner.WriteFile(path, buf.Bytes())
+}
+
+// check reports whether v can be stored as key by decoding the built-in
+// configuration with v in place of the value of key. The error names the
+// key and the type it expects rather than a line of the decoded document.
+func check(key string, v any) error {
+	merged, err := flatten(Default(), "default")
+	if err != nil {
+		return err
+	}
+
+	current, ok := merged[key]
+	if !ok {
+		return fmt.Errorf("unknown configuration key '%s'", key)
+	}
+
+	merged[key] = Value{Key: key, Value: v}
+
+	if _, err := decode(merged); err != nil {
+		return fmt.Errorf("invalid value '%s' for '%s': expected %s", FormatValue(v), key, typeName(current.Value))
+	}
+
+	return nil
+}
+
+// typeName describes the type of a configuration value for error messages.
+func typeName(v any) string {
+	switch v.(type) {
+	case int:
+		return "an integer"
+	case bool:
+		return "true or false"
+	case []any:
+		return "a list"
+	case map[string]any:
+		return "a mapping"
+	default:
+		return "a string"
+	}
+}
+
+// setNode replaces the value of the dotted key path in the mapping node,
+// adding the missing keys. The comments of a replaced value are moved to
+// its replacement.
+func setNode(mapping *yaml.Node, path []string, value *yaml.Node) error {
+	if mapping.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", mapping.Line)
+	}
+
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != path[0] {
+			continue
+		}
+
+		current := mapping.Content[i+1]
+
+		if len(path) > 1 {
+			return setNode(current, path[1:], value)
+		}
+
+		value.HeadComment = current.HeadComment
+		value.LineComment = current.LineComment
+		value.FootComment = current.FootComment
+		mapping.Content[i+1] = value
+
+		return nil
+	}
+
+	for _, p := range path[:len(path)-1] {
+		child := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: p}, child)
+		mapping = child
+	}
+
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: path[len(path)-1]}, value)
+
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		key      string
+		raw      string
+		want     string
+		err      string
+	}{
+		{
+			name: "new file",
+			key:  "vm.memory",
+			raw:  "8192",
+			want: "---\nvm:\n  memory: 8192\n",
+		},
+		{
+			name: "comments and order kept",
+			existing: "---\n# Lab settings.\nbackend: docker # containers\nvm:\n  cpus: 4 # more cores\n" +
+				"  memory: 2048 # too small\n",
+			key: "vm.memory",
+			raw: "8192",
+			want: "---\n# Lab settings.\nbackend: docker # containers\nvm:\n  cpus: 4 # more cores\n" +
+				"  memory: 8192 # too small\n",
+		},
+		{
+			name:     "missing parent added",
+			existing: "backend: docker\n",
+			key:      "ready.command",
+			raw:      "cloud-init status --wait",
+			want:     "backend: docker\nready:\n  command: cloud-init status --wait\n",
+		},
+		{
+			name: "list",
+			key:  "compare.role_ignore",
+			raw:  "[.git, .github]",
+			want: "---\ncompare:\n  role_ignore:\n    - .git\n    - .github\n",
+		},
+		{
+			name: "integer expected",
+			key:  "vm.memory",
+			raw:  "4G",
+			err:  "invalid value '4G' for 'vm.memory': expected an integer",
+		},
+		{
+			name: "boolean expected",
+			key:  "inventory.dynamic",
+			raw:  "sometimes",
+			err:  "invalid value 'sometimes' for 'inventory.dynamic': expected true or false",
+		},
+		{
+			name: "list expected",
+			key:  "machines",
+			raw:  "debian",
+			err:  "invalid value 'debian' for 'machines': expected a list",
+		},
+		{
+			name: "unknown key",
+			key:  "vm.disk",
+			raw:  "20",
+			err:  "unknown configuration key 'vm.disk'",
+		},
+		{
+			name:     "parent is not a mapping",
+			existing: "vm: 4\n",
+			key:      "vm.memory",
+			raw:      "8192",
+			err:      "line 1: expected a mapping",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yml")
+
+			if len(tt.existing) > 0 {
+				if err := os.WriteFile(path, []byte(tt.existing), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := SetValue(path, tt.key, tt.raw)
+			if len(tt.err) > 0 {
+				if err == nil || err.Error() != tt.err && err.Error() != path+": "+tt.err {
+					t.Fatalf("SetValue() error = %v, want %q", err, tt.err)
+				}
+
+				if data, _ := os.ReadFile(path); string(data) != tt.existing {
+					t.Errorf("file changed to %q after an error", data)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("SetValue() error = %v", err)
+			}
+
+			if got, _ := os.ReadFile(path); string(got) != tt.want {
+				t.Errorf("file =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadInvalidValue(t *testing.T) {
+	t.Chdir(t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Cleanup(func() { _ = Load(nil) })
+
+	if err := os.WriteFile(ProjectFile, []byte("vm:\n  memory: 4G\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "project (" + ProjectFile + "): invalid value '4G' for 'vm.memory': expected an integer"
+
+	if err := Load(nil); err == nil || err.Error() != want {
+		t.Errorf("Load() error = %v, want %q", err, want)
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// connectionPlugins maps each runtime to the Ansible connection plugin
+// that executes modules inside its containers.
+var connectionPlugins = map[string]string{
+	"docker": "community.docker.docker",
+	"podman": "containers.podman.podman",
+}
+
+// Connection returns the Ansible connection variables for the container of
+// a machine, in the order they are written to hosts.ini.
+//
+// With the "docker" connection ansible_host is the container name and
+// ansible_connection the runtime's connection plugin. With the "ssh"
+// connection ansible_host is the container's IP address, read with
+// "<runtime> inspect", and ansible_port is 22.
+func Connection(runtime, connection, machine string) ([][2]string, error) {
+	name := Name(machine)
+
+	if connection != "ssh" {
+		return [][2]string{
+			{"ansible_host", name},
+			{"ansible_connection", connectionPlugins[runtime]},
+		}, nil
+	}
+
+	out, err := runner.Capture(runtime, "inspect", "--format",
+		"{{range .NetworkSettings.Networks}}{{.IPAddress}} {{end}}", name)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := strings.Fields(out)
+	if len(addresses) == 0 {
+		return nil, fmt.Errorf("no IP address found for container '%s'", name)
+	}
+
+	return [][2]string{
+		{"ansible_host", addresses[0]},
+		{"ansible_port", "22"},
+		{"ansible_connection", "ssh"},
+	}, nil
+}
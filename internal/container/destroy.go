@@ -0,0 +1,61 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// Destroy force-removes the containers of the named machines. When no
+// machine is given every container of the current project (see [Status])
+// is removed together with the "ansible.log" file and the ".tmp" directory,
+// mirroring the cleanup of a full Vagrant destroy.
+func Destroy(runtime string, machines ...string) error {
+	all := len(machines) == 0
+
+	if all {
+		statuses, err := Status(runtime)
+		if err != nil {
+			return err
+		}
+
+		for _, s := range statuses {
+			machines = append(machines, s.Name)
+		}
+	}
+
+	if len(machines) > 0 {
+		param := []string{"rm", "--force"}
+		for _, machine := range machines {
+			param = append(param, Name(machine))
+		}
+
+		if err := runner.Run(runtime, param...); err != nil {
+			return err
+		}
+	}
+
+	if !all {
+		return nil
+	}
+
+	if err := runner.Remove("ansible.log"); err != nil {
+		return err
+	}
+
+	return runner.Remove(".tmp")
+}
@@ -0,0 +1,26 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package container provides helper functions for managing systemd-capable
+// Docker or Podman containers used as lightweight targets during Ansible
+// development and testing, as an alternative to the Vagrant VMs managed by
+// the vagrant package. Operations include creating, stopping, destroying,
+// and inspecting the containers of the current project.
+//
+// Every function takes the container runtime ("docker" or "podman") as its
+// first argument; both accept the same command-line syntax for the
+// operations used here.
+package container
@@ -0,0 +1,38 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// Down stops the containers of the named machines with "<runtime> stop".
+// Execution stops at the first failure.
+func Down(runtime string, machines ...string) error {
+	for _, machine := range machines {
+		fmt.Printf(textformat.Yellow("\nStopping '%s'...\n\n"), machine)
+
+		if err := runner.Run(runtime, "stop", Name(machine)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
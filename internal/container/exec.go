@@ -0,0 +1,43 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// Exec opens an interactive login shell in the container of a machine, or
+// runs command there with "sh -c" when command is not empty. The shell is
+// bash when the image has it and sh otherwise. When become is true the
+// process runs as root regardless of the image's default user.
+func Exec(runtime, machine, command string, become bool) error {
+	param := []string{"exec", "--interactive", "--tty"}
+
+	if become {
+		param = append(param, "--user", "root")
+	}
+
+	param = append(param, Name(machine), "sh", "-c")
+
+	if len(command) > 0 {
+		param = append(param, command)
+	} else {
+		param = append(param, "command -v bash >/dev/null && exec bash -l || exec sh -l")
+	}
+
+	return runner.Run(runtime, param...)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ProjectLabel is the label identifying the project directory a container
+// belongs to, so that labs in different directories do not interfere.
+const ProjectLabel = "io.github.dcjulian29.ansible-dev.project"
+
+// invalidName matches the characters not allowed in a container name.
+var invalidName = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// Project returns the absolute path of the current directory, which is the
+// value of [ProjectLabel] for the containers of this project.
+func Project() string {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "."
+	}
+
+	return dir
+}
+
+// Name returns the container name of a machine: the project directory name
+// and the machine name joined with "-", e.g. "my-lab-debian".
+func Name(machine string) string {
+	project := strings.Trim(invalidName.ReplaceAllString(filepath.Base(Project()), "-"), "-._")
+	if len(project) == 0 {
+		project = "ansible-dev"
+	}
+
+	return project + "-" + machine
+}
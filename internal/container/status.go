@@ -0,0 +1,63 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// MachineStatus is the state of the container of a single machine.
+//
+// Fields:
+//   - Name:  the machine name.
+//   - State: the container state (e.g. "running", "exited", "created").
+type MachineStatus struct {
+	Name  string
+	State string
+}
+
+// Status lists the containers labeled with the current [Project] and
+// returns the state of each, keyed by machine name. An error is returned if
+// the runtime cannot list containers.
+func Status(runtime string) ([]MachineStatus, error) {
+	out, err := runner.Capture(runtime, "ps", "--all",
+		"--filter", "label="+ProjectLabel+"="+Project(),
+		"--format", "{{.Names}}\t{{.State}}")
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := Name("")
+
+	var machines []MachineStatus
+
+	for _, line := range strings.Split(out, "\n") {
+		name, state, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok {
+			continue
+		}
+
+		machines = append(machines, MachineStatus{
+			Name:  strings.TrimPrefix(name, prefix),
+			State: strings.ToLower(state),
+		})
+	}
+
+	return machines, nil
+}
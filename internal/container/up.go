@@ -0,0 +1,123 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// Up creates and starts the container of a machine from image, or starts
+// it again if it already exists but is stopped. A running container is left
+// as it is.
+//
+// New containers run systemd as their init process: Docker containers are
+// privileged and share the host cgroup namespace, Podman containers use
+// "--systemd=always". The container host name is the machine name and it
+// is labeled with [ProjectLabel].
+//
+// When ready.command is set it is run in the container with
+// "<runtime> exec <name> sh -c" until it succeeds or ready.timeout expires,
+// so that provisioning does not race first-boot services.
+//
+// When the global --dry-run flag is set only the commands are printed and
+// the readiness check is skipped.
+func Up(runtime, machine, image string, ready config.Ready) error {
+	name := Name(machine)
+
+	fmt.Printf(textformat.Yellow("\nBringing '%s' online...\n\n"), machine)
+
+	state, err := runner.Capture(runtime, "container", "inspect", "--format", "{{.State.Status}}", name)
+
+	switch {
+	case err == nil && strings.TrimSpace(state) == "running":
+		fmt.Printf("container '%s' is already running\n", name)
+	case err == nil && len(strings.TrimSpace(state)) > 0:
+		if err := runner.Run(runtime, "start", name); err != nil {
+			return err
+		}
+	default:
+		if err := runner.Run(runtime, runArgs(runtime, machine, image)...); err != nil {
+			return err
+		}
+	}
+
+	if runner.IsDryRun() || len(ready.Command) == 0 {
+		return nil
+	}
+
+	return waitReady(runtime, machine, ready)
+}
+
+// runArgs returns the arguments of the "run" command creating the
+// container of machine.
+func runArgs(runtime, machine, image string) []string {
+	args := []string{
+		"run", "--detach",
+		"--name", Name(machine),
+		"--hostname", machine,
+		"--label", ProjectLabel + "=" + Project(),
+		"--tmpfs", "/run",
+		"--tmpfs", "/tmp",
+	}
+
+	if runtime == "podman" {
+		args = append(args, "--systemd=always")
+	} else {
+		args = append(args,
+			"--privileged",
+			"--cgroupns=host",
+			"--volume", "/sys/fs/cgroup:/sys/fs/cgroup:rw")
+	}
+
+	return append(args, image)
+}
+
+// waitReady runs ready.command in the container until it succeeds.
+func waitReady(runtime, machine string, ready config.Ready) error {
+	timeout := time.Duration(max(ready.Timeout, 1)) * time.Second
+	interval := time.Duration(max(ready.Interval, 1)) * time.Second
+	deadline := time.Now().Add(timeout)
+
+	fmt.Printf(textformat.Yellow("\nWaiting for '%s' to finish booting..."), machine)
+
+	for {
+		out, err := runner.Capture(runtime, "exec", Name(machine), "sh", "-c", ready.Command)
+		if err == nil {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			fmt.Println(textformat.Red(" [NotReady]"))
+
+			return fmt.Errorf("'%s' did not finish booting after %s: '%s' failed: %v\n%s",
+				machine, timeout, ready.Command, err, strings.TrimSpace(out))
+		}
+
+		fmt.Print(".")
+		time.Sleep(interval)
+	}
+
+	fmt.Println(textformat.Green(" [Ready]"))
+
+	return nil
+}
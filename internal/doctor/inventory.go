@@ -0,0 +1,293 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/vagrant"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// InventoryReport compares the machines of the backend with the hosts of
+// the [vagrant] group of hosts.ini and the host_vars/ files.
+//
+// Fields:
+//   - Machines:             the machine names reported by the backend.
+//   - MissingFromInventory: machines that are not hosts of the [vagrant]
+//     group; "ansible-dev start" would never start them.
+//   - NotMachines:          hosts of the [vagrant] group that are not
+//     machines; starting them fails.
+//   - MissingHostVars:      machines without a host_vars/<name>.yml file.
+//   - ExtraHostVars:        host_vars files of hosts that are not in the
+//     inventory at all. They are only a warning, since they may hold work
+//     worth keeping, and are never removed.
+type InventoryReport struct {
+	Machines             []string `json:"machines"`
+	MissingFromInventory []string `json:"missing_from_inventory"`
+	NotMachines          []string `json:"not_machines"`
+	MissingHostVars      []string `json:"missing_host_vars"`
+	ExtraHostVars        []string `json:"extra_host_vars"`
+}
+
+// OK reports whether no inconsistency that [FixInventory] repairs was
+// found.
+func (r InventoryReport) OK() bool {
+	return r.Consistent() && len(r.MissingHostVars) == 0
+}
+
+// Consistent reports whether the machines and the [vagrant] group agree,
+// which the lifecycle commands require; the host_vars files are not
+// considered.
+func (r InventoryReport) Consistent() bool {
+	return len(r.MissingFromInventory)+len(r.NotMachines) == 0
+}
+
+// CheckInventory compares the machines of the configured backend with the
+// hosts of the [vagrant] group of hosts.ini (including its child groups)
+// and with the files in host_vars/. In dynamic inventory mode the hosts are
+// the configured machines instead (see [ansible.GetInventory]).
+//
+// With the Vagrant backend the machines are those reported by
+// "vagrant status --machine-readable", or read from the Vagrantfile during
+// a dry run (see [vagrant.MachineNames]). With a container backend they
+// are the configured machines, since only existing containers are reported
+// by the runtime.
+func CheckInventory() (InventoryReport, error) {
+	report := InventoryReport{
+		MissingFromInventory: []string{},
+		NotMachines:          []string{},
+		MissingHostVars:      []string{},
+		ExtraHostVars:        []string{},
+	}
+
+	machines, err := machineNames()
+	if err != nil {
+		return report, err
+	}
+
+	hosts, known, err := inventoryHosts()
+	if err != nil {
+		return report, err
+	}
+
+	report.Machines = machines
+
+	for _, m := range machines {
+		if !slices.Contains(hosts, m) {
+			report.MissingFromInventory = append(report.MissingFromInventory, m)
+		}
+
+		if !hostVarsExist(m) {
+			report.MissingHostVars = append(report.MissingHostVars, m)
+		}
+	}
+
+	for _, h := range hosts {
+		if !slices.Contains(machines, h) {
+			report.NotMachines = append(report.NotMachines, h)
+		}
+	}
+
+	entries, err := os.ReadDir("host_vars")
+	if err != nil && !os.IsNotExist(err) {
+		return report, err
+	}
+
+	for _, e := range entries {
+		name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(e.Name(), ".yml"), ".yaml"), ".json")
+
+		if strings.HasPrefix(name, ".") || slices.Contains(known, name) || slices.Contains(machines, name) {
+			continue
+		}
+
+		report.ExtraHostVars = append(report.ExtraHostVars, filepath.Join("host_vars", e.Name()))
+	}
+
+	return report, nil
+}
+
+// inventoryHosts returns the hosts of the [vagrant] group, including its
+// child groups, and every host of the inventory. In dynamic inventory mode
+// both are the configured machines (see [ansible.GetInventory]); otherwise
+// they are read from hosts.ini.
+func inventoryHosts() ([]string, []string, error) {
+	if config.Current().Inventory.Dynamic {
+		hosts, err := ansible.SelectHosts(nil)
+
+		return hosts, hosts, err
+	}
+
+	inv, err := inventory.Load(inventory.Path())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return inv.GroupHosts(inventory.Managed), inv.HostNames(), nil
+}
+
+// machineNames returns the machines of the configured backend; see
+// [CheckInventory].
+func machineNames() ([]string, error) {
+	cfg := config.Current()
+
+	if cfg.Backend == "docker" || cfg.Backend == "podman" {
+		names := make([]string, 0, len(cfg.Machines))
+		for _, m := range cfg.Machines {
+			names = append(names, m.Name)
+		}
+
+		return names, nil
+	}
+
+	return vagrant.MachineNames()
+}
+
+// hostVarsExist reports whether host has a host_vars file or directory.
+func hostVarsExist(host string) bool {
+	for _, ext := range []string{"", ".yml", ".yaml", ".json"} {
+		if _, err := os.Stat(filepath.Join("host_vars", host+ext)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Print writes the report to w: one line per inconsistency, red for those
+// that break the lifecycle commands and yellow for the others, or a green
+// line when everything agrees.
+func (r InventoryReport) Print(w io.Writer) {
+	if r.OK() && len(r.ExtraHostVars) == 0 {
+		fmt.Fprintln(w, textformat.Green(fmt.Sprintf("The %d machine(s) match the inventory and host_vars.", len(r.Machines))))
+		return
+	}
+
+	for _, m := range r.MissingFromInventory {
+		fmt.Fprintln(w, textformat.Red(fmt.Sprintf("  missing  '%s' is a machine but not a host of the [%s] group", m, inventory.Managed)))
+	}
+
+	for _, h := range r.NotMachines {
+		fmt.Fprintln(w, textformat.Red(fmt.Sprintf("  extra    '%s' is a host of the [%s] group but not a machine", h, inventory.Managed)))
+	}
+
+	for _, m := range r.MissingHostVars {
+		fmt.Fprintln(w, textformat.Yellow(fmt.Sprintf("  missing  host_vars/%s.yml", m)))
+	}
+
+	for _, f := range r.ExtraHostVars {
+		fmt.Fprintln(w, textformat.Yellow(fmt.Sprintf("  extra    %s belongs to no inventory host", f)))
+	}
+}
+
+// FixInventory reconciles the inventory with the machines found by
+// [CheckInventory]: missing machines are added to the [vagrant] group with
+// the 0.0.0.0 placeholder address that is replaced when they boot, hosts
+// that are not machines are removed from the [vagrant] group (and so from
+// the inventory when no other group lists them), and missing host_vars
+// stubs are created. host_vars files that belong to no host are only
+// reported, since they may hold work worth keeping.
+//
+// In dynamic inventory mode the hosts are the configured machines, so an
+// inconsistency means the Vagrantfile is out of date; it is not repaired
+// here and an error pointing at "ansible-dev vm render" is returned.
+func FixInventory(r InventoryReport) error {
+	if !r.Consistent() && config.Current().Inventory.Dynamic {
+		return errors.New("the inventory is dynamic; run 'ansible-dev vm render' to bring the Vagrantfile in line with the configured machines")
+	}
+
+	if !r.Consistent() {
+		inv, err := inventory.Load(inventory.Path())
+		if err != nil {
+			return err
+		}
+
+		changed := len(r.MissingFromInventory) > 0
+
+		for _, h := range r.NotMachines {
+			if g := inv.Group(inventory.Managed); g == nil || !slices.Contains(g.Hosts, h) {
+				// Listed through a child group, which the user owns.
+				fmt.Println(textformat.Yellow(fmt.Sprintf("  ...  '%s' is listed in a child group of [%s]; remove it there", h, inventory.Managed)))
+				continue
+			}
+
+			if err := inv.RemoveHostFromGroup(inventory.Managed, h); err != nil {
+				return err
+			}
+
+			changed = true
+		}
+
+		for _, m := range r.MissingFromInventory {
+			var vars [][2]string
+			if inv.Host(m) == nil {
+				vars = [][2]string{{"ansible_host", "0.0.0.0"}}
+			}
+
+			if err := inv.AddHost(inventory.Managed, m, vars); err != nil {
+				return err
+			}
+		}
+
+		if changed {
+			fmt.Printf("  ...  %s\n", inventory.Path())
+
+			if err := inv.Save(); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, m := range r.MissingHostVars {
+		if _, err := ansible.EnsureHostVars(m); err != nil {
+			return err
+		}
+
+		fmt.Printf("  ...  host_vars/%s.yml\n", m)
+	}
+
+	return nil
+}
+
+// PreCheck is run by the lifecycle commands before they start machines. It
+// returns an error describing the inconsistencies when the machines and
+// the [vagrant] group disagree, since machines missing from the group
+// would silently never be started and hosts that are not machines make
+// the backend fail. Missing or extra host_vars files are not an error.
+func PreCheck() error {
+	report, err := CheckInventory()
+	if err != nil {
+		return err
+	}
+
+	if report.Consistent() {
+		return nil
+	}
+
+	report.Print(os.Stderr)
+
+	return errors.New("the inventory does not match the machines; run 'ansible-dev doctor inventory --fix' to reconcile them")
+}
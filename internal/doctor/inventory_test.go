@@ -0,0 +1,322 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package doctor
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// status is the command listing the Vagrant machines.
+const status = "vagrant status --machine-readable"
+
+// testInventory is the hosts.ini of the development directories used by
+// the tests: debian and alma are managed, rocky through the extra child
+// group, and fedora is only in the web group.
+const testInventory = `[vagrant]
+debian
+alma
+
+[vagrant:children]
+extra
+
+[extra]
+rocky
+
+[web]
+fedora
+`
+
+// machines returns the output of "vagrant status --machine-readable" for
+// the machines names.
+func machines(names ...string) string {
+	var out strings.Builder
+
+	for _, name := range names {
+		out.WriteString("1760000000," + name + ",provider-name,virtualbox\n")
+		out.WriteString("1760000000," + name + ",state,running\n")
+	}
+
+	return out.String()
+}
+
+// lab changes to a new development directory holding an ansible.cfg,
+// testInventory, a Vagrantfile defining debian and alma, host_vars files
+// for the managed hosts and files, loads the configuration with overrides
+// and makes a new [runner.Recorder] reporting the Vagrant machines vms the
+// current runner until the test ends.
+func lab(t *testing.T, vms []string, files map[string]string, overrides ...string) *runner.Recorder {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+
+	all := map[string]string{
+		"ansible.cfg":          "[defaults]\ninventory = hosts.ini\n",
+		"hosts.ini":            testInventory,
+		"Vagrantfile":          "config.vm.define \"debian\" do |c|\nend\nconfig.vm.define \"alma\" do |c|\nend\n",
+		"host_vars/debian.yml": "---\n",
+		"host_vars/alma/vars":  "---\n",
+		"host_vars/rocky.json": "{}\n",
+		"host_vars/fedora.yml": "---\n",
+		"host_vars/.gitkeep":   "",
+	}
+
+	for name, content := range files {
+		all[name] = content
+	}
+
+	for name, content := range all {
+		path := filepath.FromSlash(name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := config.Load(overrides); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = config.Load(nil) })
+
+	previous := runner.Current()
+	t.Cleanup(func() { runner.Set(previous) })
+
+	rec := runner.NewRecorder()
+	rec.Outputs[status] = machines(vms...)
+	runner.Set(rec)
+
+	return rec
+}
+
+func TestCheckInventory(t *testing.T) {
+	tests := []struct {
+		name      string
+		vms       []string
+		files     map[string]string
+		overrides []string
+		dryRun    bool
+		want      InventoryReport
+	}{
+		{
+			name: "consistent",
+			vms:  []string{"debian", "alma", "rocky"},
+			want: InventoryReport{Machines: []string{"debian", "alma", "rocky"}},
+		},
+		{
+			name: "machine missing from the inventory",
+			vms:  []string{"debian", "alma", "rocky", "centos"},
+			want: InventoryReport{
+				Machines:             []string{"debian", "alma", "rocky", "centos"},
+				MissingFromInventory: []string{"centos"},
+				MissingHostVars:      []string{"centos"},
+			},
+		},
+		{
+			name: "hosts that are not machines",
+			vms:  []string{"debian"},
+			want: InventoryReport{
+				Machines:    []string{"debian"},
+				NotMachines: []string{"alma", "rocky"},
+			},
+		},
+		{
+			name: "host_vars without a host",
+			vms:  []string{"debian", "alma", "rocky"},
+			files: map[string]string{
+				"host_vars/old.yml":     "---\n",
+				"host_vars/gone.yaml":   "---\n",
+				"host_vars/centos/vars": "---\n",
+			},
+			want: InventoryReport{
+				Machines: []string{"debian", "alma", "rocky"},
+				ExtraHostVars: []string{
+					filepath.Join("host_vars", "centos"),
+					filepath.Join("host_vars", "gone.yaml"),
+					filepath.Join("host_vars", "old.yml"),
+				},
+			},
+		},
+		{
+			name:   "dry run",
+			vms:    []string{"debian", "alma", "rocky"},
+			dryRun: true,
+			want: InventoryReport{
+				Machines:    []string{"debian", "alma"},
+				NotMachines: []string{"rocky"},
+			},
+		},
+		{
+			name:      "container backend",
+			overrides: []string{"backend=docker"},
+			want: InventoryReport{
+				Machines:    []string{"debian", "alma"},
+				NotMachines: []string{"rocky"},
+			},
+		},
+		{
+			name:      "dynamic inventory",
+			vms:       []string{"debian", "alma", "centos"},
+			overrides: []string{"inventory.dynamic=true"},
+			want: InventoryReport{
+				Machines:             []string{"debian", "alma", "centos"},
+				MissingFromInventory: []string{"centos"},
+				MissingHostVars:      []string{"centos"},
+				ExtraHostVars:        []string{filepath.Join("host_vars", "fedora.yml"), filepath.Join("host_vars", "rocky.json")},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lab(t, tt.vms, tt.files, tt.overrides...)
+
+			if tt.dryRun {
+				runner.Set(runner.NewDryRun(io.Discard))
+			}
+
+			got, err := CheckInventory()
+			if err != nil {
+				t.Fatalf("CheckInventory() error = %v", err)
+			}
+
+			for _, list := range []*[]string{
+				&tt.want.MissingFromInventory, &tt.want.NotMachines, &tt.want.MissingHostVars, &tt.want.ExtraHostVars,
+			} {
+				if *list == nil {
+					*list = []string{}
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("CheckInventory() = %+v, want %+v", got, tt.want)
+			}
+
+			if got.OK() != (len(got.MissingFromInventory)+len(got.NotMachines)+len(got.MissingHostVars) == 0) {
+				t.Errorf("OK() = %v for %+v", got.OK(), got)
+			}
+		})
+	}
+}
+
+func TestFixInventory(t *testing.T) {
+	tests := []struct {
+		name      string
+		vms       []string
+		overrides []string
+		hosts     string
+		hostVars  []string
+		wantErr   bool
+	}{
+		{
+			name: "nothing to fix",
+			vms:  []string{"debian", "alma", "rocky"},
+		},
+		{
+			name:     "add missing machines",
+			vms:      []string{"debian", "alma", "rocky", "centos", "fedora"},
+			hosts:    strings.Replace(testInventory, "alma\n", "alma\ncentos ansible_host=0.0.0.0\nfedora\n", 1),
+			hostVars: []string{"centos"},
+		},
+		{
+			name:  "remove hosts that are not machines",
+			vms:   []string{"debian", "rocky"},
+			hosts: strings.Replace(testInventory, "alma\n", "", 1),
+		},
+		{
+			name: "hosts of child groups are left alone",
+			vms:  []string{"debian", "alma"},
+		},
+		{
+			name:      "dynamic inventory",
+			vms:       []string{"debian", "alma", "centos"},
+			overrides: []string{"inventory.dynamic=true"},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := lab(t, tt.vms, nil, tt.overrides...)
+
+			report, err := CheckInventory()
+			if err != nil {
+				t.Fatalf("CheckInventory() error = %v", err)
+			}
+
+			if err := FixInventory(report); (err != nil) != tt.wantErr {
+				t.Fatalf("FixInventory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got, ok := rec.Files["hosts.ini"]; ok != (tt.hosts != "") || string(got) != tt.hosts {
+				t.Errorf("hosts.ini =\n%s\nwant\n%s", got, tt.hosts)
+			}
+
+			for _, h := range tt.hostVars {
+				if _, ok := rec.Files[filepath.Join("host_vars", h+".yml")]; !ok {
+					t.Errorf("host_vars/%s.yml was not created", h)
+				}
+			}
+
+			want := len(tt.hostVars)
+			if tt.hosts != "" {
+				want++
+			}
+
+			if len(rec.Files) != want {
+				t.Errorf("files written = %d, want %d", len(rec.Files), want)
+			}
+		})
+	}
+}
+
+func TestPreCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		vms     []string
+		files   map[string]string
+		wantErr bool
+	}{
+		{"consistent", []string{"debian", "alma", "rocky"}, nil, false},
+		{"missing host_vars only", []string{"debian", "alma", "rocky"}, map[string]string{"host_vars/old.yml": ""}, false},
+		{"machine missing from the inventory", []string{"debian", "alma", "rocky", "centos"}, nil, true},
+		{"host that is not a machine", []string{"debian", "alma"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lab(t, tt.vms, tt.files)
+
+			if err := PreCheck(); (err != nil) != tt.wantErr {
+				t.Errorf("PreCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
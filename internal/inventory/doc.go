@@ -0,0 +1,29 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package inventory reads and edits Ansible inventory files in both the INI
+// format used by the generated hosts.ini and the YAML format, modelling
+// them as groups with hosts, child groups and variables.
+//
+// Edits are applied to the source document itself — the lines of an INI
+// file or the node tree of a YAML file — rather than re-rendering it from
+// the model, so comments, blank lines, the order of sections and any
+// groups or variables ansible-dev does not manage (e.g. [web] or [db]
+// groups added by hand) survive a load/save round-trip unchanged.
+//
+// ansible-dev manages the hosts of the [Managed] group; every other group
+// belongs to the user.
+package inventory
@@ -0,0 +1,183 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"fmt"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetHostVar sets the variable key of host on the line (INI) or mapping
+// (YAML) that defines the host's variables, replacing an existing value in
+// place. An error is returned if host is not in the inventory.
+func (inv *Inventory) SetHostVar(host, key, value string) error {
+	return inv.SetHostVars(host, [][2]string{{key, value}}, nil)
+}
+
+// SetHostVars sets the variables set and removes the variables unset of
+// host in a single edit. Variables already defined are replaced in place
+// and new ones are appended in order. An error is returned if host is not
+// in the inventory.
+func (inv *Inventory) SetHostVars(host string, set [][2]string, unset []string) error {
+	if inv.Host(host) == nil {
+		return fmt.Errorf("'%s' is not a host in %s", host, inv.Filename)
+	}
+
+	if inv.Format == "yaml" {
+		node := inv.yamlHostNode(host)
+
+		for _, key := range unset {
+			deleteMappingValue(node, key)
+		}
+
+		for _, v := range set {
+			yamlSetVar(node, v[0], v[1])
+		}
+
+		if len(node.Content) == 0 {
+			// A host without variables is listed with a null value.
+			*node = yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+		}
+	} else if err := inv.iniEditHost(host, set, unset); err != nil {
+		return err
+	}
+
+	return inv.parse()
+}
+
+// UnsetHostVar removes the variable key from host. An error is returned if
+// host is not in the inventory.
+func (inv *Inventory) UnsetHostVar(host, key string) error {
+	return inv.SetHostVars(host, nil, []string{key})
+}
+
+// SetGroupVar sets the variable key of group, in its [group:vars] section
+// (INI) or vars mapping (YAML), which is created when missing.
+func (inv *Inventory) SetGroupVar(group, key, value string) error {
+	if inv.Format == "yaml" {
+		yamlSetVar(ensureMapping(inv.yamlGroupNode(group, true), "vars"), key, value)
+	} else {
+		inv.iniSetGroupVar(group, key, value)
+	}
+
+	return inv.parse()
+}
+
+// UnsetGroupVar removes the variable key from group, if set.
+func (inv *Inventory) UnsetGroupVar(group, key string) error {
+	if inv.Format == "yaml" {
+		node := inv.yamlGroupNode(group, false)
+
+		if vars := mappingValue(node, "vars"); vars != nil {
+			deleteMappingValue(vars, key)
+
+			if len(vars.Content) == 0 {
+				deleteMappingValue(node, "vars")
+			}
+		}
+	} else {
+		inv.iniUnsetGroupVar(group, key)
+	}
+
+	return inv.parse()
+}
+
+// AddHost lists host in group, creating the group when missing. vars are
+// set on the new entry; pass nil when the host is already defined in
+// another group and is only added as a member. An error is returned if the
+// group already lists the host.
+func (inv *Inventory) AddHost(group, host string, vars [][2]string) error {
+	if g := inv.Group(group); g != nil && slices.Contains(g.Hosts, host) {
+		return fmt.Errorf("'%s' is already a host of the '%s' group", host, group)
+	}
+
+	if inv.Format == "yaml" {
+		inv.yamlAddHost(group, host, vars)
+	} else {
+		inv.iniAddHost(group, host, vars)
+	}
+
+	return inv.parse()
+}
+
+// RemoveHost removes host from every group. An error is returned if host
+// is not in the inventory.
+func (inv *Inventory) RemoveHost(host string) error {
+	if inv.Host(host) == nil {
+		return fmt.Errorf("'%s' is not a host in %s", host, inv.Filename)
+	}
+
+	if inv.Format == "yaml" {
+		inv.yamlRemoveHost("", host)
+	} else {
+		inv.iniRemoveHost(host)
+	}
+
+	return inv.parse()
+}
+
+// RemoveHostFromGroup removes host from group only. An error is returned if
+// the group does not list the host.
+func (inv *Inventory) RemoveHostFromGroup(group, host string) error {
+	if g := inv.Group(group); g == nil || !slices.Contains(g.Hosts, host) {
+		return fmt.Errorf("'%s' is not a host of the '%s' group", host, group)
+	}
+
+	if inv.Format == "yaml" {
+		inv.yamlRemoveHost(group, host)
+	} else {
+		inv.iniRemoveHostFromGroup(group, host)
+	}
+
+	return inv.parse()
+}
+
+// AddGroup adds an empty group. An error is returned if the group already
+// exists.
+func (inv *Inventory) AddGroup(group string) error {
+	if inv.Group(group) != nil {
+		return fmt.Errorf("the '%s' group already exists", group)
+	}
+
+	if inv.Format == "yaml" {
+		inv.yamlGroupNode(group, true)
+	} else {
+		inv.iniAppendSection(fmt.Sprintf("[%s]", group))
+	}
+
+	return inv.parse()
+}
+
+// RemoveGroup removes group with its hosts list, variables and children
+// list, and removes it from the children of other groups. The hosts
+// themselves stay in the inventory when another group lists them. An error
+// is returned if the group does not exist.
+func (inv *Inventory) RemoveGroup(group string) error {
+	if inv.Group(group) == nil {
+		return fmt.Errorf("the '%s' group does not exist", group)
+	}
+
+	if inv.Format == "yaml" {
+		inv.yamlRemoveGroup(group)
+	} else {
+		inv.iniRemoveGroup(group)
+	}
+
+	return inv.parse()
+}
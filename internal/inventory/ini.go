@@ -0,0 +1,480 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// iniSection is a section of an INI inventory. The lines before the first
+// section header form an implicit "ungrouped" hosts section with header -1.
+//
+// Fields:
+//   - name:   the group name.
+//   - kind:   "hosts", "vars" or "children".
+//   - header: the index of the "[name:kind]" line.
+//   - end:    the index after the last host, variable or child line of the
+//     section, or after the header when there is none. Comments that
+//     follow are attributed to the next section.
+type iniSection struct {
+	name   string
+	kind   string
+	header int
+	end    int
+}
+
+// sections splits the INI lines into sections.
+func (inv *Inventory) sections() []iniSection {
+	sections := []iniSection{{name: "ungrouped", kind: "hosts", header: -1, end: 0}}
+
+	for i, line := range inv.lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case len(trimmed) == 0 || isComment(line):
+			continue
+		case strings.HasPrefix(trimmed, "[") && strings.Contains(trimmed, "]"):
+			inner := trimmed[1:strings.Index(trimmed, "]")]
+			name, kind, _ := strings.Cut(inner, ":")
+
+			if len(kind) == 0 {
+				kind = "hosts"
+			}
+
+			sections = append(sections, iniSection{name: name, kind: kind, header: i, end: i + 1})
+		default:
+			sections[len(sections)-1].end = i + 1
+		}
+	}
+
+	return sections
+}
+
+// entries returns the indexes of the host, variable or child lines of s.
+func (inv *Inventory) entries(s iniSection) []int {
+	var lines []int
+
+	for i := s.header + 1; i < s.end; i++ {
+		if len(strings.TrimSpace(inv.lines[i])) == 0 || isComment(inv.lines[i]) {
+			continue
+		}
+
+		lines = append(lines, i)
+	}
+
+	return lines
+}
+
+// parseINI builds the model from the INI lines.
+func (inv *Inventory) parseINI() {
+	for _, s := range inv.sections() {
+		entries := inv.entries(s)
+
+		if s.header < 0 && len(entries) == 0 {
+			continue
+		}
+
+		g := inv.group(s.name)
+
+		for _, i := range entries {
+			code, _ := splitComment(inv.lines[i])
+
+			switch s.kind {
+			case "vars":
+				key, value, _ := strings.Cut(code, "=")
+				inv.groupVar(s.name, strings.TrimSpace(key), unquote(strings.TrimSpace(value)), i+1)
+			case "children":
+				if child := strings.TrimSpace(code); !slices.Contains(g.Children, child) {
+					g.Children = append(g.Children, child)
+				}
+			default:
+				fields := splitQuoted(code)
+				if len(fields) == 0 {
+					continue
+				}
+
+				var (
+					vars  [][2]string
+					lines []int
+				)
+
+				for _, field := range fields[1:] {
+					key, value, _ := strings.Cut(field, "=")
+					vars = append(vars, [2]string{key, unquote(value)})
+					lines = append(lines, i+1)
+				}
+
+				inv.host(s.name, fields[0], vars, lines)
+			}
+		}
+	}
+}
+
+// iniSectionOf returns the section of the given name and kind, or false.
+func (inv *Inventory) iniSectionOf(name, kind string) (iniSection, bool) {
+	for _, s := range inv.sections() {
+		if s.name == name && s.kind == kind && (s.header >= 0 || len(inv.entries(s)) > 0) {
+			return s, true
+		}
+	}
+
+	return iniSection{}, false
+}
+
+// iniHostLine returns the index of the line defining host: the first line
+// listing it with variables, or else the first line listing it; -1 when the
+// host is not listed.
+func (inv *Inventory) iniHostLine(host string) int {
+	first := -1
+
+	for _, s := range inv.sections() {
+		if s.kind != "hosts" {
+			continue
+		}
+
+		for _, i := range inv.entries(s) {
+			code, _ := splitComment(inv.lines[i])
+			fields := splitQuoted(code)
+
+			if len(fields) == 0 || fields[0] != host {
+				continue
+			}
+
+			if len(fields) > 1 {
+				return i
+			}
+
+			if first < 0 {
+				first = i
+			}
+		}
+	}
+
+	return first
+}
+
+// iniEditHost sets the variables set on the line defining host and removes
+// the variables unset, preserving the padding after the host name, so that
+// generated columns stay aligned, and any trailing comment.
+func (inv *Inventory) iniEditHost(host string, set [][2]string, unset []string) error {
+	i := inv.iniHostLine(host)
+	if i < 0 {
+		return fmt.Errorf("'%s' is not a host in %s", host, inv.Filename)
+	}
+
+	code, comment := splitComment(inv.lines[i])
+	indent := code[:len(code)-len(strings.TrimLeft(code, " \t"))]
+	code = strings.TrimLeft(code, " \t")
+	rest := strings.TrimPrefix(code, host)
+	padding := rest[:len(rest)-len(strings.TrimLeft(rest, " \t"))]
+
+	var fields []string
+
+	applied := map[string]bool{}
+
+	for _, field := range splitQuoted(rest) {
+		key, _, _ := strings.Cut(field, "=")
+
+		if slices.Contains(unset, key) {
+			continue
+		}
+
+		if value, ok := lookup(set, key); ok {
+			field = formatVar(key, value)
+			applied[key] = true
+		}
+
+		fields = append(fields, field)
+	}
+
+	for _, v := range set {
+		if !applied[v[0]] {
+			fields = append(fields, formatVar(v[0], v[1]))
+		}
+	}
+
+	if len(fields) == 0 {
+		padding = ""
+	} else if len(padding) == 0 {
+		padding = " "
+	}
+
+	inv.lines[i] = strings.TrimRight(indent+host+padding+strings.Join(fields, " ")+comment, " \t")
+
+	return nil
+}
+
+// iniSetGroupVar sets key in the [group:vars] section, which is created
+// when missing.
+func (inv *Inventory) iniSetGroupVar(group, key, value string) {
+	line := key + "=" + value
+
+	s, ok := inv.iniSectionOf(group, "vars")
+	if !ok {
+		inv.iniAppendSection(fmt.Sprintf("[%s:vars]", group), line)
+		return
+	}
+
+	for _, i := range inv.entries(s) {
+		code, _ := splitComment(inv.lines[i])
+		if k, _, _ := strings.Cut(code, "="); strings.TrimSpace(k) == key {
+			inv.lines[i] = line
+			return
+		}
+	}
+
+	inv.insert(s.end, line)
+}
+
+// iniUnsetGroupVar removes key from the [group:vars] section.
+func (inv *Inventory) iniUnsetGroupVar(group, key string) {
+	s, ok := inv.iniSectionOf(group, "vars")
+	if !ok {
+		return
+	}
+
+	for _, i := range slices.Backward(inv.entries(s)) {
+		code, _ := splitComment(inv.lines[i])
+		if k, _, _ := strings.Cut(code, "="); strings.TrimSpace(k) == key {
+			inv.remove(i, i+1)
+		}
+	}
+}
+
+// iniAddHost lists host in group with the given variables, creating the
+// [group] section when missing. The host name is padded to the width used
+// by the other host lines of the section so that the variables line up.
+func (inv *Inventory) iniAddHost(group, host string, vars [][2]string) {
+	s, ok := inv.iniSectionOf(group, "hosts")
+
+	width := 0
+
+	if ok && len(vars) > 0 {
+		for _, i := range inv.entries(s) {
+			code, _ := splitComment(inv.lines[i])
+			fields := splitQuoted(code)
+
+			if len(fields) > 1 {
+				width = max(width, strings.Index(code, fields[1])-1)
+			}
+		}
+	}
+
+	line := host
+	if len(vars) > 0 {
+		line = fmt.Sprintf("%-*s", width, host)
+
+		for _, v := range vars {
+			line += " " + formatVar(v[0], v[1])
+		}
+	}
+
+	if !ok {
+		inv.iniAppendSection(fmt.Sprintf("[%s]", group), line)
+		return
+	}
+
+	inv.insert(s.end, line)
+}
+
+// iniRemoveHost removes every line listing host.
+func (inv *Inventory) iniRemoveHost(host string) {
+	for _, s := range slices.Backward(inv.sections()) {
+		if s.kind != "hosts" {
+			continue
+		}
+
+		for _, i := range slices.Backward(inv.entries(s)) {
+			code, _ := splitComment(inv.lines[i])
+			if fields := splitQuoted(code); len(fields) > 0 && fields[0] == host {
+				inv.remove(i, i+1)
+			}
+		}
+	}
+}
+
+// iniRemoveHostFromGroup removes the line listing host in group.
+func (inv *Inventory) iniRemoveHostFromGroup(group, host string) {
+	s, ok := inv.iniSectionOf(group, "hosts")
+	if !ok {
+		return
+	}
+
+	for _, i := range slices.Backward(inv.entries(s)) {
+		code, _ := splitComment(inv.lines[i])
+		if fields := splitQuoted(code); len(fields) > 0 && fields[0] == host {
+			inv.remove(i, i+1)
+		}
+	}
+}
+
+// iniRemoveGroup removes the [group], [group:vars] and [group:children]
+// sections, together with the comment lines directly above each and the
+// blank line that follows each, and every reference to group in the
+// children of other groups.
+func (inv *Inventory) iniRemoveGroup(group string) {
+	for _, s := range slices.Backward(inv.sections()) {
+		if s.name == group {
+			start := max(s.header, 0)
+			for start > 0 && isComment(inv.lines[start-1]) {
+				start--
+			}
+
+			end := s.end
+			if end < len(inv.lines) && len(strings.TrimSpace(inv.lines[end])) == 0 {
+				end++
+			}
+
+			inv.remove(start, end)
+
+			continue
+		}
+
+		if s.kind != "children" {
+			continue
+		}
+
+		for _, i := range slices.Backward(inv.entries(s)) {
+			if code, _ := splitComment(inv.lines[i]); strings.TrimSpace(code) == group {
+				inv.remove(i, i+1)
+			}
+		}
+	}
+}
+
+// iniAppendSection adds a section with the given header and lines. It is
+// placed before the first section of the "all" group, which by convention
+// closes the file, or else at the end of the file.
+func (inv *Inventory) iniAppendSection(header string, lines ...string) {
+	block := append([]string{header}, lines...)
+
+	for _, s := range inv.sections() {
+		if s.name == "all" && s.header >= 0 {
+			inv.insert(s.header, append(block, "")...)
+			return
+		}
+	}
+
+	if len(inv.lines) > 0 && len(strings.TrimSpace(inv.lines[len(inv.lines)-1])) > 0 {
+		block = append([]string{""}, block...)
+	}
+
+	inv.insert(len(inv.lines), block...)
+}
+
+// insert inserts lines before the line at index i.
+func (inv *Inventory) insert(i int, lines ...string) {
+	inv.lines = slices.Insert(inv.lines, i, lines...)
+}
+
+// remove removes the lines from index i up to but excluding j.
+func (inv *Inventory) remove(i, j int) {
+	inv.lines = slices.Delete(inv.lines, i, j)
+}
+
+// isComment reports whether line is a comment line.
+func isComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+
+	return strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, ";")
+}
+
+// splitComment splits an INI line into its content and a trailing comment
+// that starts with an unquoted "#" or ";" preceded by whitespace; the
+// comment keeps that whitespace.
+func splitComment(line string) (string, string) {
+	var quote rune
+
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case (r == '#' || r == ';') && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			code := line[:i]
+			trimmed := strings.TrimRight(code, " \t")
+
+			return trimmed, line[len(trimmed):]
+		}
+	}
+
+	return line, ""
+}
+
+// splitQuoted splits s on whitespace without breaking double- or
+// single-quoted runs; the quotes are kept.
+func splitQuoted(s string) []string {
+	var (
+		fields []string
+		start  = -1
+		quote  rune
+	)
+
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+
+			if start < 0 {
+				start = i
+			}
+		case r == ' ' || r == '\t':
+			if start >= 0 {
+				fields = append(fields, s[start:i])
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+
+	if start >= 0 {
+		fields = append(fields, s[start:])
+	}
+
+	return fields
+}
+
+// unquote removes matching double or single quotes around v.
+func unquote(v string) string {
+	if len(v) >= 2 && (v[0] == '"' || v[0] == '\'') && v[len(v)-1] == v[0] {
+		return v[1 : len(v)-1]
+	}
+
+	return v
+}
+
+// formatVar renders a key=value host variable, double-quoting values that
+// contain whitespace.
+func formatVar(key, value string) string {
+	if strings.ContainsAny(value, " \t") {
+		value = `"` + value + `"`
+	}
+
+	return key + "=" + value
+}
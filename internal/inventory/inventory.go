@@ -0,0 +1,371 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Filename is the inventory file created for an Ansible development
+	// directory that has none; see [Path] for the one in use.
+	Filename = "hosts.ini"
+
+	// Managed is the group listing the development hosts created by
+	// ansible-dev, whatever the backend.
+	Managed = "vagrant"
+)
+
+// Host is a host of the inventory.
+//
+// Fields:
+//   - Name: the inventory host name.
+//   - Vars: the variables set on the host in the inventory file itself, in
+//     the order they are defined. Variables in host_vars are not included.
+type Host struct {
+	Name string
+	Vars [][2]string
+}
+
+// Var returns the value of the host variable key and whether it is set.
+func (h *Host) Var(key string) (string, bool) {
+	return lookup(h.Vars, key)
+}
+
+// Group is a group of the inventory.
+//
+// Fields:
+//   - Name:     the group name.
+//   - Hosts:    the hosts listed directly in the group, in file order.
+//   - Children: the names of the child groups, in file order.
+//   - Vars:     the group variables set in the inventory file itself. Group
+//     variables in group_vars are not included.
+type Group struct {
+	Name     string
+	Hosts    []string
+	Children []string
+	Vars     [][2]string
+}
+
+// Inventory is a parsed inventory file. Use [Load] or [Parse] to create
+// one, the query methods to read it and the edit methods followed by
+// [Inventory.Save] to change it.
+type Inventory struct {
+	// Filename is the file the inventory was read from and is saved to.
+	Filename string
+
+	// Format is "ini" or "yaml".
+	Format string
+
+	groups []*Group
+	hosts  []*Host
+
+	// varLines maps a variable definition (see lineKey) to its 1-based
+	// line number in the file.
+	varLines map[string]int
+
+	lines  []string
+	root   *yaml.Node
+	source []byte
+}
+
+// Load reads and parses the inventory file filename; see [Parse].
+func Load(filename string) (*Inventory, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return Parse(filename, data)
+}
+
+// Parse parses data as the inventory file filename. Files with a ".yml" or
+// ".yaml" extension are parsed as YAML and any other file as INI.
+func Parse(filename string, data []byte) (*Inventory, error) {
+	inv := &Inventory{
+		Filename: filename,
+		Format:   "ini",
+	}
+
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".yml", ".yaml":
+		inv.Format = "yaml"
+		inv.root = &yaml.Node{}
+		inv.source = data
+
+		if err := yaml.Unmarshal(data, inv.root); err != nil {
+			return nil, fmt.Errorf("unable to parse '%s': %w", filename, err)
+		}
+	default:
+		text := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+		if len(text) > 0 {
+			inv.lines = strings.Split(text, "\n")
+		}
+	}
+
+	if err := inv.parse(); err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", filename, err)
+	}
+
+	return inv, nil
+}
+
+// parse rebuilds the model from the source document. It is called after
+// loading and after every edit.
+func (inv *Inventory) parse() error {
+	inv.groups = nil
+	inv.hosts = nil
+	inv.varLines = map[string]int{}
+
+	if inv.Format == "yaml" {
+		return inv.parseYAML()
+	}
+
+	inv.parseINI()
+
+	return nil
+}
+
+// Bytes renders the inventory file.
+func (inv *Inventory) Bytes() ([]byte, error) {
+	if inv.Format == "yaml" {
+		return inv.yamlBytes()
+	}
+
+	lines := inv.lines
+	for len(lines) > 0 && len(strings.TrimSpace(lines[len(lines)-1])) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return []byte{}, nil
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// Save writes the inventory back to its file.
+func (inv *Inventory) Save() error {
+	data, err := inv.Bytes()
+	if err != nil {
+		return err
+	}
+
+	return runner.WriteFile(inv.Filename, data)
+}
+
+// Groups returns the groups defined in the file, in the order they first
+// appear. The implicit "all" and "ungrouped" groups are only included when
+// the file defines them, e.g. with an [all:vars] section.
+func (inv *Inventory) Groups() []*Group {
+	return inv.groups
+}
+
+// Group returns the named group, or nil if the file does not define it.
+func (inv *Inventory) Group(name string) *Group {
+	for _, g := range inv.groups {
+		if g.Name == name {
+			return g
+		}
+	}
+
+	return nil
+}
+
+// Hosts returns every host, in the order they first appear.
+func (inv *Inventory) Hosts() []*Host {
+	return inv.hosts
+}
+
+// Host returns the named host, or nil if it is not in the inventory.
+func (inv *Inventory) Host(name string) *Host {
+	for _, h := range inv.hosts {
+		if h.Name == name {
+			return h
+		}
+	}
+
+	return nil
+}
+
+// HostNames returns the names of every host, in the order they first
+// appear.
+func (inv *Inventory) HostNames() []string {
+	names := make([]string, 0, len(inv.hosts))
+	for _, h := range inv.hosts {
+		names = append(names, h.Name)
+	}
+
+	return names
+}
+
+// GroupHosts returns the hosts of the named group, including those of its
+// child groups recursively, without duplicates. The "all" group contains
+// every host.
+func (inv *Inventory) GroupHosts(name string) []string {
+	if name == "all" {
+		return inv.HostNames()
+	}
+
+	var hosts []string
+
+	visited := map[string]bool{}
+
+	var walk func(string)
+
+	walk = func(name string) {
+		if visited[name] {
+			return
+		}
+
+		visited[name] = true
+
+		g := inv.Group(name)
+		if g == nil {
+			return
+		}
+
+		for _, h := range g.Hosts {
+			if !slices.Contains(hosts, h) {
+				hosts = append(hosts, h)
+			}
+		}
+
+		for _, c := range g.Children {
+			walk(c)
+		}
+	}
+
+	walk(name)
+
+	return hosts
+}
+
+// HostGroups returns the names of the groups that list host directly, in
+// file order.
+func (inv *Inventory) HostGroups(host string) []string {
+	var groups []string
+
+	for _, g := range inv.groups {
+		if slices.Contains(g.Hosts, host) {
+			groups = append(groups, g.Name)
+		}
+	}
+
+	return groups
+}
+
+// ParentGroups returns the names of the groups that list group as a child,
+// in file order.
+func (inv *Inventory) ParentGroups(group string) []string {
+	var parents []string
+
+	for _, g := range inv.groups {
+		if slices.Contains(g.Children, group) {
+			parents = append(parents, g.Name)
+		}
+	}
+
+	return parents
+}
+
+// group returns the named group, adding it to the model when missing.
+func (inv *Inventory) group(name string) *Group {
+	if g := inv.Group(name); g != nil {
+		return g
+	}
+
+	g := &Group{Name: name}
+	inv.groups = append(inv.groups, g)
+
+	return g
+}
+
+// HostVarLine returns the 1-based line number of the file that sets the
+// variable key of host, or 0 if the inventory file does not set it.
+func (inv *Inventory) HostVarLine(host, key string) int {
+	return inv.varLines[lineKey("host", host, key)]
+}
+
+// GroupVarLine returns the 1-based line number of the file that sets the
+// variable key of group, or 0 if the inventory file does not set it.
+func (inv *Inventory) GroupVarLine(group, key string) int {
+	return inv.varLines[lineKey("group", group, key)]
+}
+
+// lineKey identifies a variable of a host or group in varLines.
+func lineKey(kind, name, key string) string {
+	return kind + "\x00" + name + "\x00" + key
+}
+
+// groupVar records the variable of group defined on line.
+func (inv *Inventory) groupVar(group, key, value string, line int) {
+	g := inv.group(group)
+	g.Vars = setVar(g.Vars, key, value)
+	inv.varLines[lineKey("group", group, key)] = line
+}
+
+// host records that host is listed in group with the given variables,
+// defined on the given lines. Variables of hosts listed several times are
+// merged, later definitions taking precedence as in Ansible.
+func (inv *Inventory) host(group, name string, vars [][2]string, lines []int) {
+	g := inv.group(group)
+	if !slices.Contains(g.Hosts, name) {
+		g.Hosts = append(g.Hosts, name)
+	}
+
+	h := inv.Host(name)
+	if h == nil {
+		h = &Host{Name: name}
+		inv.hosts = append(inv.hosts, h)
+	}
+
+	for i, v := range vars {
+		h.Vars = setVar(h.Vars, v[0], v[1])
+		inv.varLines[lineKey("host", name, v[0])] = lines[i]
+	}
+}
+
+// lookup returns the value of key in vars and whether it is set.
+func lookup(vars [][2]string, key string) (string, bool) {
+	for _, v := range vars {
+		if v[0] == key {
+			return v[1], true
+		}
+	}
+
+	return "", false
+}
+
+// setVar replaces the value of key in vars, or appends it.
+func setVar(vars [][2]string, key, value string) [][2]string {
+	for i, v := range vars {
+		if v[0] == key {
+			vars[i][1] = value
+			return vars
+		}
+	}
+
+	return append(vars, [2]string{key, value})
+}
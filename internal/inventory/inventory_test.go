@@ -0,0 +1,396 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// iniFixture and yamlFixture describe the same inventory: the managed
+// debian and alma hosts, a web group with a frontend child group, and
+// variables on hosts and groups.
+const iniFixture = `# Development hosts.
+[vagrant]
+debian  ansible_host=192.168.121.10 ansible_user=vagrant  # first VM
+alma
+
+; web servers
+[web]
+debian
+
+[web:children]
+frontend
+
+[frontend]
+alma
+
+[frontend:vars]
+http_port=8080
+
+[all:vars]
+ansible_python_interpreter=/usr/bin/python3
+`
+
+const yamlFixture = `---
+# Development hosts.
+all:
+  vars:
+    ansible_python_interpreter: /usr/bin/python3   # system python
+  children:
+    vagrant:   # managed
+      hosts:
+        debian:
+          ansible_host: 192.168.121.10    # first VM
+          ansible_user: vagrant
+        alma:
+
+    web:
+      hosts:
+        debian:
+      children:
+        frontend:
+          hosts:
+            alma:
+          vars:
+            http_port: 8080
+`
+
+// fixtures returns the file name and content of the fixture of each format.
+func fixtures() map[string]string {
+	return map[string]string{
+		"hosts.ini": iniFixture,
+		"hosts.yml": yamlFixture,
+	}
+}
+
+// replace applies the old, new pairs to s in turn, each once.
+func replace(t *testing.T, s string, pairs ...string) string {
+	t.Helper()
+
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if !strings.Contains(s, pairs[i]) {
+			t.Fatalf("%q is not in the fixture", pairs[i])
+		}
+
+		s = strings.Replace(s, pairs[i], pairs[i+1], 1)
+	}
+
+	return s
+}
+
+func TestParse(t *testing.T) {
+	for filename, data := range fixtures() {
+		t.Run(filename, func(t *testing.T) {
+			inv, err := Parse(filename, []byte(data))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			groups := []string{}
+			for _, g := range inv.Groups() {
+				groups = append(groups, g.Name)
+			}
+
+			slices.Sort(groups)
+
+			for _, c := range []struct {
+				what string
+				got  []string
+				want []string
+			}{
+				{"groups", groups, []string{"all", "frontend", "vagrant", "web"}},
+				{"hosts", inv.HostNames(), []string{"debian", "alma"}},
+				{"vagrant hosts", inv.GroupHosts(Managed), []string{"debian", "alma"}},
+				{"web hosts", inv.GroupHosts("web"), []string{"debian", "alma"}},
+				{"frontend hosts", inv.GroupHosts("frontend"), []string{"alma"}},
+				{"all hosts", inv.GroupHosts("all"), []string{"debian", "alma"}},
+				{"web children", inv.Group("web").Children, []string{"frontend"}},
+				{"groups of alma", inv.HostGroups("alma"), []string{"vagrant", "frontend"}},
+				{"parents of frontend", inv.ParentGroups("frontend"), []string{"web"}},
+			} {
+				if !slices.Equal(c.got, c.want) {
+					t.Errorf("%s = %q, want %q", c.what, c.got, c.want)
+				}
+			}
+
+			debian := inv.Host("debian")
+			if want := [][2]string{{"ansible_host", "192.168.121.10"}, {"ansible_user", "vagrant"}}; !slices.Equal(debian.Vars, want) {
+				t.Errorf("debian vars = %q, want %q", debian.Vars, want)
+			}
+
+			if len(inv.Host("alma").Vars) > 0 {
+				t.Errorf("alma vars = %q, want none", inv.Host("alma").Vars)
+			}
+
+			if v, _ := lookup(inv.Group("frontend").Vars, "http_port"); v != "8080" {
+				t.Errorf("frontend http_port = %q, want 8080", v)
+			}
+
+			if v, _ := lookup(inv.Group("all").Vars, "ansible_python_interpreter"); v != "/usr/bin/python3" {
+				t.Errorf("all ansible_python_interpreter = %q, want /usr/bin/python3", v)
+			}
+
+			lines := strings.Split(data, "\n")
+
+			if n := inv.HostVarLine("debian", "ansible_host"); n < 1 || !strings.Contains(lines[n-1], "192.168.121.10") {
+				t.Errorf("HostVarLine(debian, ansible_host) = %d", n)
+			}
+
+			if n := inv.GroupVarLine("frontend", "http_port"); n < 1 || !strings.Contains(lines[n-1], "8080") {
+				t.Errorf("GroupVarLine(frontend, http_port) = %d", n)
+			}
+
+			if n := inv.HostVarLine("alma", "ansible_host"); n != 0 {
+				t.Errorf("HostVarLine(alma, ansible_host) = %d, want 0", n)
+			}
+		})
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for filename, data := range fixtures() {
+		t.Run(filename, func(t *testing.T) {
+			inv, err := Parse(filename, []byte(data))
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			if got, err := inv.Bytes(); err != nil || string(got) != data {
+				t.Errorf("Bytes() = %q, %v, want %q", got, err, data)
+			}
+		})
+	}
+}
+
+func TestEdit(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*Inventory) error
+		ini  []string
+		yaml []string
+	}{
+		{
+			name: "set host var",
+			edit: func(inv *Inventory) error { return inv.SetHostVar("debian", "ansible_host", "192.168.121.20") },
+			ini:  []string{"ansible_host=192.168.121.10", "ansible_host=192.168.121.20"},
+			yaml: []string{"ansible_host: 192.168.121.10    # first VM", "ansible_host: 192.168.121.20 # first VM"},
+		},
+		{
+			name: "add host var",
+			edit: func(inv *Inventory) error { return inv.SetHostVar("alma", "ansible_port", "2222") },
+			ini:  []string{"\nalma\n", "\nalma ansible_port=2222\n"},
+			yaml: []string{"        alma:\n", "        alma:\n          ansible_port: 2222\n"},
+		},
+		{
+			name: "set and unset host vars",
+			edit: func(inv *Inventory) error {
+				return inv.SetHostVars("debian", [][2]string{{"ansible_port", "22"}}, []string{"ansible_host"})
+			},
+			ini: []string{"debian  ansible_host=192.168.121.10 ansible_user=vagrant  # first VM",
+				"debian  ansible_user=vagrant ansible_port=22  # first VM"},
+			yaml: []string{"          ansible_host: 192.168.121.10    # first VM\n          ansible_user: vagrant\n",
+				"          ansible_user: vagrant\n          ansible_port: 22\n"},
+		},
+		{
+			name: "unset every host var",
+			edit: func(inv *Inventory) error {
+				return inv.SetHostVars("debian", nil, []string{"ansible_host", "ansible_user"})
+			},
+			ini: []string{"debian  ansible_host=192.168.121.10 ansible_user=vagrant  # first VM", "debian  # first VM"},
+			yaml: []string{"        debian:\n          ansible_host: 192.168.121.10    # first VM\n          ansible_user: vagrant\n",
+				"        debian:\n"},
+		},
+		{
+			name: "add host",
+			edit: func(inv *Inventory) error {
+				return inv.AddHost(Managed, "rocky", [][2]string{{"ansible_host", "10.0.0.3"}})
+			},
+			ini:  []string{"\nalma\n", "\nalma\nrocky   ansible_host=10.0.0.3\n"},
+			yaml: []string{"        alma:\n", "        alma:\n        rocky:\n          ansible_host: 10.0.0.3\n"},
+		},
+		{
+			name: "add host to a new group",
+			edit: func(inv *Inventory) error { return inv.AddHost("db", "alma", nil) },
+			ini:  []string{"[all:vars]", "[db]\nalma\n\n[all:vars]"},
+			yaml: []string{"            http_port: 8080\n", "            http_port: 8080\n    db:\n      hosts:\n        alma:\n"},
+		},
+		{
+			name: "remove host",
+			edit: func(inv *Inventory) error { return inv.RemoveHost("alma") },
+			ini:  []string{"\nalma\n", "\n", "[frontend]\nalma\n", "[frontend]\n"},
+			yaml: []string{"        alma:\n", "", "          hosts:\n            alma:\n", ""},
+		},
+		{
+			name: "remove host from group",
+			edit: func(inv *Inventory) error { return inv.RemoveHostFromGroup("web", "debian") },
+			ini:  []string{"[web]\ndebian\n", "[web]\n"},
+			yaml: []string{"      hosts:\n        debian:\n      children:", "      children:"},
+		},
+		{
+			name: "add group",
+			edit: func(inv *Inventory) error { return inv.AddGroup("db") },
+			ini:  []string{"[all:vars]", "[db]\n\n[all:vars]"},
+			yaml: []string{"            http_port: 8080\n", "            http_port: 8080\n    db: {}\n"},
+		},
+		{
+			name: "remove group",
+			edit: func(inv *Inventory) error { return inv.RemoveGroup("frontend") },
+			ini:  []string{"frontend\n\n[frontend]\nalma\n\n[frontend:vars]\nhttp_port=8080\n\n", "\n"},
+			yaml: []string{"      children:\n        frontend:\n          hosts:\n            alma:\n          vars:\n            http_port: 8080\n", ""},
+		},
+		{
+			name: "set group var",
+			edit: func(inv *Inventory) error { return inv.SetGroupVar("frontend", "http_port", "80") },
+			ini:  []string{"http_port=8080", "http_port=80"},
+			yaml: []string{"http_port: 8080", "http_port: 80"},
+		},
+		{
+			name: "set var of a group without vars",
+			edit: func(inv *Inventory) error { return inv.SetGroupVar("web", "http_port", "80") },
+			ini:  []string{"[all:vars]", "[web:vars]\nhttp_port=80\n\n[all:vars]"},
+			yaml: []string{"            http_port: 8080\n", "            http_port: 8080\n      vars:\n        http_port: 80\n"},
+		},
+		{
+			name: "unset group var",
+			edit: func(inv *Inventory) error { return inv.UnsetGroupVar("frontend", "http_port") },
+			ini:  []string{"[frontend:vars]\nhttp_port=8080\n", "[frontend:vars]\n"},
+			yaml: []string{"          vars:\n            http_port: 8080\n", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		for filename, data := range fixtures() {
+			t.Run(tt.name+"/"+filename, func(t *testing.T) {
+				pairs := tt.ini
+				if strings.HasSuffix(filename, ".yml") {
+					pairs = tt.yaml
+				}
+
+				want := replace(t, data, pairs...)
+
+				inv, err := Parse(filename, []byte(data))
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+
+				if err := tt.edit(inv); err != nil {
+					t.Fatalf("edit error = %v", err)
+				}
+
+				if got, err := inv.Bytes(); err != nil || string(got) != want {
+					t.Errorf("Bytes() =\n%s\nwant\n%s", got, want)
+				}
+			})
+		}
+	}
+}
+
+func TestEditErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(*Inventory) error
+		want string
+	}{
+		{"unknown host", func(inv *Inventory) error { return inv.SetHostVar("rocky", "a", "b") }, "'rocky' is not a host in "},
+		{"host already in group", func(inv *Inventory) error { return inv.AddHost("web", "debian", nil) }, "'debian' is already a host of the 'web' group"},
+		{"remove unknown host", func(inv *Inventory) error { return inv.RemoveHost("rocky") }, "'rocky' is not a host in "},
+		{"host not in group", func(inv *Inventory) error { return inv.RemoveHostFromGroup("web", "alma") }, "'alma' is not a host of the 'web' group"},
+		{"existing group", func(inv *Inventory) error { return inv.AddGroup("web") }, "the 'web' group already exists"},
+		{"unknown group", func(inv *Inventory) error { return inv.RemoveGroup("db") }, "the 'db' group does not exist"},
+	}
+
+	for _, tt := range tests {
+		for filename, data := range fixtures() {
+			t.Run(tt.name+"/"+filename, func(t *testing.T) {
+				inv, err := Parse(filename, []byte(data))
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+
+				if err := tt.edit(inv); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+					t.Errorf("edit error = %v, want %q", err, tt.want)
+				}
+
+				if got, _ := inv.Bytes(); string(got) != data {
+					t.Errorf("Bytes() after a failed edit =\n%s\nwant\n%s", got, data)
+				}
+			})
+		}
+	}
+}
+
+func TestKeepLayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		original string
+		rendered string
+		want     string
+	}{
+		{
+			name:     "spacing of unchanged lines",
+			original: "a:   # c\n  b:    1\n",
+			rendered: "a: # c\n  b: 1\n",
+			want:     "a:   # c\n  b:    1\n",
+		},
+		{
+			name:     "changed lines as rendered",
+			original: "a:   # c\n  b:    1\n",
+			rendered: "a: # c\n  b: 2\n",
+			want:     "a:   # c\n  b: 2\n",
+		},
+		{
+			name:     "document marker and blank lines",
+			original: "---\na:\n  b:\n\nc:\n",
+			rendered: "a:\n  b:\nc:\n",
+			want:     "---\na:\n  b:\n\nc:\n",
+		},
+		{
+			name:     "inserted lines before a blank line",
+			original: "a:\n  b:\n\nc:\n",
+			rendered: "a:\n  b:\n  d:\nc:\n",
+			want:     "a:\n  b:\n  d:\n\nc:\n",
+		},
+		{
+			name:     "blank lines of removed lines",
+			original: "a:\n\nb:\n\nc:\n",
+			rendered: "a:\nc:\n",
+			want:     "a:\n\nc:\n",
+		},
+		{
+			name:     "trailing blank lines",
+			original: "a:\n\nb:\n",
+			rendered: "a:\n",
+			want:     "a:\n",
+		},
+		{
+			name:     "new file",
+			original: "",
+			rendered: "a:\n",
+			want:     "a:\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(keepLayout([]byte(tt.original), []byte(tt.rendered))); got != tt.want {
+				t.Errorf("keepLayout() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/ini.v1"
+)
+
+// candidates are the inventory files looked for, in order, when ansible.cfg
+// does not point at a static inventory file.
+var candidates = []string{"hosts.yml", "hosts.yaml", Filename}
+
+// Path returns the inventory file of the Ansible development directory:
+// the file named by the "inventory" setting of the [defaults] section of
+// ansible.cfg when it is an INI or YAML file that exists, otherwise the
+// first of hosts.yml, hosts.yaml and hosts.ini that exists, and
+// [Filename] when there is none yet.
+//
+// An inventory script, such as the one written in dynamic inventory mode,
+// a directory or a comma-separated host list is not a file this package
+// can edit, so the fallback is used for those.
+func Path() string {
+	if cfg, err := ini.Load("ansible.cfg"); err == nil {
+		value := filepath.Clean(strings.TrimSpace(cfg.Section("defaults").Key("inventory").String()))
+
+		ext := strings.ToLower(filepath.Ext(value))
+		if value != "." && slices.Contains([]string{"", ".ini", ".yml", ".yaml"}, ext) && runner.FileExist(value) {
+			return value
+		}
+	}
+
+	for _, name := range candidates {
+		if runner.FileExist(name) {
+			return name
+		}
+	}
+
+	return Filename
+}
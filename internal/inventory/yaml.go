@@ -0,0 +1,468 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inventory
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlTop returns the mapping of top-level groups, or nil for an empty
+// document.
+func (inv *Inventory) yamlTop() *yaml.Node {
+	if inv.root.Kind != yaml.DocumentNode || len(inv.root.Content) == 0 {
+		return nil
+	}
+
+	return inv.root.Content[0]
+}
+
+// parseYAML builds the model from the YAML document.
+func (inv *Inventory) parseYAML() error {
+	top := inv.yamlTop()
+	if top == nil {
+		return nil
+	}
+
+	if top.Kind != yaml.MappingNode {
+		return errors.New("the inventory is not a mapping of groups")
+	}
+
+	for i := 0; i+1 < len(top.Content); i += 2 {
+		inv.parseYAMLGroup(top.Content[i].Value, top.Content[i+1])
+	}
+
+	return nil
+}
+
+// parseYAMLGroup adds the group defined by node and its children.
+func (inv *Inventory) parseYAMLGroup(name string, node *yaml.Node) {
+	g := inv.group(name)
+
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+
+		switch node.Content[i].Value {
+		case "hosts":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				vars, lines := yamlVars(value.Content[j+1])
+				inv.host(name, value.Content[j].Value, vars, lines)
+			}
+		case "children":
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				child := value.Content[j].Value
+
+				if !slices.Contains(g.Children, child) {
+					g.Children = append(g.Children, child)
+				}
+
+				inv.parseYAMLGroup(child, value.Content[j+1])
+			}
+		case "vars":
+			vars, lines := yamlVars(value)
+			for k, v := range vars {
+				inv.groupVar(name, v[0], v[1], lines[k])
+			}
+		}
+	}
+}
+
+// yamlVars returns the variables of a mapping node and the line of each.
+// Scalar values are returned as written; lists and mappings are rendered
+// in YAML flow style.
+func yamlVars(node *yaml.Node) ([][2]string, []int) {
+	if node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+
+	var (
+		vars  [][2]string
+		lines []int
+	)
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		value := node.Content[i+1]
+
+		if value.Kind != yaml.ScalarNode {
+			flow := *value
+			flow.Style = yaml.FlowStyle
+
+			data, err := yaml.Marshal(&flow)
+			if err == nil {
+				vars = append(vars, [2]string{node.Content[i].Value, strings.TrimSpace(string(data))})
+				lines = append(lines, node.Content[i].Line)
+			}
+
+			continue
+		}
+
+		vars = append(vars, [2]string{node.Content[i].Value, value.Value})
+		lines = append(lines, node.Content[i].Line)
+	}
+
+	return vars, lines
+}
+
+// yamlBytes renders the YAML document with the two-space indentation used
+// for every YAML file ansible-dev writes. The layout of the lines an edit
+// did not change is kept (see [keepLayout]).
+func (inv *Inventory) yamlBytes() ([]byte, error) {
+	if inv.yamlTop() == nil {
+		return []byte{}, nil
+	}
+
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(inv.root); err != nil {
+		return nil, err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+
+	return keepLayout(inv.source, buf.Bytes()), nil
+}
+
+// keepLayout restores in rendered the layout of original that the YAML
+// encoder does not preserve. The lines of both are aligned on their longest
+// common subsequence, comparing them with their runs of inner whitespace
+// collapsed: an aligned line is written as it is in original, so that the
+// spacing before a comment or a value survives, and a blank line or the
+// leading "---" of original that the encoder dropped is put back before
+// the line that followed it. The other lines are written as rendered.
+//
+// An edit that only changes the whitespace inside a value is therefore not
+// written; no edit made by this package does that.
+func keepLayout(original, rendered []byte) []byte {
+	a := splitLines(original)
+	b := splitLines(rendered)
+
+	na := make([]string, len(a))
+	for i, line := range a {
+		na[i] = normalize(line)
+	}
+
+	nb := make([]string, len(b))
+	for j, line := range b {
+		nb[j] = normalize(line)
+	}
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if na[i] == nb[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	result := make([]string, 0, len(b)+1)
+	i, j := 0, 0
+
+	if len(a) > 0 && a[0] == "---" && (len(b) == 0 || b[0] != "---") {
+		result = append(result, a[0])
+		i++
+	}
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && na[i] == nb[j]:
+			result = append(result, a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] > lcs[i][j+1]):
+			if len(strings.TrimSpace(a[i])) == 0 && len(result) > 0 && len(result[len(result)-1]) > 0 {
+				result = append(result, "")
+			}
+
+			i++
+		default:
+			result = append(result, b[j])
+			j++
+		}
+	}
+
+	for len(result) > 0 && len(strings.TrimSpace(result[len(result)-1])) == 0 {
+		result = result[:len(result)-1]
+	}
+
+	if len(result) == 0 {
+		return []byte{}
+	}
+
+	return []byte(strings.Join(result, "\n") + "\n")
+}
+
+// splitLines splits content into lines without their line endings.
+func splitLines(content []byte) []string {
+	text := strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	if len(text) == 0 {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// normalize returns line with its indentation kept and every other run of
+// whitespace collapsed to a single space.
+func normalize(line string) string {
+	trimmed := strings.TrimLeft(line, " \t")
+
+	return line[:len(line)-len(trimmed)] + strings.Join(strings.Fields(trimmed), " ")
+}
+
+// yamlGroupNodes calls fn with the name and node of every group definition,
+// top-level groups first and then their children, depth first.
+func (inv *Inventory) yamlGroupNodes(fn func(name string, node *yaml.Node)) {
+	var walk func(*yaml.Node)
+
+	walk = func(groups *yaml.Node) {
+		for i := 0; i+1 < len(groups.Content); i += 2 {
+			node := groups.Content[i+1]
+			fn(groups.Content[i].Value, node)
+
+			if children := mappingValue(node, "children"); children != nil {
+				walk(children)
+			}
+		}
+	}
+
+	if top := inv.yamlTop(); top != nil {
+		walk(top)
+	}
+}
+
+// yamlHostNode returns the node holding the variables of host: the first
+// non-empty definition, or else the first definition, converted to a
+// mapping; nil when the host is not listed.
+func (inv *Inventory) yamlHostNode(host string) *yaml.Node {
+	var first, defined *yaml.Node
+
+	inv.yamlGroupNodes(func(_ string, node *yaml.Node) {
+		value := mappingValue(mappingValue(node, "hosts"), host)
+		if value == nil {
+			return
+		}
+
+		if first == nil {
+			first = value
+		}
+
+		if defined == nil && value.Kind == yaml.MappingNode && len(value.Content) > 0 {
+			defined = value
+		}
+	})
+
+	if defined != nil {
+		return defined
+	}
+
+	if first != nil {
+		toMapping(first)
+	}
+
+	return first
+}
+
+// yamlGroupNode returns the mapping node of the first definition of group.
+// With create, a missing group is added to the children of "all" when the
+// file defines it, or else as a top-level group.
+func (inv *Inventory) yamlGroupNode(group string, create bool) *yaml.Node {
+	var found *yaml.Node
+
+	inv.yamlGroupNodes(func(name string, node *yaml.Node) {
+		if found == nil && name == group {
+			found = node
+		}
+	})
+
+	if found != nil {
+		toMapping(found)
+		return found
+	}
+
+	if !create {
+		return nil
+	}
+
+	top := inv.yamlTop()
+	if top == nil {
+		top = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		inv.root = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{top}}
+	}
+
+	parent := top
+
+	if all := mappingValue(top, "all"); all != nil && group != "all" {
+		toMapping(all)
+		parent = ensureMapping(all, "children")
+	}
+
+	node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	setMappingValue(parent, group, node)
+
+	return node
+}
+
+// yamlSetVar sets key in the mapping node. An existing scalar value is
+// updated in place so that a comment on it is kept.
+func yamlSetVar(node *yaml.Node, key, value string) {
+	if current := mappingValue(node, key); current != nil && current.Kind == yaml.ScalarNode {
+		current.Value = value
+		current.Tag = ""
+		current.Style = 0
+
+		return
+	}
+
+	setMappingValue(node, key, &yaml.Node{Kind: yaml.ScalarNode, Value: value})
+}
+
+// yamlAddHost lists host in group with the given variables.
+func (inv *Inventory) yamlAddHost(group, host string, vars [][2]string) {
+	hosts := ensureMapping(inv.yamlGroupNode(group, true), "hosts")
+	value := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+
+	if len(vars) > 0 {
+		value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+
+		for _, v := range vars {
+			yamlSetVar(value, v[0], v[1])
+		}
+	}
+
+	setMappingValue(hosts, host, value)
+}
+
+// yamlRemoveHost removes host from group, or from every group when group
+// is empty. A hosts mapping left empty is removed as well.
+func (inv *Inventory) yamlRemoveHost(group, host string) {
+	inv.yamlGroupNodes(func(name string, node *yaml.Node) {
+		if len(group) > 0 && name != group {
+			return
+		}
+
+		hosts := mappingValue(node, "hosts")
+		if hosts == nil {
+			return
+		}
+
+		deleteMappingValue(hosts, host)
+
+		if len(hosts.Content) == 0 {
+			deleteMappingValue(node, "hosts")
+		}
+	})
+}
+
+// yamlRemoveGroup removes every definition of group.
+func (inv *Inventory) yamlRemoveGroup(group string) {
+	if top := inv.yamlTop(); top != nil {
+		deleteMappingValue(top, group)
+	}
+
+	inv.yamlGroupNodes(func(_ string, node *yaml.Node) {
+		children := mappingValue(node, "children")
+		if children == nil {
+			return
+		}
+
+		deleteMappingValue(children, group)
+
+		if len(children.Content) == 0 {
+			deleteMappingValue(node, "children")
+		}
+	})
+}
+
+// mappingValue returns the value of key in the mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// setMappingValue replaces the value of key in the mapping node, or
+// appends it.
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, value)
+}
+
+// deleteMappingValue removes key from the mapping node.
+func deleteMappingValue(node *yaml.Node, key string) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = slices.Delete(node.Content, i, i+2)
+			return
+		}
+	}
+}
+
+// ensureMapping returns the mapping value of key in node, adding an empty
+// one when key is missing or null.
+func ensureMapping(node *yaml.Node, key string) *yaml.Node {
+	value := mappingValue(node, key)
+	if value == nil {
+		value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		setMappingValue(node, key, value)
+	}
+
+	toMapping(value)
+
+	return value
+}
+
+// toMapping turns a null node, e.g. the value of a host listed without
+// variables, into an empty mapping in place.
+func toMapping(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && len(node.Value) == 0 {
+		*node = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: node.Line, Column: node.Column}
+	}
+}
@@ -0,0 +1,28 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package lock records the exact roles and collections installed from
+// requirements.yml in a requirements.lock file, so that a project can be
+// restored to the same content later.
+//
+// For every installed role and collection the lock file keeps the resolved
+// version, the source it came from, the git commit of a repository source
+// and a hash of the installed files ([Hash]). [Generate] builds it from
+// what is on disk after "ansible-galaxy install", [Locked] turns it back
+// into a requirements file that pins each entry, and [Verify] reports
+// drift between the lock file, requirements.yml and the roles/ and
+// collections/ folders.
+package lock
@@ -0,0 +1,136 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+)
+
+// Generate builds the lock of the roles and collections installed in the
+// project: every role installed by ansible-galaxy under the roles path and
+// every collection with a MANIFEST.json under the collections path.
+//
+// Each entry is matched with its requirement in requirements.yml to record
+// the source it came from; an installed entry without a requirement is
+// marked as a dependency. A git source is recorded with the commit that is
+// installed, which is only known when its ref is a full commit hash or the
+// installed copy is a git checkout.
+//
+// An error is returned if requirements.yml or ansible.cfg cannot be read,
+// an installed role or collection cannot be read or hashed, or the commit
+// of a git source cannot be told.
+func Generate() (Lock, error) {
+	requirements, err := ansible.ReadRequirements()
+	if err != nil {
+		return Lock{}, err
+	}
+
+	roles, err := ansible.InstalledRoles()
+	if err != nil {
+		return Lock{}, err
+	}
+
+	collections, err := ansible.InstalledCollections()
+	if err != nil {
+		return Lock{}, err
+	}
+
+	l := Lock{Roles: []Entry{}, Collections: []Entry{}}
+
+	for _, r := range roles {
+		e, err := roleEntry(r, requirements.Roles)
+		if err != nil {
+			return Lock{}, err
+		}
+
+		l.Roles = append(l.Roles, e)
+	}
+
+	for _, c := range collections {
+		e, err := collectionEntry(c, requirements.Collections)
+		if err != nil {
+			return Lock{}, err
+		}
+
+		l.Collections = append(l.Collections, e)
+	}
+
+	return l, nil
+}
+
+// roleEntry builds the lock entry of the installed role r.
+func roleEntry(r ansible.InstalledRole, requirements []ansible.Role) (Entry, error) {
+	hash, err := Hash(r.Path)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{Name: r.Name, Version: r.Version, Hash: hash}
+
+	required, ok := requiredRole(requirements, r.Name)
+	if !ok {
+		e.Dependency = true
+		return e, nil
+	}
+
+	e.Source = required.Source
+	e.Scm = required.Scm
+
+	if required.IsRepository() {
+		ref := r.Version
+		if len(ref) == 0 {
+			ref = required.Version
+		}
+
+		if e.Commit, err = installedCommit(required.Source, ref, r.Path); err != nil {
+			return Entry{}, err
+		}
+	}
+
+	return e, nil
+}
+
+// collectionEntry builds the lock entry of the installed collection c.
+func collectionEntry(c ansible.InstalledCollection, requirements []ansible.Collection) (Entry, error) {
+	hash, err := Hash(c.Path)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{Name: c.Name, Version: c.Version, Hash: hash}
+
+	required, ok := requiredCollection(requirements, c.Name, c.Repository)
+	if !ok {
+		e.Dependency = true
+		return e, nil
+	}
+
+	if kind := required.SourceType(); kind != "galaxy" {
+		e.Source = required.Name
+		e.Type = kind
+	} else {
+		e.Source = required.Source
+	}
+
+	if e.Type == "git" {
+		if e.Commit, err = installedCommit(required.Name, required.Version, c.Path); err != nil {
+			return Entry{}, err
+		}
+	}
+
+	return e, nil
+}
@@ -0,0 +1,239 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// Commits pinned by the requirements of the fixture project.
+const (
+	appCommit   = "0123456789abcdef0123456789abcdef01234567"
+	toolsCommit = "89abcdef0123456789abcdef0123456789abcdef"
+)
+
+// fixtureRequirements is the requirements.yml of the fixture project.
+const fixtureRequirements = `---
+roles:
+  - name: nginx
+    version: 1.2.0
+  - name: app
+    src: git+https://git.example.com/app.git
+    version: ` + appCommit + `
+collections:
+  - name: community.docker
+    version: 3.4.0
+  - name: https://git.example.com/example/tools.git
+    type: git
+    version: ` + toolsCommit + `
+`
+
+// project changes to a new fixture project: requirements.yml and the
+// roles and collections ansible-galaxy installed from it, including the
+// common role and the community.library collection installed as
+// dependencies. files are written on top of the fixture.
+func project(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	fixture := map[string]string{
+		"ansible.cfg":                                                     "[defaults]\nroles_path = roles\ncollections_path = collections\n",
+		"requirements.yml":                                                fixtureRequirements,
+		"roles/nginx/meta/.galaxy_install_info":                           "install_date: 'Mon Jan  5 10:00:00 2026'\nversion: 1.2.0\n",
+		"roles/nginx/meta/main.yml":                                       "dependencies:\n  - common\n",
+		"roles/nginx/tasks/main.yml":                                      "- debug: msg=nginx\n",
+		"roles/app/meta/.galaxy_install_info":                             "install_date: 'Mon Jan  5 10:00:00 2026'\nversion: " + appCommit + "\n",
+		"roles/app/tasks/main.yml":                                        "- debug: msg=app\n",
+		"roles/common/meta/.galaxy_install_info":                          "install_date: 'Mon Jan  5 10:00:00 2026'\nversion: 2.0.0\n",
+		"roles/common/tasks/main.yml":                                     "- debug: msg=common\n",
+		"roles/local/tasks/main.yml":                                      "- debug: msg=local\n",
+		"collections/ansible_collections/community/docker/MANIFEST.json":  manifest("community", "docker", "3.4.0", ""),
+		"collections/ansible_collections/community/library/MANIFEST.json": manifest("community", "library", "1.0.0", ""),
+		"collections/ansible_collections/example/tools/MANIFEST.json": manifest("example", "tools", "0.3.0",
+			"https://git.example.com/example/tools"),
+	}
+
+	for name, content := range files {
+		fixture[name] = content
+	}
+
+	for name, content := range fixture {
+		write(t, dir, name, content)
+	}
+}
+
+// manifest returns the MANIFEST.json of the collection namespace.name.
+func manifest(namespace, name, version, repository string) string {
+	return `{"collection_info": {"namespace": "` + namespace + `", "name": "` + name +
+		`", "version": "` + version + `", "repository": "` + repository + `"}}`
+}
+
+// hashOf returns the hash of the installed role or collection in dir,
+// relative to the fixture project.
+func hashOf(t *testing.T, dir string) string {
+	t.Helper()
+
+	hash, err := Hash(filepath.FromSlash(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return hash
+}
+
+func TestGenerate(t *testing.T) {
+	project(t, nil)
+
+	got, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := Lock{
+		Roles: []Entry{
+			{Name: "app", Version: appCommit, Source: "git+https://git.example.com/app.git", Commit: appCommit,
+				Hash: hashOf(t, "roles/app")},
+			{Name: "common", Version: "2.0.0", Hash: hashOf(t, "roles/common"), Dependency: true},
+			{Name: "nginx", Version: "1.2.0", Hash: hashOf(t, "roles/nginx")},
+		},
+		Collections: []Entry{
+			{Name: "community.docker", Version: "3.4.0",
+				Hash: hashOf(t, "collections/ansible_collections/community/docker")},
+			{Name: "community.library", Version: "1.0.0", Dependency: true,
+				Hash: hashOf(t, "collections/ansible_collections/community/library")},
+			{Name: "example.tools", Version: "0.3.0", Source: "https://git.example.com/example/tools.git", Type: "git",
+				Commit: toolsCommit, Hash: hashOf(t, "collections/ansible_collections/example/tools")},
+		},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Generate() =\n%+v\nwant\n%+v", got, want)
+	}
+
+	if problems, err := Verify(got); err != nil || len(problems) > 0 {
+		t.Errorf("Verify(Generate()) = %v, %v, want no problems", problems, err)
+	}
+}
+
+func TestGenerateCommit(t *testing.T) {
+	tests := []struct {
+		name        string
+		requirement string
+		installInfo string
+		checkout    bool
+		want        string
+		wantErr     string
+	}{
+		{
+			name:        "commit in the install info",
+			requirement: "  - name: app\n    src: git+https://git.example.com/app.git\n    version: main\n",
+			installInfo: appCommit,
+			want:        appCommit,
+		},
+		{
+			name:        "commit in the source",
+			requirement: "  - name: app\n    src: git+https://git.example.com/app.git," + appCommit + "\n",
+			want:        appCommit,
+		},
+		{
+			name:        "branch",
+			requirement: "  - name: app\n    src: git+https://git.example.com/app.git\n    version: main\n",
+			installInfo: "main",
+			wantErr:     "can't tell which commit of git+https://git.example.com/app.git (main) is installed",
+		},
+		{
+			name:        "default branch",
+			requirement: "  - name: app\n    src: git+https://git.example.com/app.git\n",
+			wantErr:     "can't tell which commit of git+https://git.example.com/app.git (the default branch) is installed",
+		},
+		{
+			name:        "git checkout of a branch",
+			requirement: "  - name: app\n    src: git+https://git.example.com/app.git\n    version: main\n",
+			installInfo: "main",
+			checkout:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project(t, map[string]string{
+				"requirements.yml":                    "roles:\n" + tt.requirement,
+				"roles/app/meta/.galaxy_install_info": "version: '" + tt.installInfo + "'\n",
+			})
+
+			want := tt.want
+
+			if tt.checkout {
+				want = checkout(t, filepath.Join("roles", "app"))
+			}
+
+			l, err := Generate()
+
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Generate() error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if e, _ := l.Role("app"); e.Commit != want {
+				t.Errorf("commit = %q, want %q", e.Commit, want)
+			}
+		})
+	}
+}
+
+// checkout makes dir a git checkout with a single commit and returns the
+// hash of that commit.
+func checkout(t *testing.T, dir string) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+			"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com")
+
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+
+		return strings.TrimSpace(string(out))
+	}
+
+	git("init", "-q")
+	git("add", ".")
+	git("commit", "-q", "-m", "initial")
+
+	return git("rev-parse", "HEAD")
+}
@@ -0,0 +1,112 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Hash returns the hash of the files installed in dir, in the form
+// "sha256:<hex>". Every regular file and symbolic link contributes its
+// slash-separated path relative to dir and its content or link target, in
+// lexical order, so the hash changes when a file is added, removed,
+// renamed or modified.
+//
+// Files written or changed by the install itself rather than taken from
+// the source are left out: the meta/.galaxy_install_info of a role and
+// Python byte code. So is the .git directory of a git checkout, whose
+// commit is locked instead.
+//
+// An error is returned if dir cannot be walked or a file cannot be read.
+func Hash(dir string) (string, error) {
+	h := sha256.New()
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		rel = filepath.ToSlash(rel)
+
+		if d.IsDir() {
+			if d.Name() == "__pycache__" || d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if rel == "meta/.galaxy_install_info" || strings.HasSuffix(rel, ".pyc") {
+			return nil
+		}
+
+		if d.Type()&fs.ModeSymlink != 0 {
+			target, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+
+			_, _ = io.WriteString(h, "link\x00"+rel+"\x00"+target+"\x00")
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		_, _ = fmt.Fprintf(h, "file\x00%s\x00%d\x00", rel, info.Size())
+
+		return hashFile(h, path)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// hashFile adds the content of the file at path to w.
+func hashFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close() //nolint:errcheck
+
+	_, err = io.Copy(w, file)
+
+	return err
+}
@@ -0,0 +1,172 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(t *testing.T, dir string)
+		same   bool
+	}{
+		{
+			name:   "unchanged",
+			change: func(*testing.T, string) {},
+			same:   true,
+		},
+		{
+			name:   "modified file",
+			change: func(t *testing.T, dir string) { write(t, dir, "tasks/main.yml", "- debug: msg=changed\n") },
+		},
+		{
+			name:   "added file",
+			change: func(t *testing.T, dir string) { write(t, dir, "files/motd", "hello\n") },
+		},
+		{
+			name:   "added empty file",
+			change: func(t *testing.T, dir string) { write(t, dir, "files/empty", "") },
+		},
+		{
+			name:   "removed file",
+			change: func(t *testing.T, dir string) { remove(t, dir, "defaults/main.yml") },
+		},
+		{
+			name: "renamed file",
+			change: func(t *testing.T, dir string) {
+				if err := os.Rename(filepath.Join(dir, "defaults", "main.yml"), filepath.Join(dir, "defaults", "other.yml")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "content moved between files",
+			change: func(t *testing.T, dir string) {
+				write(t, dir, "tasks/main.yml", "- debug: msg=hello\nport: 80\n")
+				write(t, dir, "defaults/main.yml", "")
+			},
+		},
+		{
+			name: "changed link target",
+			change: func(t *testing.T, dir string) {
+				remove(t, dir, "files/current")
+				link(t, dir, "other", "files/current")
+			},
+		},
+		{
+			name:   "install info",
+			change: func(t *testing.T, dir string) { write(t, dir, "meta/.galaxy_install_info", "version: 2.0.0\n") },
+			same:   true,
+		},
+		{
+			name: "python byte code",
+			change: func(t *testing.T, dir string) {
+				write(t, dir, "plugins/module.pyc", "\x00")
+				write(t, dir, "plugins/__pycache__/module.cpython-312.pyc", "\x00")
+			},
+			same: true,
+		},
+		{
+			name:   "git directory",
+			change: func(t *testing.T, dir string) { write(t, dir, ".git/HEAD", "ref: refs/heads/main\n") },
+			same:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+
+			write(t, dir, "tasks/main.yml", "- debug: msg=hello\n")
+			write(t, dir, "defaults/main.yml", "port: 80\n")
+			write(t, dir, "meta/.galaxy_install_info", "version: 1.0.0\n")
+			link(t, dir, "main", "files/current")
+
+			before, err := Hash(dir)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+
+			if !strings.HasPrefix(before, "sha256:") || len(before) != len("sha256:")+64 {
+				t.Errorf("Hash() = %q, want sha256:<hex>", before)
+			}
+
+			tt.change(t, dir)
+
+			after, err := Hash(dir)
+			if err != nil {
+				t.Fatalf("Hash() error = %v", err)
+			}
+
+			if (after == before) != tt.same {
+				t.Errorf("Hash() = %s after the change, %s before; want same = %v", after, before, tt.same)
+			}
+		})
+	}
+}
+
+func TestHashMissingDirectory(t *testing.T) {
+	if hash, err := Hash(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Errorf("Hash() = %q, want an error", hash)
+	}
+}
+
+// write writes the file name, slash-separated and relative to dir,
+// creating the directories it is in.
+func write(t *testing.T, dir, name, content string) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// remove removes the file name, slash-separated and relative to dir.
+func remove(t *testing.T, dir, name string) {
+	t.Helper()
+
+	if err := os.Remove(filepath.Join(dir, filepath.FromSlash(name))); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// link creates the symbolic link name, slash-separated and relative to
+// dir, pointing at target.
+func link(t *testing.T, dir, target, name string) {
+	t.Helper()
+
+	path := filepath.Join(dir, filepath.FromSlash(name))
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Symlink(target, path); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"bytes"
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// Filename is the name of the lock file, next to requirements.yml.
+const Filename = "requirements.lock"
+
+// header is written at the top of the lock file.
+const header = "# Generated by \"ansible-dev restore\"; do not edit.\n" +
+	"# Restore exactly this content with \"ansible-dev restore --locked\".\n"
+
+// Entry is a single locked role or collection.
+//
+// Fields:
+//   - Name:       the role name or the fully qualified collection name.
+//   - Version:    the installed version.
+//   - Source:     the src of a role, the Galaxy server of a Galaxy
+//     collection, or the repository URL, archive or path of any other
+//     collection.
+//   - Scm:        the source control system of a repository role.
+//   - Type:       the source type of a collection other than "galaxy".
+//   - Commit:     the git commit a repository source resolved to.
+//   - Hash:       the hash of the installed files (see [Hash]).
+//   - Dependency: set when the entry is not in requirements.yml and was
+//     installed as a dependency of another one.
+type Entry struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version,omitempty"`
+	Source     string `yaml:"source,omitempty"`
+	Scm        string `yaml:"scm,omitempty"`
+	Type       string `yaml:"type,omitempty"`
+	Commit     string `yaml:"commit,omitempty"`
+	Hash       string `yaml:"hash"`
+	Dependency bool   `yaml:"dependency,omitempty"`
+}
+
+// Lock is the content of the requirements.lock file.
+type Lock struct {
+	Roles       []Entry `yaml:"roles"`
+	Collections []Entry `yaml:"collections"`
+}
+
+// Read reads the lock file of the current working directory.
+//
+// An error is returned if the file is missing or is not valid YAML.
+func Read() (Lock, error) {
+	var l Lock
+
+	data, err := os.ReadFile(Filename)
+	if err != nil {
+		return Lock{}, err
+	}
+
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return Lock{}, err
+	}
+
+	return l, nil
+}
+
+// Write writes l to the lock file of the current working directory through
+// the active [runner.Runner].
+//
+// An error is returned if l cannot be encoded or the file cannot be written.
+func (l Lock) Write() error {
+	var buf bytes.Buffer
+
+	buf.WriteString(header)
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	if err := encoder.Encode(l); err != nil {
+		return err
+	}
+
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+
+	return runner.WriteFile(Filename, buf.Bytes())
+}
+
+// Role returns the locked role named name.
+func (l Lock) Role(name string) (Entry, bool) {
+	return find(l.Roles, name)
+}
+
+// Collection returns the locked collection named name.
+func (l Lock) Collection(name string) (Entry, bool) {
+	return find(l.Collections, name)
+}
+
+// find returns the entry named name.
+func find(entries []Entry, name string) (Entry, bool) {
+	for _, e := range entries {
+		if e.Name == name {
+			return e, true
+		}
+	}
+
+	return Entry{}, false
+}
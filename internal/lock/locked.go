@@ -0,0 +1,86 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+)
+
+// Stale returns the part of l whose installed copy is missing or differs
+// from the lock (see [Lock.Check]): the entries "restore --locked" has to
+// install.
+//
+// An error is returned if the installed copies cannot be checked.
+func (l Lock) Stale() (Lock, error) {
+	problems, err := l.Check()
+	if err != nil {
+		return Lock{}, err
+	}
+
+	stale := Lock{Roles: []Entry{}, Collections: []Entry{}}
+
+	for _, p := range problems {
+		if p.Kind == "role" {
+			e, _ := l.Role(p.Name)
+			stale.Roles = append(stale.Roles, e)
+		} else {
+			e, _ := l.Collection(p.Name)
+			stale.Collections = append(stale.Collections, e)
+		}
+	}
+
+	return stale, nil
+}
+
+// Requirements returns requirements that install exactly the entries of
+// l: Galaxy roles and collections at their locked version and repository
+// sources at their locked commit. Dependencies are listed as well, since
+// the locked install does not resolve them.
+func (l Lock) Requirements() ansible.Requirements {
+	requirements := ansible.Requirements{
+		Roles:       []ansible.Role{},
+		Collections: []ansible.Collection{},
+	}
+
+	for _, e := range l.Roles {
+		r := ansible.Role{Name: e.Name, Source: e.Source, Scm: e.Scm, Version: e.Version}
+
+		if len(e.Commit) > 0 {
+			r.Source, _ = ansible.SplitRepository(e.Source)
+			r.Scm = "git"
+			r.Version = e.Commit
+		}
+
+		requirements.Roles = append(requirements.Roles, r)
+	}
+
+	for _, e := range l.Collections {
+		c := ansible.Collection{Name: e.Name, Source: e.Source, Version: e.Version}
+
+		switch {
+		case len(e.Commit) > 0:
+			url, _ := ansible.SplitRepository(e.Source)
+			c = ansible.Collection{Name: url, Type: "git", Version: e.Commit}
+		case len(e.Type) > 0:
+			c = ansible.Collection{Name: e.Source, Type: e.Type}
+		}
+
+		requirements.Collections = append(requirements.Collections, c)
+	}
+
+	return requirements
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// commitID matches a full git commit hash.
+var commitID = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// requiredRole returns the role requirement of the role installed as name.
+func requiredRole(roles []ansible.Role, name string) (ansible.Role, bool) {
+	for _, r := range roles {
+		if len(r.Include) == 0 && r.Name == name {
+			return r, true
+		}
+	}
+
+	return ansible.Role{}, false
+}
+
+// requiredCollection returns the collection requirement the collection
+// name, whose MANIFEST.json declares repository, was installed from (see
+// [ansible.Collection.Installs]).
+func requiredCollection(collections []ansible.Collection, name, repository string) (ansible.Collection, bool) {
+	for _, c := range collections {
+		if c.Installs(name, repository) {
+			return c, true
+		}
+	}
+
+	return ansible.Collection{}, false
+}
+
+// installedCommit returns the commit of the repository source installed in
+// dir. ansible-galaxy installs a repository from an archive of the ref it
+// was asked for, so the commit is only known when that ref already is a
+// full commit hash (as recorded in the install info of a role, or pinned
+// by the requirement) or when dir is a git checkout, whose HEAD is used.
+// Resolving the ref against the remote instead would record the commit it
+// points at now, which may not be the one that was installed.
+//
+// An error is returned if the commit cannot be told, or git fails.
+func installedCommit(source, ref, dir string) (string, error) {
+	if _, suffix := ansible.SplitRepository(source); len(ref) == 0 {
+		ref = suffix
+	}
+
+	if commitID.MatchString(ref) {
+		return ref, nil
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if len(ref) == 0 {
+			ref = "the default branch"
+		}
+
+		return "", fmt.Errorf("can't tell which commit of %s (%s) is installed in %s: pin its version to a full commit hash in requirements.yml", source, ref, dir)
+	}
+
+	output, err := runner.Capture("git", "-C", dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("reading the commit of %s: %w", dir, err)
+	}
+
+	if runner.IsDryRun() {
+		return "", nil
+	}
+
+	commit := strings.TrimSpace(output)
+
+	if !commitID.MatchString(commit) {
+		return "", fmt.Errorf("reading the commit of %s: unexpected output '%s'", dir, commit)
+	}
+
+	return commit, nil
+}
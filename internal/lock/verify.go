@@ -0,0 +1,253 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+)
+
+// Problem is a single difference found by [Verify] or [Lock.Check].
+//
+// Fields:
+//   - Kind:    "role" or "collection".
+//   - Name:    the role or collection name, or the source of a requirement
+//     that is not a Galaxy name.
+//   - Problem: a description of the difference.
+type Problem struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Problem string `json:"problem"`
+}
+
+// Verify reports the drift between the lock l, requirements.yml and the
+// roles and collections installed in the project:
+//   - requirements that have no locked entry and locked entries that are
+//     no longer required;
+//   - requirements pinning an exact version other than the locked one;
+//   - locked entries that are missing on disk or whose installed version
+//     or content differ from the lock (see [Lock.Check]);
+//   - installed roles and collections that are not locked.
+//
+// An error is returned if requirements.yml or ansible.cfg cannot be read
+// or an installed role or collection cannot be read or hashed.
+func Verify(l Lock) ([]Problem, error) {
+	requirements, err := ansible.ReadRequirements()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []Problem{}
+
+	for _, r := range requirements.Roles {
+		if len(r.Include) > 0 {
+			continue
+		}
+
+		e, ok := l.Role(r.Name)
+
+		switch {
+		case !ok:
+			problems = append(problems, Problem{"role", r.Name, "required but not locked"})
+		case pinnedOther(r.Version, e.Version) && !r.IsRepository():
+			problems = append(problems, Problem{"role", r.Name,
+				fmt.Sprintf("requirements.yml pins version %s, locked %s", r.Version, e.Version)})
+		}
+	}
+
+	for _, e := range l.Roles {
+		if _, ok := requiredRole(requirements.Roles, e.Name); !ok && !e.Dependency {
+			problems = append(problems, Problem{"role", e.Name, "locked but no longer required"})
+		}
+	}
+
+	for _, c := range requirements.Collections {
+		e, ok := lockedCollection(l, c)
+
+		switch {
+		case !ok:
+			problems = append(problems, Problem{"collection", c.Name, "required but not locked"})
+		case pinnedOther(c.Version, e.Version) && c.SourceType() == "galaxy":
+			problems = append(problems, Problem{"collection", c.Name,
+				fmt.Sprintf("requirements.yml pins version %s, locked %s", c.Version, e.Version)})
+		}
+	}
+
+	for _, e := range l.Collections {
+		if _, ok := requiredCollection(requirements.Collections, e.Name, e.Source); !ok && !e.Dependency {
+			problems = append(problems, Problem{"collection", e.Name, "locked but no longer required"})
+		}
+	}
+
+	installed, err := l.Check()
+	if err != nil {
+		return nil, err
+	}
+
+	problems = append(problems, installed...)
+
+	roles, err := ansible.InstalledRoles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range roles {
+		if _, ok := l.Role(r.Name); !ok {
+			problems = append(problems, Problem{"role", r.Name, "installed but not locked"})
+		}
+	}
+
+	collections, err := ansible.InstalledCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range collections {
+		if _, ok := l.Collection(c.Name); !ok {
+			problems = append(problems, Problem{"collection", c.Name, "installed but not locked"})
+		}
+	}
+
+	return problems, nil
+}
+
+// Check compares the locked entries of l with the roles and collections
+// installed in the project and reports the entries that are missing, that
+// have another version installed or whose content no longer matches the
+// locked hash.
+//
+// An error is returned if ansible.cfg cannot be read or an installed role
+// or collection cannot be read or hashed.
+func (l Lock) Check() ([]Problem, error) {
+	roles, err := ansible.RootRoleFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	collections, err := ansible.CollectionsFolder()
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []Problem{}
+
+	for _, e := range l.Roles {
+		problem, err := check(e, filepath.Join(roles, e.Name), func(dir string) (string, error) {
+			info, err := ansible.ReadRoleInstallInfo(dir)
+			return info.Version, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(problem) > 0 {
+			problems = append(problems, Problem{"role", e.Name, problem})
+		}
+	}
+
+	for _, e := range l.Collections {
+		dir := filepath.Join(collections, filepath.FromSlash(strings.Replace(e.Name, ".", "/", 1)))
+
+		problem, err := check(e, dir, func(dir string) (string, error) {
+			manifest, err := ansible.ReadCollectionManifest(dir)
+			return manifest.Version, err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if len(problem) > 0 {
+			problems = append(problems, Problem{"collection", e.Name, problem})
+		}
+	}
+
+	return problems, nil
+}
+
+// check compares the locked entry e with its copy installed in dir, using
+// version to read the installed version, and describes the first
+// difference found. An empty description means the copy matches the lock.
+//
+// A repository role installed by "restore --locked" records the locked
+// commit as its version rather than the branch or tag it was locked from,
+// so the commit is accepted as well.
+func check(e Entry, dir string, version func(string) (string, error)) (string, error) {
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return "not installed", nil
+	}
+
+	installed, err := version(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+
+	if len(installed) == 0 && len(e.Version) > 0 {
+		return "installed without a version, locked " + e.Version, nil
+	}
+
+	if installed != e.Version && (len(e.Commit) == 0 || installed != e.Commit) {
+		return fmt.Sprintf("version %s installed, locked %s", installed, e.Version), nil
+	}
+
+	hash, err := Hash(dir)
+	if err != nil {
+		return "", err
+	}
+
+	if hash != e.Hash {
+		return fmt.Sprintf("hash mismatch: installed %s, locked %s", short(hash), short(e.Hash)), nil
+	}
+
+	return "", nil
+}
+
+// lockedCollection returns the entry of l installed from the collection
+// requirement c.
+func lockedCollection(l Lock, c ansible.Collection) (Entry, bool) {
+	for _, e := range l.Collections {
+		if e.Dependency {
+			continue
+		}
+
+		if required, ok := requiredCollection([]ansible.Collection{c}, e.Name, e.Source); ok && required.Name == c.Name {
+			return e, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+// pinnedOther reports whether the version constraint of a requirement
+// pins a single version other than locked.
+func pinnedOther(constraint, locked string) bool {
+	version, ok := ansible.PinnedVersion(constraint)
+
+	return ok && version != locked
+}
+
+// short abbreviates a "sha256:<hex>" hash for display.
+func short(hash string) string {
+	if len(hash) > 19 {
+		return hash[:19]
+	}
+
+	return hash
+}
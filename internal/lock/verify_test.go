@@ -0,0 +1,206 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lock
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+	nginxInfo := "roles/nginx/meta/.galaxy_install_info"
+
+	tests := []struct {
+		name   string
+		files  map[string]string
+		remove []string
+		edit   func(l *Lock)
+		want   []Problem
+	}{
+		{
+			name: "no drift",
+			want: []Problem{},
+		},
+		{
+			name: "required but not locked",
+			edit: func(l *Lock) { l.Roles = l.Roles[:2] },
+			want: []Problem{
+				{"role", "nginx", "required but not locked"},
+				{"role", "nginx", "installed but not locked"},
+			},
+		},
+		{
+			name:  "no longer required",
+			files: map[string]string{"requirements.yml": "roles:\n  - name: nginx\n    version: 1.2.0\n"},
+			want: []Problem{
+				{"role", "app", "locked but no longer required"},
+				{"collection", "community.docker", "locked but no longer required"},
+				{"collection", "example.tools", "locked but no longer required"},
+			},
+		},
+		{
+			name: "requirements pin another version",
+			files: map[string]string{"requirements.yml": strings.NewReplacer(
+				"version: 1.2.0", "version: 1.3.0", "version: 3.4.0", "version: ==3.5.0").Replace(fixtureRequirements)},
+			want: []Problem{
+				{"role", "nginx", "requirements.yml pins version 1.3.0, locked 1.2.0"},
+				{"collection", "community.docker", "requirements.yml pins version ==3.5.0, locked 3.4.0"},
+			},
+		},
+		{
+			name: "requirements allow the locked version",
+			files: map[string]string{"requirements.yml": strings.Replace(
+				fixtureRequirements, "version: 3.4.0", "version: '>=3.0.0'", 1)},
+			want: []Problem{},
+		},
+		{
+			name:   "not installed",
+			remove: []string{"roles/nginx", "collections/ansible_collections/community/library"},
+			want: []Problem{
+				{"role", "nginx", "not installed"},
+				{"collection", "community.library", "not installed"},
+			},
+		},
+		{
+			name:  "other version installed",
+			files: map[string]string{nginxInfo: "version: 1.1.0\n"},
+			want:  []Problem{{"role", "nginx", "version 1.1.0 installed, locked 1.2.0"}},
+		},
+		{
+			name:  "installed without a version",
+			files: map[string]string{nginxInfo: "install_date: 'Mon Jan  5 10:00:00 2026'\n"},
+			want:  []Problem{{"role", "nginx", "installed without a version, locked 1.2.0"}},
+		},
+		{
+			name: "content changed",
+			files: map[string]string{
+				"roles/nginx/tasks/main.yml":                              "- debug: msg=patched\n",
+				"collections/ansible_collections/example/tools/README.md": "# tools\n",
+			},
+			want: []Problem{
+				{"role", "nginx", "hash mismatch: installed sha256:"},
+				{"collection", "example.tools", "hash mismatch: installed sha256:"},
+			},
+		},
+		{
+			name: "installed but not locked",
+			files: map[string]string{
+				"roles/extra/meta/.galaxy_install_info":                       "version: 1.0.0\n",
+				"collections/ansible_collections/ansible/posix/MANIFEST.json": manifest("ansible", "posix", "1.5.0", ""),
+			},
+			want: []Problem{
+				{"role", "extra", "installed but not locked"},
+				{"collection", "ansible.posix", "installed but not locked"},
+			},
+		},
+		{
+			name: "repository role installed at the locked commit",
+			edit: func(l *Lock) { l.Roles[0].Version = "main" },
+			want: []Problem{},
+		},
+		{
+			name: "repository role installed at another commit",
+			edit: func(l *Lock) {
+				l.Roles[0].Version = "main"
+				l.Roles[0].Commit = toolsCommit
+			},
+			want: []Problem{{"role", "app", "version " + appCommit + " installed, locked main"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project(t, nil)
+
+			l, err := Generate()
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			dir, _ := os.Getwd()
+
+			for name, content := range tt.files {
+				write(t, dir, name, content)
+			}
+
+			for _, name := range tt.remove {
+				if err := os.RemoveAll(filepath.FromSlash(name)); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.edit != nil {
+				tt.edit(&l)
+			}
+
+			got, err := Verify(l)
+			if err != nil {
+				t.Fatalf("Verify() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Verify() = %v, want %v", got, tt.want)
+			}
+
+			for i, p := range got {
+				want := tt.want[i]
+
+				if p.Kind != want.Kind || p.Name != want.Name || !strings.HasPrefix(p.Problem, want.Problem) {
+					t.Errorf("Verify()[%d] = %v, want %v", i, p, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStale(t *testing.T) {
+	project(t, nil)
+
+	l, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	write(t, ".", "roles/nginx/tasks/main.yml", "- debug: msg=patched\n")
+
+	if err := os.RemoveAll(filepath.Join("collections", "ansible_collections", "example", "tools")); err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := l.Stale()
+	if err != nil {
+		t.Fatalf("Stale() error = %v", err)
+	}
+
+	if len(stale.Roles) != 1 || stale.Roles[0].Name != "nginx" ||
+		len(stale.Collections) != 1 || stale.Collections[0].Name != "example.tools" {
+		t.Fatalf("Stale() = %+v, want the nginx role and the example.tools collection", stale)
+	}
+
+	requirements := stale.Requirements()
+
+	if r := requirements.Roles[0]; r.Name != "nginx" || r.Version != "1.2.0" {
+		t.Errorf("role requirement = %+v, want nginx at 1.2.0", r)
+	}
+
+	if c := requirements.Collections[0]; c.Name != "https://git.example.com/example/tools.git" ||
+		c.Type != "git" || c.Version != toolsCommit {
+		t.Errorf("collection requirement = %+v, want the repository at %s", c, toolsCommit)
+	}
+}
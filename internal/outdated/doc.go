@@ -0,0 +1,21 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package outdated reports which roles and collections of requirements.yml
+// are behind: it compares the installed version of each with the version
+// pinned in requirements.yml and with the latest version available from
+// its source, a Galaxy server ([Galaxy]) or a git repository ([Tags]).
+package outdated
@@ -0,0 +1,139 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Galaxy queries the API of a Galaxy server, such as galaxy.ansible.com or
+// a private Galaxy NG or Automation Hub instance, for the versions of roles
+// and collections.
+type Galaxy struct {
+	api    string
+	client *http.Client
+}
+
+// NewGalaxy returns a [Galaxy] for the server at server. The URL may point
+// at the server or at its API root ("<server>/api/").
+func NewGalaxy(server string) *Galaxy {
+	api := strings.TrimSuffix(server, "/")
+
+	if !strings.HasSuffix(api, "/api") {
+		api += "/api"
+	}
+
+	return &Galaxy{
+		api:    api,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// LatestCollection returns the highest version of the collection
+// namespace.name published on the server, from the v3 collections API.
+//
+// An error is returned if the server cannot be reached or does not know
+// the collection.
+func (g *Galaxy) LatestCollection(namespace, name string) (string, error) {
+	var collection struct {
+		HighestVersion struct {
+			Version string `json:"version"`
+		} `json:"highest_version"`
+	}
+
+	endpoint := fmt.Sprintf("%s/v3/collections/%s/%s/", g.api, url.PathEscape(namespace), url.PathEscape(name))
+
+	if err := g.get(endpoint, &collection); err != nil {
+		return "", err
+	}
+
+	if len(collection.HighestVersion.Version) == 0 {
+		return "", fmt.Errorf("%s has no published version", endpoint)
+	}
+
+	return collection.HighestVersion.Version, nil
+}
+
+// LatestRole returns the newest release of the role namespace.name
+// published on the server, from the v1 roles API.
+//
+// An error is returned if the server cannot be reached or does not know
+// the role.
+func (g *Galaxy) LatestRole(namespace, name string) (string, error) {
+	var roles struct {
+		Results []struct {
+			SummaryFields struct {
+				Versions []struct {
+					Name string `json:"name"`
+				} `json:"versions"`
+			} `json:"summary_fields"`
+		} `json:"results"`
+	}
+
+	query := url.Values{"owner__username": {namespace}, "name": {name}}
+	endpoint := g.api + "/v1/roles/?" + query.Encode()
+
+	if err := g.get(endpoint, &roles); err != nil {
+		return "", err
+	}
+
+	if len(roles.Results) == 0 {
+		return "", fmt.Errorf("role %s.%s not found on %s", namespace, name, g.api)
+	}
+
+	versions := []string{}
+
+	for _, v := range roles.Results[0].SummaryFields.Versions {
+		versions = append(versions, v.Name)
+	}
+
+	latest := Latest(versions)
+	if len(latest) == 0 {
+		return "", fmt.Errorf("role %s.%s has no released version", namespace, name)
+	}
+
+	return latest, nil
+}
+
+// get fetches endpoint and decodes its JSON body into v.
+func (g *Galaxy) get(endpoint string, v any) error {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", "ansible-dev")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", endpoint, resp.Status)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// galaxyServer starts a stand-in for the Galaxy API that serves the
+// community.general collection and the geerlingguy.docker role.
+func galaxyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /api/v3/collections/community/general/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"namespace": "community", "name": "general",
+			"highest_version": {"href": "/api/v3/collections/community/general/versions/10.1.0/", "version": "10.1.0"}}`))
+	})
+
+	mux.HandleFunc("GET /api/v3/collections/community/empty/", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"namespace": "community", "name": "empty", "highest_version": null}`))
+	})
+
+	mux.HandleFunc("GET /api/v1/roles/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("owner__username") + "." + r.URL.Query().Get("name") {
+		case "geerlingguy.docker":
+			_, _ = w.Write([]byte(`{"count": 1, "results": [{"name": "docker", "summary_fields": {"versions": [
+				{"name": "7.4.1"}, {"name": "7.10.0"}, {"name": "8.0.0-rc1"}, {"name": "7.9.2"}]}}]}`))
+		case "geerlingguy.unreleased":
+			_, _ = w.Write([]byte(`{"count": 1, "results": [{"name": "unreleased", "summary_fields": {"versions": []}}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"count": 0, "results": []}`))
+		}
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGalaxyLatestCollection(t *testing.T) {
+	server := galaxyServer(t)
+
+	tests := []struct {
+		name      string
+		server    string
+		namespace string
+		want      string
+		err       string
+	}{
+		{"server", server.URL, "general", "10.1.0", ""},
+		{"api root", server.URL + "/api/", "general", "10.1.0", ""},
+		{"no version", server.URL, "empty", "", "has no published version"},
+		{"unknown", server.URL, "missing", "", "404 Not Found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGalaxy(tt.server).LatestCollection("community", tt.namespace)
+			if got != tt.want || !matches(err, tt.err) {
+				t.Errorf("LatestCollection() = %q, %v, want %q, %q", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestGalaxyLatestRole(t *testing.T) {
+	server := galaxyServer(t)
+
+	tests := []struct {
+		name string
+		role string
+		want string
+		err  string
+	}{
+		{"released", "docker", "7.10.0", ""},
+		{"no release", "unreleased", "", "role geerlingguy.unreleased has no released version"},
+		{"unknown", "missing", "", "role geerlingguy.missing not found on " + server.URL + "/api"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGalaxy(server.URL).LatestRole("geerlingguy", tt.role)
+			if got != tt.want || !matches(err, tt.err) {
+				t.Errorf("LatestRole() = %q, %v, want %q, %q", got, err, tt.want, tt.err)
+			}
+		})
+	}
+}
+
+func TestGalaxyUnreachable(t *testing.T) {
+	server := galaxyServer(t)
+	server.Close()
+
+	if _, err := NewGalaxy(server.URL).LatestCollection("community", "general"); err == nil {
+		t.Error("LatestCollection() error = nil, want a connection error")
+	}
+}
+
+// matches reports whether err contains want, or is nil when want is empty.
+func matches(err error, want string) bool {
+	if len(want) == 0 {
+		return err == nil
+	}
+
+	return err != nil && strings.Contains(err.Error(), want)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// Tags returns the tags of the git repository url, read with
+// "git ls-remote --tags", without their "refs/tags/" prefix.
+//
+// An error is returned if git cannot reach the repository.
+func Tags(url string) ([]string, error) {
+	output, err := runner.Capture("git", "ls-remote", "--tags", url)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := []string{}
+
+	for line := range strings.Lines(output) {
+		_, ref, ok := strings.Cut(strings.TrimSpace(line), "\t")
+		if !ok || strings.HasSuffix(ref, "^{}") {
+			continue
+		}
+
+		tags = append(tags, strings.TrimPrefix(ref, "refs/tags/"))
+	}
+
+	return tags, nil
+}
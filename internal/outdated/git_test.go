@@ -0,0 +1,85 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// gitRepository creates a bare git repository with a lightweight and an
+// annotated tag and returns its path.
+func gitRepository(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	dir := t.TempDir()
+	bare := filepath.Join(dir, "role.git")
+	work := filepath.Join(dir, "work")
+
+	git := func(args ...string) {
+		t.Helper()
+
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %q: %v\n%s", args, err, out)
+		}
+	}
+
+	git("init", "--quiet", "--bare", bare)
+	git("init", "--quiet", work)
+	git("-C", work, "commit", "--quiet", "--allow-empty", "-m", "first")
+	git("-C", work, "tag", "1.0.0")
+	git("-C", work, "commit", "--quiet", "--allow-empty", "-m", "second")
+	git("-C", work, "tag", "-a", "-m", "release 1.1.0", "v1.1.0")
+	git("-C", work, "push", "--quiet", "--tags", bare, "HEAD:refs/heads/main")
+
+	return bare
+}
+
+func TestTags(t *testing.T) {
+	repository := gitRepository(t)
+
+	got, err := Tags(repository)
+	if err != nil {
+		t.Fatalf("Tags() error = %v", err)
+	}
+
+	if want := []string{"1.0.0", "v1.1.0"}; !slices.Equal(got, want) {
+		t.Errorf("Tags() = %q, want %q", got, want)
+	}
+
+	if latest := Latest(got); latest != "v1.1.0" {
+		t.Errorf("Latest(Tags()) = %q, want %q", latest, "v1.1.0")
+	}
+}
+
+func TestTagsMissingRepository(t *testing.T) {
+	gitRepository(t)
+
+	if _, err := Tags(filepath.Join(t.TempDir(), "missing.git")); err == nil {
+		t.Error("Tags() error = nil, want an error for a missing repository")
+	}
+}
@@ -0,0 +1,289 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+)
+
+// The statuses of an [Entry], from the most to the least urgent.
+const (
+	StatusNotInstalled = "not installed"
+	StatusPinMismatch  = "not at pinned version"
+	StatusOutdated     = "outdated"
+	StatusUnknown      = "unknown"
+	StatusCurrent      = "up to date"
+)
+
+// Entry is the outdated report of one role or collection of
+// requirements.yml.
+//
+// Fields:
+//   - Kind:      "role" or "collection".
+//   - Name:      the role name or the fully qualified collection name; the
+//     repository URL or path of a collection that is not installed and
+//     not from Galaxy.
+//   - Source:    where the latest version is looked up: "galaxy", the
+//     Galaxy server of the requirement, or the repository URL.
+//   - Installed: the installed version, empty when not installed.
+//   - Required:  the version constraint of requirements.yml, or the ref
+//     of a repository source.
+//   - Latest:    the latest version available from Source, empty when it
+//     could not be found.
+//   - Status:    one of the Status constants.
+//   - Error:     why Latest could not be found.
+type Entry struct {
+	Kind      string `json:"kind"`
+	Name      string `json:"name"`
+	Source    string `json:"source"`
+	Installed string `json:"installed"`
+	Required  string `json:"required"`
+	Latest    string `json:"latest"`
+	Status    string `json:"status"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Check builds the outdated report of every role and collection of
+// requirements.yml. The installed versions are read from the
+// meta/.galaxy_install_info of each role and the MANIFEST.json of each
+// collection. The latest versions come from the Galaxy server of the
+// requirement, or server when it names none, and from the tags of
+// repository sources.
+//
+// A latest version that cannot be found is recorded in the entry rather
+// than returned as an error. An error is returned if requirements.yml or
+// ansible.cfg cannot be read, or an installed version cannot be read.
+func Check(server string) ([]Entry, error) {
+	requirements, err := ansible.ReadRequirements()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &checker{server: server, servers: map[string]*Galaxy{}}
+	entries := []Entry{}
+
+	for _, r := range requirements.Roles {
+		if len(r.Include) > 0 {
+			continue
+		}
+
+		e, err := c.role(r)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	installed, err := ansible.InstalledCollections()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range requirements.Collections {
+		e, err := c.collection(r, installed)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
+
+// checker looks up the latest versions of the requirements, reusing one
+// [Galaxy] per server.
+type checker struct {
+	server  string
+	servers map[string]*Galaxy
+}
+
+// galaxy returns the [Galaxy] of the server URL.
+func (c *checker) galaxy(server string) *Galaxy {
+	if _, ok := c.servers[server]; !ok {
+		c.servers[server] = NewGalaxy(server)
+	}
+
+	return c.servers[server]
+}
+
+// role builds the entry of the role requirement r.
+func (c *checker) role(r ansible.Role) (Entry, error) {
+	e := Entry{Kind: "role", Name: r.Name, Required: r.Version}
+
+	dir, err := ansible.RoleFolder(r.Name)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	info, err := ansible.ReadRoleInstallInfo(dir)
+
+	switch {
+	case err == nil:
+		e.Installed = info.Version
+	case !os.IsNotExist(err):
+		return Entry{}, err
+	default:
+		e.Status = StatusNotInstalled
+	}
+
+	var latest error
+
+	switch {
+	case r.IsRepository():
+		e.Source, _ = ansible.SplitRepository(r.Source)
+		e.Latest, latest = latestTag(e.Source)
+	case strings.ContainsAny(r.Source, "/:"):
+		e.Source = r.Source
+		latest = errors.New("the latest version of an archive source is unknown")
+	default:
+		e.Source = "galaxy"
+
+		name := r.Name
+		if len(r.Source) > 0 {
+			name = r.Source
+		}
+
+		if namespace, role, ok := strings.Cut(name, "."); ok {
+			e.Latest, latest = c.galaxy(c.server).LatestRole(namespace, role)
+		} else {
+			latest = fmt.Errorf("'%s' is not a Galaxy 'namespace.name'", name)
+		}
+	}
+
+	settle(&e, latest)
+
+	return e, nil
+}
+
+// collection builds the entry of the collection requirement r, whose
+// installed copy is looked up in installed.
+func (c *checker) collection(r ansible.Collection, installed []ansible.InstalledCollection) (Entry, error) {
+	e := Entry{Kind: "collection", Name: r.Name, Required: r.Version, Status: StatusNotInstalled}
+
+	if r.SourceType() == "galaxy" {
+		folder, err := ansible.CollectionsFolder()
+		if err != nil {
+			return Entry{}, err
+		}
+
+		manifest, err := ansible.ReadCollectionManifest(filepath.Join(folder, filepath.FromSlash(strings.Replace(r.Name, ".", "/", 1))))
+
+		switch {
+		case err == nil:
+			e.Installed, e.Status = manifest.Version, ""
+		case !os.IsNotExist(err):
+			return Entry{}, err
+		}
+	} else {
+		for _, i := range installed {
+			if r.Installs(i.Name, i.Repository) {
+				e.Name, e.Installed, e.Status = i.Name, i.Version, ""
+				break
+			}
+		}
+	}
+
+	var latest error
+
+	switch r.SourceType() {
+	case "galaxy":
+		e.Source = "galaxy"
+		server := c.server
+
+		if len(r.Source) > 0 {
+			e.Source = r.Source
+			server = r.Source
+
+			if !strings.Contains(r.Source, "://") {
+				server, latest = ansible.GalaxyServerURL(r.Source)
+			}
+		}
+
+		if latest == nil {
+			namespace, name, _ := strings.Cut(r.Name, ".")
+			e.Latest, latest = c.galaxy(server).LatestCollection(namespace, name)
+		}
+	case "git":
+		url, ref := ansible.SplitRepository(r.Name)
+		if len(e.Required) == 0 {
+			e.Required = ref
+		}
+
+		e.Source = url
+		e.Latest, latest = latestTag(url)
+	default:
+		e.Source = r.Name
+		latest = fmt.Errorf("the latest version of a '%s' source is unknown", r.SourceType())
+	}
+
+	settle(&e, latest)
+
+	return e, nil
+}
+
+// latestTag returns the newest version tag of the repository url.
+func latestTag(url string) (string, error) {
+	tags, err := Tags(url)
+	if err != nil {
+		return "", err
+	}
+
+	latest := Latest(tags)
+	if len(latest) == 0 {
+		return "", fmt.Errorf("%s has no version tags", url)
+	}
+
+	return latest, nil
+}
+
+// settle records latest, the error of the latest version lookup, in e and
+// sets the status of an installed entry.
+func settle(e *Entry, latest error) {
+	if latest != nil {
+		e.Error = latest.Error()
+	}
+
+	if e.Status == StatusNotInstalled {
+		return
+	}
+
+	if pinned, ok := ansible.PinnedVersion(e.Required); ok {
+		if c, ok := Compare(pinned, e.Installed); ok && c != 0 {
+			e.Status = StatusPinMismatch
+			return
+		}
+	}
+
+	c, ok := Compare(e.Latest, e.Installed)
+
+	switch {
+	case !ok:
+		e.Status = StatusUnknown
+	case c > 0:
+		e.Status = StatusOutdated
+	default:
+		e.Status = StatusCurrent
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Compare compares the versions a and b and returns -1, 0 or 1 when a is
+// older than, the same as or newer than b. Versions are dot-separated
+// numbers with an optional "v" prefix and "-prerelease" or "+build"
+// suffix; a prerelease is older than its release and build metadata is
+// ignored. ok is false when either string is not a version, such as a
+// branch name.
+func Compare(a, b string) (int, bool) {
+	va, ok := parse(a)
+	if !ok {
+		return 0, false
+	}
+
+	vb, ok := parse(b)
+	if !ok {
+		return 0, false
+	}
+
+	for i := range max(len(va.numbers), len(vb.numbers)) {
+		x, y := part(va.numbers, i), part(vb.numbers, i)
+
+		if x != y {
+			if x < y {
+				return -1, true
+			}
+
+			return 1, true
+		}
+	}
+
+	switch {
+	case va.pre == vb.pre:
+		return 0, true
+	case len(va.pre) == 0:
+		return 1, true
+	case len(vb.pre) == 0:
+		return -1, true
+	default:
+		return strings.Compare(va.pre, vb.pre), true
+	}
+}
+
+// Latest returns the newest release among versions, ignoring prereleases
+// and strings that are not versions, or "" when there is none.
+func Latest(versions []string) string {
+	latest := ""
+
+	for _, v := range versions {
+		parsed, ok := parse(v)
+		if !ok || len(parsed.pre) > 0 {
+			continue
+		}
+
+		if len(latest) == 0 {
+			latest = v
+			continue
+		}
+
+		if c, _ := Compare(v, latest); c > 0 {
+			latest = v
+		}
+	}
+
+	return latest
+}
+
+// version is a parsed version string.
+type version struct {
+	numbers []int
+	pre     string
+}
+
+// parse parses v as described by [Compare].
+func parse(v string) (version, bool) {
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+
+	if len(v) == 0 {
+		return version{}, false
+	}
+
+	parsed := version{pre: pre}
+
+	for field := range strings.SplitSeq(v, ".") {
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return version{}, false
+		}
+
+		parsed.numbers = append(parsed.numbers, n)
+	}
+
+	return parsed, true
+}
+
+// part returns the i-th number of a version, 0 past its end.
+func part(numbers []int, i int) int {
+	if i < len(numbers) {
+		return numbers[i]
+	}
+
+	return 0
+}
@@ -0,0 +1,81 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outdated
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"1.2.3", "1.2.3", 0, true},
+		{"1.2.3", "1.2.4", -1, true},
+		{"1.10.0", "1.9.0", 1, true},
+		{"2.0", "1.99.99", 1, true},
+		{"1.2", "1.2.0", 0, true},
+		{"1.2", "1.2.1", -1, true},
+		{"v1.2.3", "1.2.3", 0, true},
+		{"V2.0.0", "v1.0.0", 1, true},
+		{"1.0.0-rc1", "1.0.0", -1, true},
+		{"1.0.0", "1.0.0-rc1", 1, true},
+		{"1.0.0-alpha", "1.0.0-beta", -1, true},
+		{"1.0.0+build5", "1.0.0", 0, true},
+		{"main", "1.0.0", 0, false},
+		{"1.0.0", "", 0, false},
+		{"1.x", "1.0", 0, false},
+		{"1.-1", "1.0", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.a+" vs "+tt.b, func(t *testing.T) {
+			got, ok := Compare(tt.a, tt.b)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("Compare(%q, %q) = %d, %v, want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestLatest(t *testing.T) {
+	tests := []struct {
+		name     string
+		versions []string
+		want     string
+	}{
+		{"none", nil, ""},
+		{"single", []string{"1.0.0"}, "1.0.0"},
+		{"numeric order", []string{"1.9.0", "1.10.0", "1.2.0"}, "1.10.0"},
+		{"prefix kept", []string{"v1.0.0", "v2.1.0", "v2.0.0"}, "v2.1.0"},
+		{"prereleases ignored", []string{"1.0.0", "2.0.0-rc1"}, "1.0.0"},
+		{"branches ignored", []string{"main", "1.0.0", "develop"}, "1.0.0"},
+		{"only prereleases and branches", []string{"main", "2.0.0-beta"}, ""},
+		{"first of equal versions", []string{"1.0", "1.0.0"}, "1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Latest(slices.Clone(tt.versions)); got != tt.want {
+				t.Errorf("Latest(%q) = %q, want %q", tt.versions, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import "strings"
+
+// Command is a single program invocation as seen by a [Runner]. Env holds
+// any extra "KEY=value" environment variables.
+type Command struct {
+	Env     []string
+	Program string
+	Args    []string
+}
+
+// String renders the command as a shell-style line, preceded by its extra
+// environment variables. Arguments containing whitespace or quote
+// characters are wrapped in single quotes so the output can be copied and
+// pasted into a terminal.
+func (c Command) String() string {
+	var parts []string
+
+	for _, e := range c.Env {
+		parts = append(parts, Quote(e))
+	}
+
+	parts = append(parts, Quote(c.Program))
+
+	for _, a := range c.Args {
+		parts = append(parts, Quote(a))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Quote returns s quoted for a POSIX shell. Strings without whitespace,
+// quotes or shell metacharacters are returned unchanged; anything else is
+// wrapped in single quotes.
+func Quote(s string) string {
+	if len(s) == 0 {
+		return "''"
+	}
+
+	if !strings.ContainsAny(s, " \t\n'\"$`\\;&|<>*?") {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
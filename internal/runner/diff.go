@@ -0,0 +1,171 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// Diff returns the unified diff ("diff -u") turning before into after, with
+// "a/<name>" and "b/<name>" headers, as lines. No lines are returned when
+// the contents are equal.
+func Diff(name string, before, after []byte) []string {
+	a := splitLines(before)
+	b := splitLines(after)
+
+	ops := diffOps(a, b)
+
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	lines := []string{"--- a/" + name, "+++ b/" + name}
+
+	for start := 0; start < len(ops); {
+		// Find the next change and the extent of its hunk, merging changes
+		// separated by at most twice the context.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+
+		if first == len(ops) {
+			break
+		}
+
+		from := max(first-diffContext, start)
+		to := first
+
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				to = i + 1
+				continue
+			}
+
+			if i-to >= 2*diffContext {
+				break
+			}
+		}
+
+		to = min(to+diffContext, len(ops))
+
+		lines = append(lines, hunk(ops[from:to])...)
+		start = to
+	}
+
+	return lines
+}
+
+// diffOp is a single line of a diff: ' ' for a line in both inputs, '-'
+// for a line only in the first and '+' for a line only in the second. aLine
+// and bLine are the 1-based line numbers the operation starts at.
+type diffOp struct {
+	kind  byte
+	text  string
+	aLine int
+	bLine int
+}
+
+// diffOps aligns a and b on their longest common subsequence of lines.
+func diffOps(a, b []string) []diffOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{' ', a[i], i + 1, j + 1})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', a[i], i + 1, j + 1})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', b[j], i + 1, j + 1})
+			j++
+		}
+	}
+
+	return ops
+}
+
+// hunk renders ops as a unified diff hunk with its "@@" header.
+func hunk(ops []diffOp) []string {
+	aCount, bCount := 0, 0
+
+	body := make([]string, 0, len(ops))
+
+	for _, op := range ops {
+		if op.kind != '+' {
+			aCount++
+		}
+
+		if op.kind != '-' {
+			bCount++
+		}
+
+		body = append(body, string(op.kind)+op.text)
+	}
+
+	aStart, bStart := ops[0].aLine, ops[0].bLine
+	if aCount == 0 {
+		aStart--
+	}
+
+	if bCount == 0 {
+		bStart--
+	}
+
+	return append([]string{fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, aCount, bStart, bCount)}, body...)
+}
+
+// splitLines splits content into lines without their line endings.
+func splitLines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+}
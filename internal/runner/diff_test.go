@@ -0,0 +1,120 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// numbers returns the lines "1" to "n", with replace applied to them.
+func numbers(n int, replace map[int]string) []byte {
+	var b strings.Builder
+
+	for i := 1; i <= n; i++ {
+		line, ok := replace[i]
+		if !ok {
+			line = fmt.Sprint(i)
+		}
+
+		b.WriteString(line + "\n")
+	}
+
+	return []byte(b.String())
+}
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []byte
+		after  []byte
+		want   []string
+	}{
+		{
+			name:   "equal",
+			before: numbers(5, nil),
+			after:  numbers(5, nil),
+		},
+		{
+			name:   "new file",
+			before: nil,
+			after:  numbers(2, nil),
+			want:   []string{"@@ -0,0 +1,2 @@", "+1", "+2"},
+		},
+		{
+			name:   "emptied file",
+			before: numbers(2, nil),
+			after:  nil,
+			want:   []string{"@@ -1,2 +0,0 @@", "-1", "-2"},
+		},
+		{
+			name:   "appended line",
+			before: numbers(3, nil),
+			after:  numbers(4, nil),
+			want:   []string{"@@ -1,3 +1,4 @@", " 1", " 2", " 3", "+4"},
+		},
+		{
+			name:   "changed line",
+			before: numbers(10, nil),
+			after:  numbers(10, map[int]string{5: "five"}),
+			want:   []string{"@@ -2,7 +2,7 @@", " 2", " 3", " 4", "-5", "+five", " 6", " 7", " 8"},
+		},
+		{
+			name:   "changes merged into one hunk",
+			before: numbers(20, nil),
+			after:  numbers(20, map[int]string{5: "five", 12: "twelve"}),
+			want: []string{
+				"@@ -2,14 +2,14 @@",
+				" 2", " 3", " 4", "-5", "+five", " 6", " 7", " 8", " 9", " 10", " 11",
+				"-12", "+twelve", " 13", " 14", " 15",
+			},
+		},
+		{
+			name:   "changes in separate hunks",
+			before: numbers(20, nil),
+			after:  numbers(20, map[int]string{5: "five", 13: "thirteen"}),
+			want: []string{
+				"@@ -2,7 +2,7 @@", " 2", " 3", " 4", "-5", "+five", " 6", " 7", " 8",
+				"@@ -10,7 +10,7 @@", " 10", " 11", " 12", "-13", "+thirteen", " 14", " 15", " 16",
+			},
+		},
+		{
+			name:   "changes at the edges",
+			before: numbers(20, nil),
+			after:  numbers(20, map[int]string{2: "two", 18: "eighteen"}),
+			want: []string{
+				"@@ -1,5 +1,5 @@", " 1", "-2", "+two", " 3", " 4", " 5",
+				"@@ -15,6 +15,6 @@", " 15", " 16", " 17", "-18", "+eighteen", " 19", " 20",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if len(want) > 0 {
+				want = append([]string{"--- a/numbers.txt", "+++ b/numbers.txt"}, want...)
+			}
+
+			if got := Diff("numbers.txt", tt.before, tt.after); !slices.Equal(got, want) {
+				t.Errorf("Diff() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runner abstracts every side effect ansible-dev performs on behalf
+// of the user — launching external programs such as vagrant, ansible-playbook,
+// ansible-galaxy, git and gh, and writing or removing generated files such as
+// .tmp/play.yml, hosts.ini and requirements.yml — behind the [Runner]
+// interface.
+//
+// Three implementations are provided:
+//   - [Exec]:     the real implementation, which delegates to go-toolbox's
+//     execute and filesystem helpers.
+//   - [DryRun]:   prints the exact command lines and file writes that would
+//     be performed without executing them. Selected by the global --dry-run
+//     flag.
+//   - [Recorder]: records every call in memory and returns canned output,
+//     allowing callers to be exercised without the real tools installed.
+//
+// The internal/ansible and internal/vagrant packages call the package-level
+// helpers ([Run], [Capture], [WriteFile], ...), which dispatch to the runner
+// selected with [Set].
+package runner
@@ -0,0 +1,155 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/dcjulian29/go-toolbox/filesystem"
+)
+
+// DryRun is the [Runner] selected by the global --dry-run flag. Instead of
+// executing commands or touching the filesystem it prints what would be done
+// to its writer, one "[dry-run]" line per operation. File writes are followed
+// by the indented content that would have been written, or by a unified
+// diff when the file already exists.
+//
+// Written and removed paths are tracked in memory so that [DryRun.FileExist]
+// answers as if the operations had happened; this lets a caller that
+// generates .tmp/play.yml and then checks for it preview the full sequence.
+type DryRun struct {
+	mu      sync.Mutex
+	out     io.Writer
+	written map[string]bool
+}
+
+// NewDryRun returns a [DryRun] runner that prints to w.
+func NewDryRun(w io.Writer) *DryRun {
+	return &DryRun{
+		out:     w,
+		written: map[string]bool{},
+	}
+}
+
+// Run prints the command line.
+func (d *DryRun) Run(program string, args ...string) error {
+	d.printf("%s\n", Command{Program: program, Args: args})
+
+	return nil
+}
+
+// Capture prints the command line and returns empty output.
+func (d *DryRun) Capture(program string, args ...string) (string, error) {
+	d.printf("%s\n", Command{Program: program, Args: args})
+
+	return "", nil
+}
+
+// CaptureEnv prints the command line, prefixed with its environment, and
+// returns empty output.
+func (d *DryRun) CaptureEnv(env []string, program string, args ...string) (string, error) {
+	d.printf("%s\n", Command{Env: env, Program: program, Args: args})
+
+	return "", nil
+}
+
+// Stream prints the command line to w.
+func (d *DryRun) Stream(w io.Writer, program string, args ...string) error {
+	fmt.Fprintf(w, "[dry-run] %s\n", Command{Program: program, Args: args}) //nolint:errcheck
+
+	return nil
+}
+
+// WriteFile prints the file name and the content that would be written.
+// When the file already exists a unified diff of the change is printed
+// instead (see [Diff]).
+func (d *DryRun) WriteFile(name string, content []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.written[name] = true
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+
+	if before, err := os.ReadFile(name); err == nil {
+		if lines = Diff(name, before, content); len(lines) == 0 {
+			fmt.Fprintf(d.out, "[dry-run] write %s (unchanged)\n", name) //nolint:errcheck
+			return nil
+		}
+	}
+
+	fmt.Fprintf(d.out, "[dry-run] write %s\n", name) //nolint:errcheck
+
+	for _, line := range lines {
+		fmt.Fprintln(d.out, strings.TrimRight("[dry-run]   | "+line, " ")) //nolint:errcheck
+	}
+
+	return nil
+}
+
+// MkdirAll prints the directory that would be created.
+func (d *DryRun) MkdirAll(path string) error {
+	if filesystem.DirectoryExist(path) {
+		return nil
+	}
+
+	d.printf("mkdir -p %s\n", Quote(path))
+
+	return nil
+}
+
+// Remove prints the path that would be removed.
+func (d *DryRun) Remove(path string) error {
+	d.mu.Lock()
+	d.written[path] = false
+	d.mu.Unlock()
+
+	d.printf("rm -rf %s\n", Quote(path))
+
+	return nil
+}
+
+// CopyDir prints the copy that would be performed.
+func (d *DryRun) CopyDir(src, dest string) error {
+	d.printf("cp -r %s %s\n", Quote(src), Quote(dest))
+
+	return nil
+}
+
+// FileExist reports whether name would exist had the previous operations
+// been performed.
+func (d *DryRun) FileExist(name string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if written, ok := d.written[name]; ok {
+		return written
+	}
+
+	return filesystem.FileExist(name)
+}
+
+func (d *DryRun) printf(format string, a ...any) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	fmt.Fprintf(d.out, "[dry-run] "+format, a...) //nolint:errcheck
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/go-toolbox/filesystem"
+)
+
+// Exec is the [Runner] that actually performs every operation. It is the
+// default runner.
+type Exec struct{}
+
+// Run executes program via [execute.ExternalProgram].
+func (Exec) Run(program string, args ...string) error {
+	return execute.ExternalProgram(program, args...)
+}
+
+// Capture executes program via [execute.ExternalProgramCapture].
+func (Exec) Capture(program string, args ...string) (string, error) {
+	return execute.ExternalProgramCapture(program, args...)
+}
+
+// CaptureEnv executes program with env appended to the current environment
+// and returns its standard output. Standard error is passed through to the
+// terminal.
+func (Exec) CaptureEnv(env []string, program string, args ...string) (string, error) {
+	cmd := exec.Command(program, args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stderr = os.Stderr
+
+	out, err := cmd.Output()
+
+	return string(out), err
+}
+
+// Stream executes program with both its standard output and standard error
+// written to w. Standard input is not attached.
+func (Exec) Stream(w io.Writer, program string, args ...string) error {
+	cmd := exec.Command(program, args...)
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	return cmd.Run()
+}
+
+// WriteFile creates the parent directory of name if needed and writes
+// content to it with mode 0644.
+func (Exec) WriteFile(name string, content []byte) error {
+	if dir := filepath.Dir(name); dir != "." {
+		if err := filesystem.EnsureDirectoryExist(dir); err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(name, content, 0o644)
+}
+
+// MkdirAll ensures path exists.
+func (Exec) MkdirAll(path string) error {
+	return filesystem.EnsureDirectoryExist(path)
+}
+
+// Remove deletes path and anything below it. A missing path is not an error.
+func (Exec) Remove(path string) error {
+	return os.RemoveAll(path)
+}
+
+// CopyDir recursively copies src into dest.
+func (Exec) CopyDir(src, dest string) error {
+	return os.CopyFS(dest, os.DirFS(src))
+}
+
+// FileExist reports whether the file name exists on disk.
+func (Exec) FileExist(name string) bool {
+	return filesystem.FileExist(name)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"bytes"
+	"io"
+	"sync"
+)
+
+// outputMu serializes the lines written by every [PrefixWriter] so that
+// concurrent programs never interleave within a line.
+var outputMu sync.Mutex
+
+// PrefixWriter is an [io.Writer] that buffers its input and writes it to an
+// underlying writer one complete line at a time, each line prefixed with a
+// fixed string such as "[debian] ". It is used to tell apart the output of
+// programs that run concurrently.
+type PrefixWriter struct {
+	mu     sync.Mutex
+	out    io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+// NewPrefixWriter returns a [PrefixWriter] that writes to w.
+func NewPrefixWriter(w io.Writer, prefix string) *PrefixWriter {
+	return &PrefixWriter{
+		out:    w,
+		prefix: []byte(prefix),
+	}
+}
+
+// Write buffers p and emits every complete line. Carriage returns used by
+// progress bars are treated as line ends.
+func (p *PrefixWriter) Write(data []byte) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.buf = append(p.buf, bytes.ReplaceAll(data, []byte("\r"), []byte("\n"))...)
+
+	for {
+		i := bytes.IndexByte(p.buf, '\n')
+		if i < 0 {
+			break
+		}
+
+		line := p.buf[:i]
+		p.buf = p.buf[i+1:]
+
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		if err := p.emit(line); err != nil {
+			return len(data), err
+		}
+	}
+
+	return len(data), nil
+}
+
+// Flush writes any buffered partial line.
+func (p *PrefixWriter) Flush() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(bytes.TrimSpace(p.buf)) == 0 {
+		p.buf = nil
+		return nil
+	}
+
+	line := p.buf
+	p.buf = nil
+
+	return p.emit(line)
+}
+
+func (p *PrefixWriter) emit(line []byte) error {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	out := make([]byte, 0, len(p.prefix)+len(line)+1)
+	out = append(out, p.prefix...)
+	out = append(out, line...)
+	out = append(out, '\n')
+
+	_, err := p.out.Write(out)
+
+	return err
+}
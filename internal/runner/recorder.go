@@ -0,0 +1,150 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"io"
+	"sync"
+)
+
+// Recorder is a [Runner] that performs nothing and records every call, so
+// that code built on the package-level helpers can be exercised without
+// vagrant, ansible or git installed.
+//
+// Canned results are looked up by the command's [Command.String] form:
+// Outputs supplies what [Recorder.Capture] returns and Errors supplies the
+// error returned by either Run or Capture.
+type Recorder struct {
+	mu sync.Mutex
+
+	Commands []Command
+	Files    map[string][]byte
+	Removed  []string
+	Copied   [][2]string
+	Outputs  map[string]string
+	Errors   map[string]error
+}
+
+// NewRecorder returns an empty [Recorder].
+func NewRecorder() *Recorder {
+	return &Recorder{
+		Files:   map[string][]byte{},
+		Outputs: map[string]string{},
+		Errors:  map[string]error{},
+	}
+}
+
+// Run records the command and returns its canned error, if any.
+func (r *Recorder) Run(program string, args ...string) error {
+	_, err := r.Capture(program, args...)
+
+	return err
+}
+
+// Capture records the command and returns its canned output and error.
+func (r *Recorder) Capture(program string, args ...string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := Command{Program: program, Args: append([]string(nil), args...)}
+	r.Commands = append(r.Commands, c)
+
+	return r.Outputs[c.String()], r.Errors[c.String()]
+}
+
+// CaptureEnv records the command with its environment and returns its
+// canned output and error.
+func (r *Recorder) CaptureEnv(env []string, program string, args ...string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	c := Command{Env: append([]string(nil), env...), Program: program, Args: append([]string(nil), args...)}
+	r.Commands = append(r.Commands, c)
+
+	return r.Outputs[c.String()], r.Errors[c.String()]
+}
+
+// Stream records the command, writes its canned output to w, and returns
+// its canned error, if any.
+func (r *Recorder) Stream(w io.Writer, program string, args ...string) error {
+	out, err := r.Capture(program, args...)
+
+	if len(out) > 0 {
+		_, _ = io.WriteString(w, out)
+	}
+
+	return err
+}
+
+// WriteFile records the content written to name.
+func (r *Recorder) WriteFile(name string, content []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Files[name] = append([]byte(nil), content...)
+
+	return nil
+}
+
+// MkdirAll does nothing.
+func (r *Recorder) MkdirAll(_ string) error {
+	return nil
+}
+
+// Remove records the removed path and forgets any content written to it.
+func (r *Recorder) Remove(path string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.Files, path)
+	r.Removed = append(r.Removed, path)
+
+	return nil
+}
+
+// CopyDir records the copy.
+func (r *Recorder) CopyDir(src, dest string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.Copied = append(r.Copied, [2]string{src, dest})
+
+	return nil
+}
+
+// FileExist reports whether name has been written through the recorder.
+func (r *Recorder) FileExist(name string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.Files[name]
+
+	return ok
+}
+
+// Lines returns the recorded commands rendered with [Command.String].
+func (r *Recorder) Lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	lines := make([]string, 0, len(r.Commands))
+	for _, c := range r.Commands {
+		lines = append(lines, c.String())
+	}
+
+	return lines
+}
@@ -0,0 +1,130 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"io"
+	"sync"
+)
+
+// Runner performs the side effects requested by ansible-dev. Implementations
+// must be safe for concurrent use.
+//
+// Methods:
+//   - Run:       execute a program with its standard streams attached.
+//   - Capture:   execute a program and return its standard output.
+//   - CaptureEnv: as Capture, with extra "KEY=value" environment
+//     variables added to the program's environment.
+//   - Stream:    execute a program with its standard output and error
+//     written to w instead of the terminal, so that several programs can
+//     run concurrently with distinguishable output.
+//   - WriteFile: create or overwrite a file, creating parent directories.
+//   - MkdirAll:  ensure a directory (and its parents) exists.
+//   - Remove:    remove a file or directory tree; missing paths are ignored.
+//   - CopyDir:   recursively copy the directory src to dest.
+//   - FileExist: report whether a file exists, taking into account writes
+//     and removals performed through the runner.
+type Runner interface {
+	Run(program string, args ...string) error
+	Capture(program string, args ...string) (string, error)
+	CaptureEnv(env []string, program string, args ...string) (string, error)
+	Stream(w io.Writer, program string, args ...string) error
+	WriteFile(name string, content []byte) error
+	MkdirAll(path string) error
+	Remove(path string) error
+	CopyDir(src, dest string) error
+	FileExist(name string) bool
+}
+
+var (
+	mu      sync.RWMutex
+	current Runner = Exec{}
+)
+
+// Set replaces the runner used by the package-level helpers. It is called
+// once by the root command when --dry-run is given, and by callers that want
+// to substitute a [Recorder].
+func Set(r Runner) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	current = r
+}
+
+// Current returns the runner used by the package-level helpers.
+func Current() Runner {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	return current
+}
+
+// IsDryRun reports whether the current runner is a [DryRun], allowing callers
+// to skip steps that depend on the real outcome of a command (for example,
+// discovering a VM's address after "vagrant up").
+func IsDryRun() bool {
+	_, ok := Current().(*DryRun)
+	return ok
+}
+
+// Run executes program with args using the current runner.
+func Run(program string, args ...string) error {
+	return Current().Run(program, args...)
+}
+
+// Capture executes program with args using the current runner and returns
+// its standard output.
+func Capture(program string, args ...string) (string, error) {
+	return Current().Capture(program, args...)
+}
+
+// CaptureEnv executes program with args and the extra environment
+// variables env using the current runner and returns its standard output.
+func CaptureEnv(env []string, program string, args ...string) (string, error) {
+	return Current().CaptureEnv(env, program, args...)
+}
+
+// Stream executes program with args using the current runner, writing its
+// output to w.
+func Stream(w io.Writer, program string, args ...string) error {
+	return Current().Stream(w, program, args...)
+}
+
+// WriteFile writes content to name using the current runner.
+func WriteFile(name string, content []byte) error {
+	return Current().WriteFile(name, content)
+}
+
+// MkdirAll ensures path exists using the current runner.
+func MkdirAll(path string) error {
+	return Current().MkdirAll(path)
+}
+
+// Remove removes path using the current runner.
+func Remove(path string) error {
+	return Current().Remove(path)
+}
+
+// CopyDir copies the directory src to dest using the current runner.
+func CopyDir(src, dest string) error {
+	return Current().CopyDir(src, dest)
+}
+
+// FileExist reports whether name exists according to the current runner.
+func FileExist(name string) bool {
+	return Current().FileExist(name)
+}
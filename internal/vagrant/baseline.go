@@ -0,0 +1,108 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"fmt"
+
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// BaselineSnapshot is the name of the snapshot taken right after a VM
+// first boots successfully. Restoring it returns the VM to a freshly
+// created, unprovisioned state.
+const BaselineSnapshot = "baseline"
+
+// SaveBaseline saves a [BaselineSnapshot] of every named VM that does not
+// have one yet. VMs whose provider does not support snapshots are skipped
+// with a warning, so that a baseline is only ever recorded after the first
+// successful boot and never overwritten by later runs.
+func SaveBaseline(names []string) error {
+	providers, err := providers()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if !SupportsSnapshots(providers[name]) {
+			fmt.Println(textformat.Yellow(fmt.Sprintf(
+				"\nProvider '%s' of '%s' does not support snapshots; no baseline recorded.", providers[name], name)))
+
+			continue
+		}
+
+		if HasSnapshot(name, BaselineSnapshot) {
+			continue
+		}
+
+		if err := SaveSnapshot(name, BaselineSnapshot); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RestoreBaseline restores the [BaselineSnapshot] of every named VM. It
+// returns false without changing any VM when a baseline cannot be used for
+// all of them, because the provider lacks snapshot support or the
+// snapshot was never taken; callers should then fall back to destroying
+// and recreating the environment.
+func RestoreBaseline(names []string) (bool, error) {
+	providers, err := providers()
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range names {
+		if !SupportsSnapshots(providers[name]) {
+			fmt.Println(textformat.Yellow(fmt.Sprintf(
+				"\nProvider '%s' of '%s' does not support snapshots.", providers[name], name)))
+
+			return false, nil
+		}
+
+		if !HasSnapshot(name, BaselineSnapshot) {
+			fmt.Println(textformat.Yellow(fmt.Sprintf("\n'%s' has no '%s' snapshot.", name, BaselineSnapshot)))
+
+			return false, nil
+		}
+	}
+
+	for _, name := range names {
+		if err := RestoreSnapshot(name, BaselineSnapshot); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// providers maps each machine reported by [Status] to its provider.
+func providers() (map[string]string, error) {
+	statuses, err := Status()
+	if err != nil {
+		return nil, err
+	}
+
+	providers := make(map[string]string, len(statuses))
+	for _, s := range statuses {
+		providers[s.Name] = s.Provider
+	}
+
+	return providers, nil
+}
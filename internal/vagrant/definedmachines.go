@@ -0,0 +1,53 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"os"
+	"regexp"
+)
+
+// vmDefine matches a machine definition in a Vagrantfile, e.g.
+// `config.vm.define "debian" do |c|` or `config.vm.define :alma`.
+var vmDefine = regexp.MustCompile(`(?m)^\s*\w+\.vm\.define\s*\(?\s*(?:"([^"]+)"|'([^']+)'|:(\w+))`)
+
+// DefinedMachines returns the names of the machines defined in the
+// Vagrantfile of the current directory, in file order. Both Vagrantfiles
+// rendered by [RenderVagrantfile] and hand-written ones are understood, as
+// long as each machine is declared with a literal "vm.define" name. A
+// missing Vagrantfile defines no machines.
+//
+// Machines defined in a loop or through variables are not found; use
+// [MachineNames], which asks Vagrant, unless vagrant cannot be run.
+func DefinedMachines() ([]string, error) {
+	data, err := os.ReadFile("Vagrantfile")
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	var names []string
+
+	for _, m := range vmDefine.FindAllStringSubmatch(string(data), -1) {
+		names = append(names, m[1]+m[2]+m[3])
+	}
+
+	return names, nil
+}
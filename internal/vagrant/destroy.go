@@ -17,8 +17,7 @@ limitations under the License.
 package vagrant
 
 import (
-	"github.com/dcjulian29/go-toolbox/execute"
-	"github.com/dcjulian29/go-toolbox/filesystem"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // Destroy tears down the Vagrant environment and removes all local
@@ -31,24 +30,32 @@ import (
 //  4. Removes the ".tmp" directory used for generated playbook files
 //     (see [ansible.GenerateRolePlay] and [ansible.GeneratePlaybookPlay]).
 //
+// When one or more names are given only those VMs are destroyed with
+// "vagrant destroy --force <name>..." and the shared artifacts are kept,
+// since the remaining VMs still use them.
+//
 // Execution stops and the first encountered error is returned if any step
 // fails. A nil return indicates the environment was fully cleaned up.
-func Destroy() error {
-	param := []string{"destroy", "--force"}
+func Destroy(names ...string) error {
+	param := append([]string{"destroy", "--force"}, names...)
 
-	if err := execute.ExternalProgram("vagrant", param...); err != nil {
+	if err := runner.Run("vagrant", param...); err != nil {
 		return err
 	}
 
-	if err := filesystem.RemoveFile("ansible.log"); err != nil {
+	if len(names) > 0 {
+		return nil
+	}
+
+	if err := runner.Remove("ansible.log"); err != nil {
 		return err
 	}
 
-	if err := filesystem.RemoveDirectory(".vagrant"); err != nil {
+	if err := runner.Remove(".vagrant"); err != nil {
 		return err
 	}
 
-	if err := filesystem.RemoveDirectory(".tmp"); err != nil {
+	if err := runner.Remove(".tmp"); err != nil {
 		return err
 	}
 
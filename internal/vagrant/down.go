@@ -18,42 +18,34 @@ package vagrant
 
 import (
 	"fmt"
-	"strings"
 
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/textformat"
-	"gopkg.in/ini.v1"
 )
 
-// Down gracefully stops every Vagrant VM listed in the [vagrant] section of
-// the local hosts.ini inventory file by running "vagrant halt <name>" for
-// each entry.
-//
-// Host names are extracted from the INI keys by taking the first
-// whitespace-delimited token (matching the convention used by
-// [ansible.GetInventory]). A yellow status line is printed to stdout before
+// Down gracefully stops the named Vagrant VMs by running
+// "vagrant halt <name>" for each. When no name is given every host of the
+// [vagrant] group of the local hosts.ini inventory file is stopped (see
+// [ansible.SelectHosts]). A yellow status line is printed to stdout before
 // each VM is halted.
 //
-// An error is returned if hosts.ini cannot be loaded, the [vagrant] section
+// An error is returned if hosts.ini cannot be loaded, the [vagrant] group
 // is missing, or any individual "vagrant halt" invocation fails. Execution
 // stops at the first failure, leaving remaining VMs in their current state.
-func Down() error {
-	inv, err := ini.Load("hosts.ini")
-	if err != nil {
-		return err
-	}
+func Down(names ...string) error {
+	if len(names) == 0 {
+		var err error
 
-	section, err := inv.GetSection("vagrant")
-	if err != nil {
-		return err
+		if names, err = ansible.SelectHosts(nil); err != nil {
+			return err
+		}
 	}
 
-	for _, vm := range section.KeyStrings() {
-		name := strings.Split(vm, " ")[0]
-
+	for _, name := range names {
 		fmt.Printf(textformat.Yellow("\nStopping '%s'...\n\n"), name)
 
-		err := execute.ExternalProgram("vagrant", "halt", name)
+		err := runner.Run("vagrant", "halt", name)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,54 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"os"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// MachineNames returns the names of the machines of the Vagrant
+// environment in the current directory as Vagrant itself evaluates the
+// Vagrantfile, via "vagrant status --machine-readable" (see [Status]), so
+// that machines defined in a loop or through variables are found. During a
+// dry run, when vagrant is not executed, they are read from the
+// Vagrantfile instead (see [DefinedMachines]). A missing Vagrantfile
+// defines no machines.
+//
+// An error is returned if the Vagrantfile cannot be read or vagrant fails.
+func MachineNames() ([]string, error) {
+	if runner.IsDryRun() {
+		return DefinedMachines()
+	}
+
+	if _, err := os.Stat("Vagrantfile"); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	statuses, err := Status()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		names = append(names, s.Name)
+	}
+
+	return names, nil
+}
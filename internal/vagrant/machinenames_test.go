@@ -0,0 +1,122 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// loopVagrantfile defines a machine literally and two in a loop, which
+// only Vagrant itself can list.
+const loopVagrantfile = `Vagrant.configure("2") do |config|
+  config.vm.define "debian" do |c|
+    c.vm.box = "debian/bookworm64"
+  end
+
+  %w[web1 web2].each do |name|
+    config.vm.define name do |c|
+      c.vm.box = "almalinux/9"
+    end
+  end
+end
+`
+
+// loopStatus is the output of "vagrant status --machine-readable" for
+// loopVagrantfile.
+const loopStatus = `1760000000,debian,metadata,provider,virtualbox
+1760000000,debian,provider-name,virtualbox
+1760000000,debian,state,running
+1760000000,web1,provider-name,virtualbox
+1760000000,web1,state,not_created
+1760000000,web2,provider-name,virtualbox
+1760000000,web2,state,poweroff
+1760000000,,ui,info,Current machine states:
+`
+
+func TestMachineNames(t *testing.T) {
+	const status = "vagrant status --machine-readable"
+
+	tests := []struct {
+		name        string
+		vagrantfile string
+		dryRun      bool
+		err         error
+		want        []string
+		commands    []string
+		wantErr     bool
+	}{
+		{
+			name:        "machines reported by vagrant",
+			vagrantfile: loopVagrantfile,
+			want:        []string{"debian", "web1", "web2"},
+			commands:    []string{status},
+		},
+		{
+			name:        "literal definitions during a dry run",
+			vagrantfile: loopVagrantfile,
+			dryRun:      true,
+			want:        []string{"debian"},
+		},
+		{
+			name: "no Vagrantfile",
+		},
+		{
+			name:        "vagrant fails",
+			vagrantfile: loopVagrantfile,
+			err:         errors.New("exit status 1"),
+			commands:    []string{status},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+			rec.Outputs[status] = loopStatus
+			rec.Errors[status] = tt.err
+
+			if len(tt.vagrantfile) > 0 {
+				if err := os.WriteFile("Vagrantfile", []byte(tt.vagrantfile), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if tt.dryRun {
+				runner.Set(runner.NewDryRun(io.Discard))
+			}
+
+			got, err := MachineNames()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MachineNames() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("MachineNames() = %q, want %q", got, tt.want)
+			}
+
+			if lines := rec.Lines(); !slices.Equal(lines, tt.commands) {
+				t.Errorf("commands = %q, want %q", lines, tt.commands)
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+)
+
+// RenderVagrantfile returns the content of the development Vagrantfile for
+// the given machines. The generated configuration defines a multi-VM setup
+// with the following characteristics:
+//
+//   - Provider selection: Hyper-V or VirtualBox on Windows, libvirt or
+//     VirtualBox on Linux, VirtualBox elsewhere.
+//   - Shared settings: insecure SSH key insertion disabled, synced folders
+//     disabled, 3600-second boot timeout.
+//   - Providers: vm.CPUs CPUs and vm.Memory MB RAM for every machine
+//     (VM_CPUS / VM_MEMORY), headless VirtualBox with VMSVGA graphics,
+//     IOAPIC enabled, and guest additions checking disabled.
+//   - One VM definition per machine, using its box, a "<name>.dev"
+//     hostname, its extra networks, and per-machine CPU/memory overrides
+//     applied to every provider.
+//
+// Names, boxes and network values are written as escaped Ruby string
+// literals (see [rubyString]), so that a quote in a value cannot make the
+// Vagrantfile invalid. The networks are expected to be valid (see
+// [config.Network.Validate]).
+func RenderVagrantfile(vm config.VM, machines []config.Machine) []byte {
+	var defines strings.Builder
+
+	for _, m := range machines {
+		fmt.Fprintf(&defines, `
+  config.vm.define %s do |c|
+    c.vm.box      = %s
+    c.vm.hostname = %s
+`, rubyString(m.Name), rubyString(m.Box), rubyString(m.Name+".dev"))
+
+		for _, n := range m.Networks {
+			fmt.Fprintf(&defines, "    c.vm.network %s\n", networkArgs(n))
+		}
+
+		if m.CPUs > 0 || m.Memory > 0 {
+			defines.WriteString(`
+    %w[virtualbox hyperv libvirt].each do |provider|
+      c.vm.provider provider do |p|
+`)
+
+			if m.CPUs > 0 {
+				fmt.Fprintf(&defines, "        p.cpus   = %d\n", m.CPUs)
+			}
+
+			if m.Memory > 0 {
+				fmt.Fprintf(&defines, "        p.memory = %d\n", m.Memory)
+			}
+
+			defines.WriteString("      end\n    end\n")
+		}
+
+		defines.WriteString("  end\n")
+	}
+
+	return []byte(fmt.Sprintf(`# Generated by ansible-dev from the "machines" configuration.
+# Use "ansible-dev vm" to change the machines and regenerate this file.
+
+VM_CPUS   = %d
+VM_MEMORY = %d
+
+host = Vagrant::Util::Platform.platform
+
+if host =~ /mswin|mingw|cygwin/
+  hyperv_available = File.exist?("C:/Windows/System32/vmms.exe")
+  ENV["VAGRANT_DEFAULT_PROVIDER"] ||= (hyperv_available ? "hyperv" : "virtualbox")
+elsif host =~ /linux/
+  libvirt_ok = system("virsh --version >/dev/null 2>&1")
+  ENV["VAGRANT_DEFAULT_PROVIDER"] ||= (libvirt_ok ? "libvirt" : "virtualbox")
+else
+  ENV["VAGRANT_DEFAULT_PROVIDER"] ||= "virtualbox"
+end
+
+Vagrant.configure("2") do |config|
+  config.ssh.insert_key = false
+  if Vagrant.has_plugin?("vagrant-vbguest")
+    config.vbguest.auto_update = false
+  end
+  config.vm.boot_timeout = 3600
+  config.vm.box_check_update = true
+  config.vm.synced_folder ".", "/vagrant", disabled: true
+
+  if ENV["VAGRANT_DEFAULT_PROVIDER"] == "hyperv"
+    config.vm.network "public_network", bridge: "Default Switch"
+  end
+
+  config.vm.provider "virtualbox" do |vb|
+    vb.gui    = false
+    vb.cpus   = VM_CPUS
+    vb.memory = VM_MEMORY
+    vb.check_guest_additions = false
+    vb.customize [ "modifyvm", :id, "--uartmode1", "disconnected" ]
+    vb.customize [ "modifyvm", :id, "--graphicscontroller", "vmsvga" ]
+    vb.customize [ "modifyvm", :id, "--ioapic", "on" ]
+  end
+
+  config.vm.provider "hyperv" do |hv|
+    hv.cpus   = VM_CPUS
+    hv.memory = VM_MEMORY
+    hv.enable_enhanced_session_mode = false
+    hv.auto_start_action = "Nothing"
+  end
+
+  config.vm.provider "libvirt" do |lv|
+    lv.cpus   = VM_CPUS
+    lv.memory = VM_MEMORY
+    lv.driver = "kvm"
+  end
+%send
+`, vm.CPUs, vm.Memory, defines.String()))
+}
+
+// networkArgs renders the arguments of a "c.vm.network" line. A private
+// network without a static address falls back to DHCP.
+func networkArgs(n config.Network) string {
+	args := []string{rubyString(n.Type)}
+
+	if n.Type == "forwarded_port" {
+		args = append(args, fmt.Sprintf("guest: %d", n.Guest), fmt.Sprintf("host: %d", n.Host))
+	}
+
+	if len(n.IP) > 0 {
+		args = append(args, "ip: "+rubyString(n.IP))
+	} else if n.Type == "private_network" {
+		args = append(args, `type: "dhcp"`)
+	}
+
+	if len(n.Bridge) > 0 {
+		args = append(args, "bridge: "+rubyString(n.Bridge))
+	}
+
+	return strings.Join(args, ", ")
+}
+
+// rubyString renders s as a double-quoted Ruby string literal. Backslashes,
+// quotes and "#" are escaped so that a value cannot end the literal or
+// interpolate Ruby code, and control characters are written as escapes.
+func rubyString(s string) string {
+	var b strings.Builder
+
+	b.WriteByte('"')
+
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '"' || r == '#':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
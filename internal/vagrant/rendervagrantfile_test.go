@@ -0,0 +1,89 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+)
+
+func TestRubyString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`debian/bookworm64`, `"debian/bookworm64"`},
+		{`my "box"`, `"my \"box\""`},
+		{`C:\boxes\debian`, `"C:\\boxes\\debian"`},
+		{`#{system("id")}`, `"\#{system(\"id\")}"`},
+		{"two\nlines\ttab", `"two\nlines\ttab"`},
+		{"bell\a", `"bell\x07"`},
+		{"débian", `"débian"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := rubyString(tt.value); got != tt.want {
+				t.Errorf("rubyString() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderVagrantfile(t *testing.T) {
+	machines := []config.Machine{
+		{
+			Name:   "debian",
+			Box:    `debian/bookworm64"; system("id") #`,
+			CPUs:   4,
+			Memory: 4096,
+			Networks: []config.Network{
+				{Type: "private_network"},
+				{Type: "private_network", IP: "192.168.57.10"},
+				{Type: "public_network", Bridge: `Intel(R) "Wi-Fi"`},
+				{Type: "forwarded_port", Guest: 80, Host: 8080},
+			},
+		},
+		{Name: "alma", Box: "almalinux/9"},
+	}
+
+	got := string(RenderVagrantfile(config.VM{CPUs: 2, Memory: 2048}, machines))
+
+	for _, want := range []string{
+		"VM_CPUS   = 2\nVM_MEMORY = 2048\n",
+		`  config.vm.define "debian" do |c|
+    c.vm.box      = "debian/bookworm64\"; system(\"id\") \#"
+    c.vm.hostname = "debian.dev"
+    c.vm.network "private_network", type: "dhcp"
+    c.vm.network "private_network", ip: "192.168.57.10"
+    c.vm.network "public_network", bridge: "Intel(R) \"Wi-Fi\""
+    c.vm.network "forwarded_port", guest: 80, host: 8080
+`,
+		"        p.cpus   = 4\n        p.memory = 4096\n",
+		`  config.vm.define "alma" do |c|
+    c.vm.box      = "almalinux/9"
+    c.vm.hostname = "alma.dev"
+  end
+`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("RenderVagrantfile() does not contain\n%s\nin\n%s", want, got)
+		}
+	}
+}
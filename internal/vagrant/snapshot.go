@@ -0,0 +1,115 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// DefaultSnapshot is the snapshot name used when none is given.
+const DefaultSnapshot = "ansible-dev"
+
+// snapshotProviders lists the Vagrant providers that implement
+// "vagrant snapshot".
+var snapshotProviders = map[string]bool{
+	"hyperv":         true,
+	"libvirt":        true,
+	"parallels":      true,
+	"virtualbox":     true,
+	"vmware_desktop": true,
+}
+
+// SupportsSnapshots reports whether the named Vagrant provider can save
+// and restore snapshots.
+func SupportsSnapshots(provider string) bool {
+	return snapshotProviders[provider]
+}
+
+// SaveSnapshot runs "vagrant snapshot save --force <vm> <name>", replacing
+// any existing snapshot with the same name.
+func SaveSnapshot(vm, name string) error {
+	fmt.Printf(textformat.Yellow("\nSaving snapshot '%s' of '%s'...\n\n"), name, vm)
+
+	return runner.Run("vagrant", "snapshot", "save", "--force", vm, name)
+}
+
+// RestoreSnapshot runs "vagrant snapshot restore --no-provision <vm> <name>"
+// so that the Vagrantfile provisioners are not re-run after the restore.
+func RestoreSnapshot(vm, name string) error {
+	fmt.Printf(textformat.Yellow("\nRestoring snapshot '%s' of '%s'...\n\n"), name, vm)
+
+	return runner.Run("vagrant", "snapshot", "restore", "--no-provision", vm, name)
+}
+
+// DeleteSnapshot runs "vagrant snapshot delete <vm> <name>".
+func DeleteSnapshot(vm, name string) error {
+	fmt.Printf(textformat.Yellow("\nDeleting snapshot '%s' of '%s'...\n\n"), name, vm)
+
+	return runner.Run("vagrant", "snapshot", "delete", vm, name)
+}
+
+// ListSnapshots returns the names of the snapshots of a VM as reported by
+// "vagrant snapshot list <vm>".
+func ListSnapshots(vm string) ([]string, error) {
+	out, err := runner.Capture("vagrant", "snapshot", "list", vm)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseSnapshots(out), nil
+}
+
+// HasSnapshot reports whether the VM has a snapshot with the given name.
+// A VM whose snapshots cannot be listed (for example because it has not
+// been created) is reported as not having the snapshot.
+func HasSnapshot(vm, name string) bool {
+	snapshots, err := ListSnapshots(vm)
+	if err != nil {
+		return false
+	}
+
+	for _, s := range snapshots {
+		if s == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseSnapshots parses the output of "vagrant snapshot list". Vagrant's
+// own status lines ("==> vm: ...") are skipped, including the message it
+// prints when no snapshot exists; every other non-empty line is a snapshot
+// name.
+func ParseSnapshots(out string) []string {
+	var snapshots []string
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "==>") {
+			continue
+		}
+
+		snapshots = append(snapshots, line)
+	}
+
+	return snapshots
+}
@@ -0,0 +1,40 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// SSH opens an interactive session on a VM with "vagrant ssh <name>", or
+// runs command there with "vagrant ssh <name> -c <command>" when command is
+// not empty. When become is true the session is a root login shell
+// ("sudo -i") and command is run through "sudo sh -c".
+func SSH(name, command string, become bool) error {
+	param := []string{"ssh", name}
+
+	switch {
+	case len(command) > 0 && become:
+		param = append(param, "-c", "sudo sh -c "+runner.Quote(command))
+	case len(command) > 0:
+		param = append(param, "-c", command)
+	case become:
+		param = append(param, "-c", "sudo -i")
+	}
+
+	return runner.Run("vagrant", param...)
+}
@@ -0,0 +1,134 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// SSHConfig is one "Host" block of the output of "vagrant ssh-config".
+//
+// Fields:
+//   - Host:         the Vagrant machine name.
+//   - HostName:     the address to connect to, e.g. "192.168.121.7" or
+//     "127.0.0.1" for NAT-forwarded ports.
+//   - Port:         the SSH port, e.g. "22" or "2222".
+//   - User:         the login user.
+//   - IdentityFile: the private key, which is a per-machine generated key
+//     when the Vagrantfile sets config.ssh.insert_key = true.
+//   - Options:      every other option in the block, keyed by its name
+//     as written by Vagrant (e.g. "StrictHostKeyChecking").
+type SSHConfig struct {
+	Host         string
+	HostName     string
+	Port         string
+	User         string
+	IdentityFile string
+	Options      map[string]string
+}
+
+// GetSSHConfig runs "vagrant ssh-config <name>" and returns the block for
+// the machine. The port defaults to 22 when Vagrant does not list one.
+func GetSSHConfig(name string) (SSHConfig, error) {
+	out, err := runner.Capture("vagrant", "ssh-config", name)
+	if err != nil {
+		return SSHConfig{}, fmt.Errorf("vagrant ssh-config %s: %w", name, err)
+	}
+
+	for _, c := range ParseSSHConfig(out) {
+		if c.Host == name || len(c.Host) == 0 {
+			if len(c.HostName) == 0 {
+				break
+			}
+
+			return c, nil
+		}
+	}
+
+	return SSHConfig{}, fmt.Errorf("HostName not found in ssh-config output for '%s'", name)
+}
+
+// ParseSSHConfig parses ssh_config formatted text into one [SSHConfig] per
+// "Host" block. Keywords are matched case-insensitively, values may be
+// separated from keywords by whitespace or "=", and double quotes around
+// values (used by Vagrant for paths containing spaces) are removed.
+func ParseSSHConfig(out string) []SSHConfig {
+	var configs []SSHConfig
+
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		if k, v, ok := strings.Cut(key, "="); ok {
+			key, value = k, v+" "+value
+		}
+
+		value = strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), "=")), `"`)
+
+		if strings.EqualFold(key, "Host") || len(configs) == 0 {
+			configs = append(configs, SSHConfig{Port: "22", Options: map[string]string{}})
+		}
+
+		c := &configs[len(configs)-1]
+
+		switch strings.ToLower(key) {
+		case "host":
+			c.Host = value
+		case "hostname":
+			c.HostName = value
+		case "port":
+			c.Port = value
+		case "user":
+			c.User = value
+		case "identityfile":
+			// Vagrant lists the generated key first when insert_key is
+			// enabled; later entries are fallbacks.
+			if len(c.IdentityFile) == 0 {
+				c.IdentityFile = value
+			}
+		default:
+			c.Options[key] = value
+		}
+	}
+
+	return configs
+}
+
+// InventoryVars returns the Ansible connection variables matching c, in the
+// order they are written to hosts.ini. Empty values are omitted.
+func (c SSHConfig) InventoryVars() [][2]string {
+	var vars [][2]string
+
+	for _, v := range [][2]string{
+		{"ansible_host", c.HostName},
+		{"ansible_port", c.Port},
+		{"ansible_user", c.User},
+		{"ansible_ssh_private_key_file", c.IdentityFile},
+	} {
+		if len(v[1]) > 0 {
+			vars = append(vars, v)
+		}
+	}
+
+	return vars
+}
@@ -0,0 +1,88 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// MachineStatus is the state of a single Vagrant machine as reported by
+// "vagrant status --machine-readable".
+//
+// Fields:
+//   - Name:     the Vagrant machine name.
+//   - Provider: the provider backing the machine (e.g. "virtualbox").
+//   - State:    the machine state (e.g. "running", "poweroff",
+//     "not_created").
+type MachineStatus struct {
+	Name     string
+	Provider string
+	State    string
+}
+
+// Status runs "vagrant status --machine-readable" and returns the machines
+// it reports, in the order they first appear. An error is returned if the
+// command fails.
+func Status() ([]MachineStatus, error) {
+	out, err := runner.Capture("vagrant", "status", "--machine-readable")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseStatus(out), nil
+}
+
+// ParseStatus parses the output of "vagrant status --machine-readable". Each
+// line has the form "timestamp,target,type,data"; only the "provider-name"
+// and "state" types of named targets are used. Vagrant's escaped commas
+// ("%!(VAGRANT_COMMA)") in the data field are restored.
+func ParseStatus(out string) []MachineStatus {
+	var machines []MachineStatus
+
+	index := map[string]int{}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(strings.TrimSpace(line), ",", 4)
+		if len(fields) < 4 || len(fields[1]) == 0 {
+			continue
+		}
+
+		name, kind := fields[1], fields[2]
+		data := strings.ReplaceAll(fields[3], "%!(VAGRANT_COMMA)", ",")
+
+		if kind != "provider-name" && kind != "state" {
+			continue
+		}
+
+		i, ok := index[name]
+		if !ok {
+			i = len(machines)
+			index[name] = i
+			machines = append(machines, MachineStatus{Name: name})
+		}
+
+		if kind == "provider-name" {
+			machines[i].Provider = data
+		} else {
+			machines[i].State = data
+		}
+	}
+
+	return machines
+}
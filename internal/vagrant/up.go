@@ -18,86 +18,80 @@ package vagrant
 
 import (
 	"fmt"
-	"strings"
+	"io"
+	"os"
 
-	"github.com/dcjulian29/go-toolbox/execute"
-	"github.com/dcjulian29/go-toolbox/network"
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 	"github.com/dcjulian29/go-toolbox/textformat"
 )
 
-// Up starts a named Vagrant VM, discovers its IP address via
-// "vagrant ssh-config", updates hosts.ini with the discovered address,
-// and waits for the VM to become reachable from the host.
-//
-// The retry loop attempts up to 20 pings. A progress dot is printed for
-// each failed attempt. If the VM responds within the limit, a green
-// "[Found]" status is printed; otherwise a red "[NotFound]" status is
-// printed and an error is returned.
+// Up starts a named Vagrant VM, reads its connection details via
+// "vagrant ssh-config" (see [GetSSHConfig]), writes them to the VM's line
+// in hosts.ini as ansible_host, ansible_port, ansible_user and
+// ansible_ssh_private_key_file, and waits for the VM to accept SSH
+// connections (see [WaitForSSH]). The timeout, probe interval and optional
+// boot-finished command come from the "ready" configuration values. In
+// dynamic inventory mode hosts.ini is left alone, since Ansible reads the
+// address from "ansible-dev inventory" instead.
 //
 // Parameters:
 //   - name: the Vagrant machine name as defined in the Vagrantfile and
 //     the [vagrant] section of hosts.ini.
-//   - addr: the expected IP address of the VM (e.g. "192.168.57.42").
 //
-// An error is returned if "vagrant up" fails, the IP cannot be
-// discovered via ssh-config, hosts.ini cannot be updated, or the VM
-// does not respond to pings within the retry limit.
+// When the global --dry-run flag is set only the "vagrant up" command line
+// is printed; address discovery and the readiness check are skipped.
+//
+// An error is returned if "vagrant up" fails, the connection details
+// cannot be read via ssh-config, hosts.ini cannot be updated, or the VM
+// is not ready within the timeout.
 func Up(name string) error {
-	fmt.Printf(textformat.Yellow("\nBringing '%s' online...\n\n"), name)
+	return up(os.Stdout, name, false)
+}
 
-	if err := execute.ExternalProgram("vagrant", "up", name); err != nil {
-		return err
+// up implements [Up], writing progress messages to w. When stream is true
+// the vagrant output is also written to w (see [runner.Stream]) instead of
+// being attached to the terminal, which is how [UpAll] brings several VMs
+// up at once.
+func up(w io.Writer, name string, stream bool) error {
+	fmt.Fprintf(w, textformat.Yellow("\nBringing '%s' online...\n\n"), name) //nolint:errcheck
+
+	var err error
+
+	if stream {
+		err = runner.Stream(w, "vagrant", "up", name)
+	} else {
+		err = runner.Run("vagrant", "up", name)
 	}
 
-	addr, err := getSSHHost(name)
 	if err != nil {
 		return err
 	}
 
-	if err := updateHostsIni(name, addr); err != nil {
-		return fmt.Errorf("failed to update hosts.ini for %s: %w", name, err)
+	// The address is only known once the VM has actually booted, so a dry
+	// run stops here rather than reporting a failed discovery.
+	if runner.IsDryRun() {
+		return nil
 	}
 
-	fmt.Printf(textformat.Yellow("\nSearching for '%s' at %s..."), name, addr)
-
-	found := false
-	count := 0
-
-	for !found {
-		found = network.Ping(addr)
-
-		if found {
-			fmt.Println(textformat.Green(" [Found]"))
-		} else {
-			if count < 20 {
-				fmt.Print(".")
-				count++
-			} else {
-				fmt.Println(textformat.Red(" [NotFound]"))
-				return fmt.Errorf("can't find the '%s' VM at %s", name, addr)
-			}
-		}
-	}
-
-	return nil
-}
-
-func getSSHHost(name string) (string, error) {
-	out, err := execute.ExternalProgramCapture("vagrant", "ssh-config", name)
+	ssh, err := GetSSHConfig(name)
 	if err != nil {
-		return "", fmt.Errorf("vagrant ssh-config %s: %w", name, err)
+		return err
 	}
 
-	for _, line := range strings.Split(out, "\n") {
-		line = strings.TrimSpace(line)
-		if strings.HasPrefix(line, "HostName ") {
-			return strings.TrimPrefix(line, "HostName "), nil
-		}
+	if err := updateHostsIni(name, ssh); err != nil {
+		return fmt.Errorf("failed to update the inventory for %s: %w", name, err)
 	}
 
-	return "", fmt.Errorf("HostName not found in ssh-config output for '%s'", name)
+	return WaitForSSH(w, name, ssh.HostName, ssh.Port, config.Current().Ready)
 }
 
-func updateHostsIni(name, addr string) error {
-	return UpdateInventoryAddress("hosts.ini", name, addr)
+func updateHostsIni(name string, ssh SSHConfig) error {
+	if config.Current().Inventory.Dynamic {
+		return nil
+	}
+
+	return ansible.UpdateInventoryHost(inventory.Path(), name, ssh.InventoryVars())
 }
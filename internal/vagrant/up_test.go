@@ -0,0 +1,252 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+)
+
+// record changes to a new development directory holding an ansible.cfg and
+// a hosts.ini with the debian and alma hosts and makes a new
+// [runner.Recorder] the current runner until the test ends.
+func record(t *testing.T) *runner.Recorder {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	for name, content := range map[string]string{
+		"ansible.cfg": "[defaults]\ninventory = hosts.ini\n",
+		"hosts.ini":   "[vagrant]\ndebian\nalma\n",
+	} {
+		if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	previous := runner.Current()
+	t.Cleanup(func() { runner.Set(previous) })
+
+	rec := runner.NewRecorder()
+	runner.Set(rec)
+
+	return rec
+}
+
+// sshd starts a listener that greets every connection with an SSH banner,
+// so that [WaitForSSH] finds the VM ready, and returns its port.
+func sshd(t *testing.T) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+
+			_, _ = conn.Write([]byte("SSH-2.0-test\r\n"))
+			_ = conn.Close()
+		}
+	}()
+
+	_, port, _ := net.SplitHostPort(l.Addr().String())
+
+	return port
+}
+
+// sshConfig returns the "vagrant ssh-config" output of name listening on
+// 127.0.0.1:port.
+func sshConfig(name, port string) string {
+	return fmt.Sprintf(`Host %s
+  HostName 127.0.0.1
+  User vagrant
+  Port %s
+  UserKnownHostsFile /dev/null
+  StrictHostKeyChecking no
+  IdentityFile /work/.vagrant/machines/%s/libvirt/private_key
+  IdentitiesOnly yes
+`, name, port, name)
+}
+
+func TestUp(t *testing.T) {
+	rec := record(t)
+	port := sshd(t)
+
+	rec.Outputs["vagrant ssh-config debian"] = sshConfig("debian", port)
+
+	if err := Up("debian"); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	want := []string{
+		"vagrant up debian",
+		"vagrant ssh-config debian",
+	}
+
+	if got := rec.Lines(); !slices.Equal(got, want) {
+		t.Errorf("commands = %q, want %q", got, want)
+	}
+
+	hosts := fmt.Sprintf("[vagrant]\n"+
+		"debian ansible_host=127.0.0.1 ansible_port=%s ansible_user=vagrant "+
+		"ansible_ssh_private_key_file=/work/.vagrant/machines/debian/libvirt/private_key\n"+
+		"alma\n", port)
+
+	if got := string(rec.Files["hosts.ini"]); got != hosts {
+		t.Errorf("hosts.ini = %q, want %q", got, hosts)
+	}
+}
+
+func TestUpDryRun(t *testing.T) {
+	record(t)
+
+	var out bytes.Buffer
+	runner.Set(runner.NewDryRun(&out))
+
+	if err := Up("debian"); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+
+	if got, want := out.String(), "[dry-run] vagrant up debian\n"; got != want {
+		t.Errorf("dry-run output = %q, want %q", got, want)
+	}
+}
+
+func TestUpAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		parallel int
+		provider string
+		errors   map[string]error
+		want     []string
+		err      string
+		serial   bool
+	}{
+		{
+			name:     "sequential",
+			parallel: 1,
+			want: []string{
+				"vagrant up debian",
+				"vagrant ssh-config debian",
+				"vagrant up alma",
+				"vagrant ssh-config alma",
+			},
+		},
+		{
+			name:     "sequential stops at the first failure",
+			parallel: 1,
+			errors:   map[string]error{"vagrant up debian": errors.New("exit status 1")},
+			want: []string{
+				"vagrant up debian",
+			},
+			err: "exit status 1",
+		},
+		{
+			name:     "parallel",
+			provider: "libvirt",
+			want: []string{
+				"vagrant ssh-config alma",
+				"vagrant ssh-config debian",
+				"vagrant up alma",
+				"vagrant up debian",
+			},
+		},
+		{
+			name:     "parallel continues after a failure",
+			provider: "libvirt",
+			errors:   map[string]error{"vagrant up debian": errors.New("exit status 1")},
+			want: []string{
+				"vagrant ssh-config alma",
+				"vagrant up alma",
+				"vagrant up debian",
+			},
+			err: "1 of 2 VMs failed to start",
+		},
+		{
+			name:     "unsupported provider",
+			provider: "virtualbox",
+			want: []string{
+				"vagrant ssh-config alma",
+				"vagrant ssh-config debian",
+				"vagrant up alma",
+				"vagrant up debian",
+			},
+			serial: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := record(t)
+			port := sshd(t)
+
+			t.Setenv("VAGRANT_DEFAULT_PROVIDER", tt.provider)
+
+			for _, name := range []string{"debian", "alma"} {
+				rec.Outputs["vagrant ssh-config "+name] = sshConfig(name, port)
+			}
+
+			for cmd, err := range tt.errors {
+				rec.Errors[cmd] = err
+			}
+
+			err := UpAll([]string{"debian", "alma"}, tt.parallel)
+			if (err == nil) != (len(tt.err) == 0) || (err != nil && err.Error() != tt.err) {
+				t.Fatalf("UpAll() error = %v, want %q", err, tt.err)
+			}
+
+			got := rec.Lines()
+
+			// VMs started one at a time run each step of Up before the next
+			// VM starts, in whatever order they got their turn.
+			if tt.serial {
+				for i := 0; i+1 < len(got); i += 2 {
+					if name := got[i][len("vagrant up "):]; got[i+1] != "vagrant ssh-config "+name {
+						t.Errorf("commands = %q, want the steps of each VM together", got)
+					}
+				}
+			}
+
+			// Concurrent VMs record their commands in no particular order.
+			if tt.parallel != 1 {
+				slices.Sort(got)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("commands = %q, want %q", got, tt.want)
+			}
+
+			if _, ok := rec.Files["hosts.ini"]; !ok && len(tt.err) == 0 {
+				t.Error("hosts.ini was not updated")
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// parallelProviders lists the Vagrant providers that can safely boot
+// several machines of one environment at the same time. VirtualBox is
+// deliberately absent: Vagrant itself refuses "up --parallel" for it.
+var parallelProviders = map[string]bool{
+	"aws":            true,
+	"docker":         true,
+	"hyperv":         true,
+	"libvirt":        true,
+	"vmware_desktop": true,
+}
+
+// UpResult is the outcome of bringing a single VM online with [UpAll].
+type UpResult struct {
+	Name    string
+	Err     error
+	Elapsed time.Duration
+}
+
+// UpAll brings every named VM online via the same steps as [Up].
+//
+// When parallel is 1 the VMs are started one after another and the first
+// failure is returned immediately, which is the historical behavior of
+// "ansible-dev start". Any other value selects the concurrent mode:
+//
+//   - up to parallel VMs (every VM when parallel is 0 or negative) are
+//     started at the same time, provided the provider reported by
+//     [Provider] supports it; otherwise they are started sequentially.
+//   - each line of vagrant output is prefixed with the machine name.
+//   - a failing VM does not stop the others. A per-VM success/failure
+//     summary is printed at the end and an error is returned if any VM
+//     failed.
+func UpAll(names []string, parallel int) error {
+	if parallel == 1 {
+		for _, name := range names {
+			if err := Up(name); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	if parallel <= 0 || parallel > len(names) {
+		parallel = len(names)
+	}
+
+	if provider := Provider(); !parallelProviders[provider] {
+		if len(provider) == 0 {
+			provider = "unknown"
+		}
+
+		fmt.Println(textformat.Yellow(fmt.Sprintf(
+			"the '%s' provider does not support parallel startup; starting VMs sequentially", provider)))
+
+		parallel = 1
+	}
+
+	results := make([]UpResult, len(names))
+	slots := make(chan struct{}, parallel)
+
+	var wg sync.WaitGroup
+
+	for i, name := range names {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			w := runner.NewPrefixWriter(os.Stdout, fmt.Sprintf("[%s] ", name))
+			start := time.Now()
+			err := up(w, name, true)
+
+			_ = w.Flush()
+
+			results[i] = UpResult{Name: name, Err: err, Elapsed: time.Since(start)}
+		}()
+	}
+
+	wg.Wait()
+
+	return summarize(results)
+}
+
+// Provider returns the Vagrant provider used by the environment: the
+// VAGRANT_DEFAULT_PROVIDER environment variable when set, otherwise the
+// provider "vagrant status" reports for the first machine. It returns an
+// empty string if the provider cannot be determined.
+func Provider() string {
+	if p := os.Getenv("VAGRANT_DEFAULT_PROVIDER"); len(p) > 0 {
+		return p
+	}
+
+	machines, err := Status()
+	if err != nil {
+		return ""
+	}
+
+	for _, m := range machines {
+		if len(m.Provider) > 0 {
+			return m.Provider
+		}
+	}
+
+	return ""
+}
+
+func summarize(results []UpResult) error {
+	width := 0
+	failed := 0
+
+	for _, r := range results {
+		width = max(width, len(r.Name))
+	}
+
+	fmt.Println(textformat.Yellow("\nSummary:"))
+
+	for _, r := range results {
+		elapsed := r.Elapsed.Round(time.Second)
+
+		if r.Err != nil {
+			failed++
+			fmt.Println(textformat.Red(fmt.Sprintf("  %-*s  [Failed] %s (%s)", width, r.Name, r.Err, elapsed)))
+		} else {
+			fmt.Println(textformat.Green(fmt.Sprintf("  %-*s  [OK] (%s)", width, r.Name, elapsed)))
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d VMs failed to start", failed, len(results))
+	}
+
+	return nil
+}
@@ -17,7 +17,7 @@ limitations under the License.
 package vagrant
 
 import (
-	"github.com/dcjulian29/go-toolbox/execute"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
 )
 
 // Upgrade updates the Vagrant box for the current environment and then
@@ -37,12 +37,12 @@ func Upgrade() error {
 	update := []string{"box", "update"}
 	prune := []string{"box", "prune", "--force", "--keep-active-boxes"}
 
-	err := execute.ExternalProgram("vagrant", update...)
+	err := runner.Run("vagrant", update...)
 	if err != nil {
 		return err
 	}
 
-	err = execute.ExternalProgram("vagrant", prune...)
+	err = runner.Run("vagrant", prune...)
 	if err != nil {
 		return err
 	}
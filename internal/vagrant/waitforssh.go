@@ -0,0 +1,142 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vagrant
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/dcjulian29/ansible-dev/internal/config"
+	"github.com/dcjulian29/ansible-dev/internal/runner"
+	"github.com/dcjulian29/go-toolbox/textformat"
+)
+
+// WaitForSSH waits until the VM is ready to be provisioned. It probes
+// host:port (as reported by "vagrant ssh-config") every ready.interval
+// seconds until a TCP connection succeeds and the server sends an SSH
+// identification banner ("SSH-2.0-..."). When ready.command is set it then
+// runs that command with "vagrant ssh <name> -c" until it exits
+// successfully, so that provisioning does not race cloud-init or other
+// first-boot services.
+//
+// A progress dot is written to w for each failed probe. A green "[Ready]"
+// marks success; on timeout a red "[NotReady]" is written and the returned
+// error explains the last failure, for example whether the port refused
+// connections, was filtered, or answered without an SSH banner.
+func WaitForSSH(w io.Writer, name, host, port string, ready config.Ready) error {
+	timeout := time.Duration(max(ready.Timeout, 1)) * time.Second
+	interval := time.Duration(max(ready.Interval, 1)) * time.Second
+	deadline := time.Now().Add(timeout)
+	address := net.JoinHostPort(host, port)
+
+	fmt.Fprintf(w, textformat.Yellow("\nWaiting for SSH on '%s' at %s..."), name, address) //nolint:errcheck
+
+	var last error
+
+	for {
+		if last = probeSSH(address, interval); last == nil {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			fmt.Fprintln(w, textformat.Red(" [NotReady]")) //nolint:errcheck
+
+			return fmt.Errorf("'%s' is not accepting SSH connections at %s after %s: %s",
+				name, address, timeout, diagnose(last))
+		}
+
+		fmt.Fprint(w, ".") //nolint:errcheck
+		time.Sleep(interval)
+	}
+
+	if len(ready.Command) > 0 {
+		fmt.Fprintf(w, textformat.Yellow("\nWaiting for '%s' to finish booting..."), name) //nolint:errcheck
+
+		for {
+			out, err := runner.Capture("vagrant", "ssh", name, "-c", ready.Command)
+			if err == nil {
+				break
+			}
+
+			if time.Now().After(deadline) {
+				fmt.Fprintln(w, textformat.Red(" [NotReady]")) //nolint:errcheck
+
+				return fmt.Errorf("'%s' did not finish booting after %s: '%s' failed: %v\n%s",
+					name, timeout, ready.Command, err, strings.TrimSpace(out))
+			}
+
+			fmt.Fprint(w, ".") //nolint:errcheck
+			time.Sleep(interval)
+		}
+	}
+
+	fmt.Fprintln(w, textformat.Green(" [Ready]")) //nolint:errcheck
+
+	return nil
+}
+
+// errNoBanner is returned by probeSSH when the port accepts connections but
+// does not identify itself as an SSH server.
+var errNoBanner = errors.New("no SSH banner")
+
+// probeSSH connects to address and reads the first line the server sends,
+// which for an SSH server is its identification string. The connection
+// and the read are each bounded by timeout.
+func probeSSH(address string, timeout time.Duration) error {
+	conn, err := net.DialTimeout("tcp", address, timeout)
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close() //nolint:errcheck
+
+	if err := conn.SetReadDeadline(time.Now().Add(timeout)); err != nil {
+		return err
+	}
+
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	if strings.HasPrefix(line, "SSH-") {
+		return nil
+	}
+
+	if err != nil && len(line) == 0 {
+		return fmt.Errorf("%w: %v", errNoBanner, err)
+	}
+
+	return fmt.Errorf("%w: received %q", errNoBanner, strings.TrimSpace(line))
+}
+
+// diagnose turns the last probe error into a hint about its likely cause.
+func diagnose(err error) string {
+	var netErr net.Error
+
+	switch {
+	case errors.Is(err, errNoBanner):
+		return fmt.Sprintf("the port is open but sshd did not answer (%v)", err)
+	case strings.Contains(err.Error(), "connection refused"):
+		return fmt.Sprintf("the VM is reachable but nothing is listening on the SSH port yet (%v)", err)
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return fmt.Sprintf("no response; the VM may be unreachable or the port filtered by a firewall (%v)", err)
+	default:
+		return err.Error()
+	}
+}
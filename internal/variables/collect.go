@@ -0,0 +1,328 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dcjulian29/ansible-dev/internal/inventory"
+	"gopkg.in/yaml.v3"
+)
+
+// Options selects the sources of a play beyond the inventory.
+//
+// Fields:
+//   - Roles:     roles whose defaults and vars apply, in addition to the
+//     roles of Playbook. Role dependencies are included automatically.
+//   - Playbook:  an optional playbook whose play vars, vars_files, roles
+//     and adjacent group_vars/host_vars apply.
+//   - ExtraVars: extra variables as given to ansible-playbook -e:
+//     "key=value ...", a JSON or YAML mapping, or "@file".
+type Options struct {
+	Roles     []string
+	Playbook  string
+	ExtraVars []string
+}
+
+// Collect returns every definition of the variables of host, lowest
+// precedence first, with the definition that takes effect for each
+// variable marked with Wins. Definitions on the same level follow
+// Ansible's loading order: groups by depth in the group hierarchy and then
+// by name, "all" first; files in lexical order; roles after their
+// dependencies.
+//
+// The inventory is the one [inventory.Path] finds in the current
+// directory, whose group_vars and host_vars directories are the ones
+// "ansible-dev initialize" creates.
+// An error is returned if the inventory cannot be read, host is not in it,
+// a role cannot be found (see [ansible.RoleFolder]) or a file cannot be
+// parsed.
+func Collect(host string, opts Options) ([]Definition, error) {
+	inv, err := inventory.Load(inventory.Path())
+	if err != nil {
+		return nil, err
+	}
+
+	h := inv.Host(host)
+	if h == nil {
+		return nil, fmt.Errorf("'%s' is not a host in %s", host, inv.Filename)
+	}
+
+	var pb playbook
+
+	if len(opts.Playbook) > 0 {
+		if pb, err = readPlaybook(opts.Playbook); err != nil {
+			return nil, err
+		}
+	}
+
+	roles, err := expandRoles(append(pb.roles, opts.Roles...))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := hostGroups(inv, host)
+
+	// Group and host variable directories next to the playbook only apply
+	// when the playbook is not in the inventory directory.
+	playbookDir := ""
+	if len(opts.Playbook) > 0 {
+		if dir, _ := filepath.Abs(filepath.Dir(opts.Playbook)); dir != absDir(".") {
+			playbookDir = filepath.Dir(opts.Playbook)
+		}
+	}
+
+	var defs []Definition
+
+	steps := []func() ([]Definition, error){
+		func() ([]Definition, error) { return roleDefinitions(roles, RoleDefaults) },
+		func() ([]Definition, error) {
+			var d []Definition
+
+			for _, g := range groups {
+				if group := inv.Group(g); group != nil {
+					for _, v := range group.Vars {
+						d = append(d, Definition{
+							Name:       v[0],
+							Value:      v[1],
+							Precedence: InventoryFileGroupVars,
+							Source:     source(InventoryFileGroupVars, g),
+							File:       inv.Filename,
+							Line:       inv.GroupVarLine(g, v[0]),
+						})
+					}
+				}
+			}
+
+			return d, nil
+		},
+		func() ([]Definition, error) {
+			return readVarsFiles("group_vars", "all", InventoryGroupVarsAll, source(InventoryGroupVarsAll, ""))
+		},
+		func() ([]Definition, error) {
+			return playbookVars(playbookDir, "group_vars", []string{"all"}, PlaybookGroupVarsAll)
+		},
+		func() ([]Definition, error) {
+			return groupVarsFiles("", "group_vars", groups[1:], InventoryGroupVars)
+		},
+		func() ([]Definition, error) {
+			return playbookVars(playbookDir, "group_vars", groups[1:], PlaybookGroupVars)
+		},
+		func() ([]Definition, error) {
+			var d []Definition
+
+			for _, v := range h.Vars {
+				d = append(d, Definition{
+					Name:       v[0],
+					Value:      v[1],
+					Precedence: InventoryFileHostVars,
+					Source:     source(InventoryFileHostVars, ""),
+					File:       inv.Filename,
+					Line:       inv.HostVarLine(host, v[0]),
+				})
+			}
+
+			return d, nil
+		},
+		func() ([]Definition, error) {
+			return readVarsFiles("host_vars", host, InventoryHostVars, source(InventoryHostVars, ""))
+		},
+		func() ([]Definition, error) {
+			return playbookVars(playbookDir, "host_vars", []string{host}, PlaybookHostVars)
+		},
+		func() ([]Definition, error) { return pb.vars, nil },
+		func() ([]Definition, error) { return pb.varsFiles, nil },
+		func() ([]Definition, error) { return roleDefinitions(roles, RoleVars) },
+		func() ([]Definition, error) { return extraVars(opts.ExtraVars) },
+	}
+
+	for _, step := range steps {
+		d, err := step()
+		if err != nil {
+			return nil, err
+		}
+
+		defs = append(defs, d...)
+	}
+
+	seen := map[string]bool{}
+
+	for i := len(defs) - 1; i >= 0; i-- {
+		if !seen[defs[i].Name] {
+			seen[defs[i].Name] = true
+			defs[i].Wins = true
+		}
+	}
+
+	return defs, nil
+}
+
+// hostGroups returns "all" followed by every group host belongs to,
+// directly or through a child group, sorted by depth in the group
+// hierarchy and then by name, the order in which Ansible merges group
+// variables.
+func hostGroups(inv *inventory.Inventory, host string) []string {
+	depths := map[string]int{}
+
+	var depth func(group string, path map[string]bool) int
+
+	depth = func(group string, path map[string]bool) int {
+		if group == "all" {
+			return 0
+		}
+
+		if d, ok := depths[group]; ok {
+			return d
+		}
+
+		d := 1
+
+		for _, parent := range inv.ParentGroups(group) {
+			if path[parent] {
+				continue
+			}
+
+			path[group] = true
+			d = max(d, depth(parent, path)+1)
+			delete(path, group)
+		}
+
+		depths[group] = d
+
+		return d
+	}
+
+	var groups []string
+
+	for _, g := range inv.Groups() {
+		if g.Name == "all" {
+			continue
+		}
+
+		for _, h := range inv.GroupHosts(g.Name) {
+			if h == host {
+				groups = append(groups, g.Name)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		di, dj := depth(groups[i], map[string]bool{}), depth(groups[j], map[string]bool{})
+		if di != dj {
+			return di < dj
+		}
+
+		return groups[i] < groups[j]
+	})
+
+	return append([]string{"all"}, groups...)
+}
+
+// groupVarsFiles reads the variables files of each name below base/dir.
+func groupVarsFiles(base, dir string, names []string, level int) ([]Definition, error) {
+	var defs []Definition
+
+	for _, name := range names {
+		qualifier := ""
+		if level == InventoryGroupVars || level == PlaybookGroupVars {
+			qualifier = name
+		}
+
+		d, err := readVarsFiles(filepath.Join(base, dir), name, level, source(level, qualifier))
+		if err != nil {
+			return nil, err
+		}
+
+		defs = append(defs, d...)
+	}
+
+	return defs, nil
+}
+
+// playbookVars reads the variables files of each name below the
+// group_vars or host_vars directory next to the playbook, if any.
+func playbookVars(playbookDir, dir string, names []string, level int) ([]Definition, error) {
+	if len(playbookDir) == 0 {
+		return nil, nil
+	}
+
+	return groupVarsFiles(playbookDir, dir, names, level)
+}
+
+// extraVars parses extra variables given as "key=value ...", as a JSON or
+// YAML mapping, or as "@file".
+func extraVars(values []string) ([]Definition, error) {
+	var defs []Definition
+
+	src := source(ExtraVars, "")
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+
+		switch {
+		case strings.HasPrefix(value, "@"):
+			d, err := readVarsFile(value[1:], ExtraVars, src)
+			if err != nil {
+				return nil, err
+			}
+
+			defs = append(defs, d...)
+		case strings.HasPrefix(value, "{"):
+			var doc yaml.Node
+
+			if err := yaml.Unmarshal([]byte(value), &doc); err != nil {
+				return nil, fmt.Errorf("unable to parse the extra vars '%s': %w", value, err)
+			}
+
+			for _, d := range mappingDefinitions(doc.Content[0], ExtraVars, src, "command line") {
+				d.Line = 0
+				defs = append(defs, d)
+			}
+		default:
+			for _, field := range strings.Fields(value) {
+				key, val, ok := strings.Cut(field, "=")
+				if !ok {
+					return nil, fmt.Errorf("invalid extra var '%s' (expected key=value)", field)
+				}
+
+				defs = append(defs, Definition{
+					Name:       key,
+					Value:      val,
+					Precedence: ExtraVars,
+					Source:     src,
+					File:       "command line",
+				})
+			}
+		}
+	}
+
+	return defs, nil
+}
+
+// absDir returns the absolute form of dir, or dir when it cannot be
+// determined.
+func absDir(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		return abs
+	}
+
+	return dir
+}
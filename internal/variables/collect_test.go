@@ -0,0 +1,320 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testInventory is the hosts.ini of the fixture project: debian is in
+// web directly and through its child group frontend. port is set on the
+// web group and on debian.
+const testInventory = `[vagrant]
+debian port=8
+alma
+
+[web]
+debian
+
+[web:children]
+frontend
+
+[frontend]
+debian
+
+[web:vars]
+port=3
+`
+
+// project changes to a new fixture project holding an ansible.cfg,
+// testInventory and files, keyed by their slash-separated path.
+func project(t *testing.T, files map[string]string) {
+	t.Helper()
+	t.Chdir(t.TempDir())
+
+	all := map[string]string{
+		"ansible.cfg": "[defaults]\ninventory = hosts.ini\nroles_path = roles\n",
+		"hosts.ini":   testInventory,
+	}
+
+	for name, content := range files {
+		all[name] = content
+	}
+
+	for name, content := range all {
+		path := filepath.FromSlash(name)
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestCollectPrecedence(t *testing.T) {
+	project(t, map[string]string{
+		"roles/nginx/defaults/main.yml":  "port: 2\n",
+		"roles/nginx/vars/main.yml":      "port: 15\n",
+		"group_vars/all.yml":             "port: 4\n",
+		"group_vars/web.yml":             "port: 6\n",
+		"host_vars/debian.yml":           "port: 9\n",
+		"playbooks/group_vars/all.yml":   "port: 5\n",
+		"playbooks/group_vars/web.yml":   "port: 7\n",
+		"playbooks/host_vars/debian.yml": "port: 10\n",
+		"playbooks/vars/main.yml":        "port: 14\n",
+		"playbooks/site.yml": `- hosts: all
+  vars:
+    port: 12
+  vars_files:
+    - vars/main.yml
+    - "{{ env }}.yml"
+  roles:
+    - role: nginx
+`,
+	})
+
+	defs, err := Collect("debian", Options{Playbook: "playbooks/site.yml", ExtraVars: []string{"port=22"}})
+	if err != nil {
+		t.Fatalf("Collect() error = %v", err)
+	}
+
+	got := Explain(defs, "port")
+
+	want := []int{
+		RoleDefaults, InventoryFileGroupVars, InventoryGroupVarsAll, PlaybookGroupVarsAll, InventoryGroupVars,
+		PlaybookGroupVars, InventoryFileHostVars, InventoryHostVars, PlaybookHostVars, PlayVars, PlayVarsFiles,
+		RoleVars, ExtraVars,
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Explain() = %+v, want %d definitions", got, len(want))
+	}
+
+	for i, d := range got {
+		if d.Precedence != want[i] || d.Value != strconv.Itoa(want[i]) {
+			t.Errorf("definition %d = %s from %s (level %d), want level %d", i, d.Value, d.Source, d.Precedence, want[i])
+		}
+
+		if d.Wins != (i == len(got)-1) {
+			t.Errorf("definition %d from %s: Wins = %v", i, d.Source, d.Wins)
+		}
+	}
+
+	for _, c := range []struct {
+		level    int
+		source   string
+		location string
+	}{
+		{RoleDefaults, "role defaults (nginx)", filepath.Join("roles", "nginx", "defaults", "main.yml") + ":1"},
+		{InventoryFileGroupVars, "inventory file group vars (web)", "hosts.ini:15"},
+		{InventoryGroupVars, "inventory group_vars/* (web)", filepath.Join("group_vars", "web.yml") + ":1"},
+		{PlaybookGroupVars, "playbook group_vars/* (web)", filepath.Join("playbooks", "group_vars", "web.yml") + ":1"},
+		{InventoryFileHostVars, "inventory file host vars", "hosts.ini:2"},
+		{PlayVars, "play vars", filepath.Join("playbooks", "site.yml") + ":3"},
+		{ExtraVars, "extra vars", "command line"},
+	} {
+		i := slices.Index(want, c.level)
+
+		if got[i].Source != c.source || got[i].Location() != c.location {
+			t.Errorf("level %d = %s at %s, want %s at %s", c.level, got[i].Source, got[i].Location(), c.source, c.location)
+		}
+	}
+
+	if merged := Merge(defs); len(merged) != 1 || merged[0].Value != "22" {
+		t.Errorf("Merge() = %+v, want port=22", merged)
+	}
+}
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		opts  Options
+		host  string
+		want  []string
+	}{
+		{
+			name: "deeper groups override shallower ones",
+			files: map[string]string{
+				"group_vars/frontend.yml": "tier: frontend\n",
+				"group_vars/web.yml":      "tier: web\n",
+				"group_vars/vagrant.yml":  "tier: vagrant\n",
+			},
+			want: []string{"vagrant", "web", "frontend"},
+		},
+		{
+			name: "groups of the same depth by name",
+			files: map[string]string{
+				"group_vars/web.yml":     "tier: web\n",
+				"group_vars/vagrant.yml": "tier: vagrant\n",
+				"group_vars/all.yml":     "tier: all\n",
+			},
+			want: []string{"all", "vagrant", "web"},
+		},
+		{
+			name: "groups the host is not in",
+			files: map[string]string{
+				"group_vars/web.yml": "tier: web\n",
+				"group_vars/db.yml":  "tier: db\n",
+			},
+			host: "alma",
+			want: nil,
+		},
+		{
+			name: "variables directories in lexical order",
+			files: map[string]string{
+				"host_vars/debian/b.yml":    "tier: b\n",
+				"host_vars/debian/a.yml":    "tier: a\n",
+				"host_vars/debian/.hidden":  "tier: hidden\n",
+				"host_vars/debian/c/d.json": `{"tier": "d"}`,
+			},
+			want: []string{"a", "b", "d"},
+		},
+		{
+			name: "file extensions",
+			files: map[string]string{
+				"group_vars/web":      "tier: plain\n",
+				"group_vars/web.yml":  "tier: yml\n",
+				"group_vars/web.yaml": "tier: yaml\n",
+				"group_vars/web.json": `{"tier": "json"}`,
+			},
+			want: []string{"plain", "yml", "yaml", "json"},
+		},
+		{
+			name: "roles after their dependencies",
+			files: map[string]string{
+				"roles/nginx/defaults/main.yml":  "tier: nginx\n",
+				"roles/nginx/meta/main.yml":      "dependencies:\n  - role: common\n  - name: base\n",
+				"roles/common/defaults/main.yml": "tier: common\n",
+				"roles/common/meta/main.yml":     "dependencies:\n  - base\n",
+				"roles/base/defaults/main.yml":   "tier: base\n",
+			},
+			opts: Options{Roles: []string{"nginx"}},
+			want: []string{"base", "common", "nginx"},
+		},
+		{
+			name:  "extra vars in every form",
+			files: map[string]string{"extra.yml": "tier: file\n"},
+			opts: Options{ExtraVars: []string{
+				"tier=first other=x",
+				`{"tier": "json"}`,
+				"{tier: yaml}",
+				"@extra.yml",
+			}},
+			want: []string{"first", "json", "yaml", "file"},
+		},
+		{
+			name: "lists, mappings and vault values",
+			files: map[string]string{
+				"group_vars/web.yml":     "tier: [a, b]\n",
+				"group_vars/vagrant.yml": "tier:\n  a: 1\n",
+				"host_vars/debian.yml":   "tier: !vault |\n  $ANSIBLE_VAULT;1.1;AES256\n  6162\n",
+				"host_vars/debian.json":  "$ANSIBLE_VAULT;1.1;AES256\n6162\n",
+			},
+			want: []string{"{a: 1}", "[a, b]", "<vault encrypted>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project(t, tt.files)
+
+			host := tt.host
+			if len(host) == 0 {
+				host = "debian"
+			}
+
+			defs, err := Collect(host, tt.opts)
+			if err != nil {
+				t.Fatalf("Collect() error = %v", err)
+			}
+
+			var got []string
+			for _, d := range Explain(defs, "tier") {
+				got = append(got, d.Value)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("tier definitions = %q, want %q", got, tt.want)
+			}
+
+			if explained := Explain(defs, "tier"); len(explained) > 0 && !explained[len(explained)-1].Wins {
+				t.Errorf("the last definition of tier does not win: %+v", explained)
+			}
+		})
+	}
+}
+
+func TestCollectErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		host  string
+		files map[string]string
+		opts  Options
+		want  string
+	}{
+		{
+			name: "unknown host",
+			host: "rocky",
+			want: "'rocky' is not a host in hosts.ini",
+		},
+		{
+			name: "missing role",
+			opts: Options{Roles: []string{"nginx"}},
+			want: "the 'nginx' role can't be found in roles",
+		},
+		{
+			name: "invalid extra var",
+			opts: Options{ExtraVars: []string{"tier"}},
+			want: "invalid extra var 'tier' (expected key=value)",
+		},
+		{
+			name:  "playbook that is not a list of plays",
+			files: map[string]string{"site.yml": "hosts: all\n"},
+			opts:  Options{Playbook: "site.yml"},
+			want:  "'site.yml' is not a list of plays",
+		},
+		{
+			name:  "invalid variables file",
+			files: map[string]string{"host_vars/debian.yml": "tier: [\n"},
+			want:  "unable to parse '" + filepath.Join("host_vars", "debian.yml") + "'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project(t, tt.files)
+
+			host := tt.host
+			if len(host) == 0 {
+				host = "debian"
+			}
+
+			if _, err := Collect(host, tt.opts); err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("Collect() error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Precedence levels, numbered as in the variable precedence list of the
+// Ansible documentation ("Understanding variable precedence"); a higher
+// level overrides a lower one.
+const (
+	RoleDefaults           = 2
+	InventoryFileGroupVars = 3
+	InventoryGroupVarsAll  = 4
+	PlaybookGroupVarsAll   = 5
+	InventoryGroupVars     = 6
+	PlaybookGroupVars      = 7
+	InventoryFileHostVars  = 8
+	InventoryHostVars      = 9
+	PlaybookHostVars       = 10
+	PlayVars               = 12
+	PlayVarsFiles          = 14
+	RoleVars               = 15
+	ExtraVars              = 22
+)
+
+// levelNames describes each precedence level.
+var levelNames = map[int]string{
+	RoleDefaults:           "role defaults",
+	InventoryFileGroupVars: "inventory file group vars",
+	InventoryGroupVarsAll:  "inventory group_vars/all",
+	PlaybookGroupVarsAll:   "playbook group_vars/all",
+	InventoryGroupVars:     "inventory group_vars/*",
+	PlaybookGroupVars:      "playbook group_vars/*",
+	InventoryFileHostVars:  "inventory file host vars",
+	InventoryHostVars:      "inventory host_vars/*",
+	PlaybookHostVars:       "playbook host_vars/*",
+	PlayVars:               "play vars",
+	PlayVarsFiles:          "play vars_files",
+	RoleVars:               "role vars",
+	ExtraVars:              "extra vars",
+}
+
+// Definition is a single definition of a variable.
+//
+// Fields:
+//   - Name:       the variable name.
+//   - Value:      the value as written; lists and mappings are rendered in
+//     YAML flow style.
+//   - Precedence: the precedence level (e.g. [RoleDefaults]).
+//   - Source:     a description of the level, qualified with the role or
+//     group it belongs to, e.g. "role defaults (nginx)".
+//   - File:       the file defining the variable, or "command line".
+//   - Line:       the 1-based line in File, or 0 when unknown.
+//   - Wins:       set on the definition that takes effect.
+type Definition struct {
+	Name       string `json:"name" yaml:"name"`
+	Value      string `json:"value" yaml:"value"`
+	Precedence int    `json:"precedence" yaml:"precedence"`
+	Source     string `json:"source" yaml:"source"`
+	File       string `json:"file" yaml:"file"`
+	Line       int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Wins       bool   `json:"wins" yaml:"wins"`
+}
+
+// Location renders the file and line of the definition, e.g.
+// "host_vars/debian.yml:3".
+func (d Definition) Location() string {
+	if d.Line == 0 {
+		return d.File
+	}
+
+	return fmt.Sprintf("%s:%d", d.File, d.Line)
+}
+
+// Explain returns the definitions of the variable name from defs, lowest
+// precedence first, as returned by [Collect].
+func Explain(defs []Definition, name string) []Definition {
+	var result []Definition
+
+	for _, d := range defs {
+		if d.Name == name {
+			result = append(result, d)
+		}
+	}
+
+	return result
+}
+
+// Merge returns the winning definition of each variable in defs, sorted by
+// variable name.
+func Merge(defs []Definition) []Definition {
+	var result []Definition
+
+	for _, d := range defs {
+		if d.Wins {
+			result = append(result, d)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
+
+// source describes a precedence level, qualified with the role or group
+// it belongs to when given.
+func source(level int, qualifier string) string {
+	if len(qualifier) == 0 {
+		return levelNames[level]
+	}
+
+	return fmt.Sprintf("%s (%s)", levelNames[level], qualifier)
+}
@@ -0,0 +1,28 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package variables finds every definition of the variables that apply to
+// a host of the development environment — role defaults, inventory group
+// and host variables, group_vars and host_vars files, play variables, role
+// variables and extra variables — and orders them by Ansible's variable
+// precedence, so that the value a play would see can be explained without
+// running it.
+//
+// Only definitions that can be read statically are considered: facts,
+// registered variables, set_fact, include_vars and task or block variables
+// are not, and Jinja2 expressions are shown as written rather than
+// evaluated.
+package variables
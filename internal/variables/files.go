@@ -0,0 +1,156 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// readVarsFile reads the top-level variables of a YAML or JSON variables
+// file, in file order, as definitions of the given level and source. A
+// missing file defines nothing. Files encrypted with ansible-vault cannot
+// be read and define nothing either; values encrypted inline with the
+// !vault tag are reported as "<vault encrypted>".
+func readVarsFile(path string, level int, src string) ([]Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if bytes.HasPrefix(data, []byte("$ANSIBLE_VAULT")) {
+		return nil, nil
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", path, err)
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+
+	return mappingDefinitions(doc.Content[0], level, src, path), nil
+}
+
+// mappingDefinitions returns the keys of a mapping node as definitions.
+func mappingDefinitions(node *yaml.Node, level int, src, file string) []Definition {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	var defs []Definition
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		defs = append(defs, Definition{
+			Name:       node.Content[i].Value,
+			Value:      render(node.Content[i+1]),
+			Precedence: level,
+			Source:     src,
+			File:       file,
+			Line:       node.Content[i].Line,
+		})
+	}
+
+	return defs
+}
+
+// render returns a scalar value as written and renders lists and mappings
+// in YAML flow style.
+func render(node *yaml.Node) string {
+	if node.Tag == "!vault" {
+		return "<vault encrypted>"
+	}
+
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+
+	flow := *node
+	flow.Style = yaml.FlowStyle
+
+	data, err := yaml.Marshal(&flow)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// varsFiles returns the variables files Ansible loads for name from a
+// group_vars or host_vars directory: "<name>", "<name>.yml", "<name>.yaml"
+// and "<name>.json", or when "<name>" is a directory every file below it
+// in lexical order.
+func varsFiles(dir, name string) []string {
+	base := filepath.Join(dir, name)
+
+	if info, err := os.Stat(base); err == nil && info.IsDir() {
+		var files []string
+
+		_ = filepath.WalkDir(base, func(path string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() && !strings.HasPrefix(d.Name(), ".") {
+				files = append(files, path)
+			}
+
+			return nil
+		})
+
+		sort.Strings(files)
+
+		return files
+	}
+
+	var files []string
+
+	for _, ext := range []string{"", ".yml", ".yaml", ".json"} {
+		if info, err := os.Stat(base + ext); err == nil && !info.IsDir() {
+			files = append(files, base+ext)
+		}
+	}
+
+	return files
+}
+
+// readVarsFiles reads every variables file of name below dir; see
+// [varsFiles].
+func readVarsFiles(dir, name string, level int, src string) ([]Definition, error) {
+	var defs []Definition
+
+	for _, file := range varsFiles(dir, name) {
+		d, err := readVarsFile(file, level, src)
+		if err != nil {
+			return nil, err
+		}
+
+		defs = append(defs, d...)
+	}
+
+	return defs, nil
+}
@@ -0,0 +1,97 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// playbook holds what a playbook contributes to the variables of a host.
+//
+// Fields:
+//   - vars:      the vars of every play.
+//   - varsFiles: the variables of every static vars_files entry.
+//   - roles:     the roles applied by every play.
+type playbook struct {
+	vars      []Definition
+	varsFiles []Definition
+	roles     []string
+}
+
+// readPlaybook reads the plays of the playbook at path. vars_files entries
+// are resolved relative to the playbook; entries containing a Jinja2
+// expression cannot be resolved statically and are skipped.
+func readPlaybook(path string) (playbook, error) {
+	var pb playbook
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return pb, err
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return pb, fmt.Errorf("unable to parse '%s': %w", path, err)
+	}
+
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.SequenceNode {
+		return pb, fmt.Errorf("'%s' is not a list of plays", path)
+	}
+
+	for _, play := range doc.Content[0].Content {
+		for i := 0; i+1 < len(play.Content); i += 2 {
+			value := play.Content[i+1]
+
+			switch play.Content[i].Value {
+			case "vars":
+				pb.vars = append(pb.vars, mappingDefinitions(value, PlayVars, source(PlayVars, ""), path)...)
+			case "vars_files":
+				for _, file := range value.Content {
+					if file.Kind != yaml.ScalarNode || strings.Contains(file.Value, "{{") {
+						continue
+					}
+
+					name := file.Value
+					if !filepath.IsAbs(name) {
+						name = filepath.Join(filepath.Dir(path), name)
+					}
+
+					defs, err := readVarsFile(name, PlayVarsFiles, source(PlayVarsFiles, ""))
+					if err != nil {
+						return pb, err
+					}
+
+					pb.varsFiles = append(pb.varsFiles, defs...)
+				}
+			case "roles":
+				for _, role := range value.Content {
+					if name := roleName(role); len(name) > 0 {
+						pb.roles = append(pb.roles, name)
+					}
+				}
+			}
+		}
+	}
+
+	return pb, nil
+}
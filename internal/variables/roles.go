@@ -0,0 +1,142 @@
+/*
+Copyright © 2026 Julian Easterling
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dcjulian29/ansible-dev/internal/ansible"
+	"gopkg.in/yaml.v3"
+)
+
+// expandRoles returns roles preceded by their dependencies, as declared
+// in meta/main.yml, recursively and without duplicates, which is the order
+// in which Ansible loads them. Every role is resolved with
+// [ansible.RoleFolder]; an error is returned if one cannot be found.
+func expandRoles(roles []string) ([]string, error) {
+	var ordered []string
+
+	var visit func(role string, path []string) error
+
+	visit = func(role string, path []string) error {
+		if slices.Contains(ordered, role) || slices.Contains(path, role) {
+			return nil
+		}
+
+		folder, err := ansible.RoleFolder(role)
+		if err != nil {
+			return err
+		}
+
+		if info, err := os.Stat(folder); err != nil || !info.IsDir() {
+			return fmt.Errorf("the '%s' role can't be found in %s", role, filepath.Dir(folder))
+		}
+
+		for _, dep := range roleDependencies(folder) {
+			if err := visit(dep, append(path, role)); err != nil {
+				return err
+			}
+		}
+
+		ordered = append(ordered, role)
+
+		return nil
+	}
+
+	for _, role := range roles {
+		if err := visit(role, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
+
+// roleDependencies returns the names of the roles listed as dependencies
+// in the meta/main.yml file of the role in folder.
+func roleDependencies(folder string) []string {
+	data, err := os.ReadFile(filepath.Join(folder, "meta", "main.yml"))
+	if err != nil {
+		return nil
+	}
+
+	var meta struct {
+		Dependencies []yaml.Node `yaml:"dependencies"`
+	}
+
+	if err := yaml.Unmarshal(data, &meta); err != nil {
+		return nil
+	}
+
+	var deps []string
+
+	for _, dep := range meta.Dependencies {
+		if name := roleName(&dep); len(name) > 0 {
+			deps = append(deps, name)
+		}
+	}
+
+	return deps
+}
+
+// roleName returns the name of a role reference, written either as a
+// plain name or as a mapping with a "role" or "name" key.
+func roleName(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+
+	for _, key := range []string{"role", "name"} {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				return node.Content[i+1].Value
+			}
+		}
+	}
+
+	return ""
+}
+
+// roleDefinitions returns the variables of the given roles from their
+// defaults or vars directory, depending on level.
+func roleDefinitions(roles []string, level int) ([]Definition, error) {
+	dir := "defaults"
+	if level == RoleVars {
+		dir = "vars"
+	}
+
+	var defs []Definition
+
+	for _, role := range roles {
+		folder, err := ansible.RoleFolder(role)
+		if err != nil {
+			return nil, err
+		}
+
+		d, err := readVarsFiles(filepath.Join(folder, dir), "main", level, source(level, role))
+		if err != nil {
+			return nil, err
+		}
+
+		defs = append(defs, d...)
+	}
+
+	return defs, nil
+}